@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/origin-ca-issuer/cmd/controller/check"
+	"github.com/cloudflare/origin-ca-issuer/cmd/controller/options"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"github.com/cloudflare/origin-ca-issuer/internal/credfile"
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+// runCheck runs the preflight checks of a controller configured with args, the same flags as the
+// controller, writing a report to stdout. It returns the exit code of the check subcommand, which
+// is non-zero when a check fails.
+func runCheck(args []string, stdout, stderr io.Writer) int {
+	fs := pflag.NewFlagSet("check", pflag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	o := options.NewControllerOptions()
+	o.AddFlags(fs)
+	serviceAccount := fs.String("service-account", "", "Service account of the controller, as namespace/name, whose access is reviewed. Defaults to the current user, such as when run from within the controller pod.")
+	timeout := fs.Duration("timeout", time.Minute, "Timeout of the checks.")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+
+		return 2
+	}
+
+	if err := o.Load(fs, os.LookupEnv); err != nil {
+		fmt.Fprintf(stderr, "error loading options: %v\n", err)
+		return 1
+	}
+
+	report := &check.Report{}
+	defer func() {
+		_ = report.Write(stdout)
+	}()
+
+	if ns, name, ok := strings.Cut(*serviceAccount, "/"); *serviceAccount != "" && (!ok || ns == "" || name == "") {
+		report.Add("Options", check.StatusFailed, "invalid value for service-account: %q must be namespace/name", *serviceAccount)
+		return 1
+	}
+
+	if err := o.Validate(); err != nil {
+		report.Add("Options", check.StatusFailed, "%v", err)
+		return 1
+	}
+	report.Add("Options", check.StatusOK, "options are valid")
+
+	checker, err := newChecker(o, *serviceAccount)
+	if err != nil {
+		report.Add("Setup", check.StatusFailed, "%v", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	checker.Run(ctx, report)
+	if report.Failed() {
+		return 1
+	}
+
+	return 0
+}
+
+// newChecker returns a checker using the kubeconfig of the controller.
+func newChecker(o *options.ControllerOptions, serviceAccount string) (*check.Checker, error) {
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeCfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not load kubeconfig: %w", err)
+	}
+
+	c, err := client.New(kubeCfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("could not create client: %w", err)
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(kubeCfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create discovery client: %w", err)
+	}
+
+	files, err := credfile.NewStore(o.CredentialDirectories, logr.Discard())
+	if err != nil {
+		return nil, fmt.Errorf("could not create credential file store: %w", err)
+	}
+
+	hc := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	return &check.Checker{
+		Options:        o,
+		Client:         c,
+		Discovery:      dc,
+		ServiceAccount: serviceAccount,
+		Builder:        cfapi.NewBuilder().WithClient(hc).WithUserAgent(readBuildInfo().userAgent()),
+		Files:          files,
+	}, nil
+}
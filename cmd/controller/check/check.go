@@ -0,0 +1,504 @@
+// Package check implements preflight checks of a controller deployment, ensuring
+// the APIs, namespaces, permissions and issuer credentials the controller relies
+// on are available before it is started.
+package check
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	certmanager "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cloudflare/origin-ca-issuer/cmd/controller/options"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"github.com/cloudflare/origin-ca-issuer/internal/credfile"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/controllers"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusWarning Status = "warn"
+	StatusFailed  Status = "FAIL"
+)
+
+// Result is the outcome of a single check.
+type Result struct {
+	Section string
+	Status  Status
+	Message string
+}
+
+// Report collects the results of the checks.
+type Report struct {
+	Results []Result
+}
+
+// Add records the result of a check in section.
+func (r *Report) Add(section string, status Status, format string, args ...any) {
+	r.Results = append(r.Results, Result{Section: section, Status: status, Message: fmt.Sprintf(format, args...)})
+}
+
+// Failed reports whether any check failed.
+func (r *Report) Failed() bool {
+	return slices.ContainsFunc(r.Results, func(res Result) bool { return res.Status == StatusFailed })
+}
+
+// Write writes the results to w, grouped by section, followed by a summary.
+func (r *Report) Write(w io.Writer) error {
+	var b strings.Builder
+	failed, warnings := 0, 0
+
+	for i, res := range r.Results {
+		if i == 0 || r.Results[i-1].Section != res.Section {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "%s\n", res.Section)
+		}
+
+		fmt.Fprintf(&b, "  %-4s  %s\n", res.Status, res.Message)
+
+		switch res.Status {
+		case StatusFailed:
+			failed++
+		case StatusWarning:
+			warnings++
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%d checks, %d failed, %d warnings\n", len(r.Results), failed, warnings)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Sections of the report.
+const (
+	sectionAPI         = "API resources"
+	sectionNamespaces  = "Namespaces"
+	sectionAccess      = "Access"
+	sectionCredentials = "Issuer credentials"
+)
+
+// inClusterNamespaceFile holds the namespace of the pod, when run within a cluster.
+var inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// apiResources are the resources the controllers read and write, served by CRDs or Kubernetes.
+var apiResources = []schema.GroupVersionResource{
+	v1.GroupVersion.WithResource("originissuers"),
+	v1.GroupVersion.WithResource("clusteroriginissuers"),
+	v1.GroupVersion.WithResource("origincertificates"),
+	v1.GroupVersion.WithResource("origininventories"),
+	v1.GroupVersion.WithResource("origincertificaterecords"),
+	certmanager.SchemeGroupVersion.WithResource("certificaterequests"),
+	certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
+}
+
+// Checker checks that a controller configured with Options is able to run.
+type Checker struct {
+	Options   *options.ControllerOptions
+	Client    client.Client
+	Discovery discovery.DiscoveryInterface
+
+	// ServiceAccount is the namespace/name of the service account of the controller, whose
+	// access is reviewed. If empty, the access of the current user is reviewed.
+	ServiceAccount string
+
+	// Builder builds the Cloudflare API clients issuer credentials are verified with.
+	Builder *cfapi.Builder
+
+	// Files reads credential files referenced by issuers.
+	Files *credfile.Store
+}
+
+// Run runs every check, recording the results in r.
+func (c *Checker) Run(ctx context.Context, r *Report) {
+	issuers, err := c.issuers(ctx)
+
+	c.checkAPIResources(r)
+	c.checkNamespaces(ctx, r)
+	// Access to Secrets is reviewed as if issuers referenced them when they could not be listed.
+	c.checkAccess(ctx, r, err != nil || secretCredentials(issuers))
+	c.checkCredentials(ctx, r, issuers, err)
+}
+
+// checkAPIResources ensures the resources of the enabled controllers are served.
+func (c *Checker) checkAPIResources(r *Report) {
+	served := map[string]*metav1.APIResourceList{}
+	errs := map[string]error{}
+
+	for _, gvr := range apiResources {
+		if !required(c.Options, false, gvr.Group, gvr.Resource, "get") {
+			continue
+		}
+
+		gv := gvr.GroupVersion().String()
+		if _, ok := served[gv]; !ok && errs[gv] == nil {
+			served[gv], errs[gv] = c.Discovery.ServerResourcesForGroupVersion(gv)
+		}
+
+		name := gvr.GroupResource().String()
+		if err := errs[gv]; err != nil {
+			r.Add(sectionAPI, StatusFailed, "%s is not served: API %s is unavailable: %v", name, gv, err)
+			continue
+		}
+
+		if !slices.ContainsFunc(served[gv].APIResources, func(res metav1.APIResource) bool { return res.Name == gvr.Resource }) {
+			r.Add(sectionAPI, StatusFailed, "%s is not served by API %s; are the CRDs installed?", name, gv)
+			continue
+		}
+
+		r.Add(sectionAPI, StatusOK, "%s is served", name)
+	}
+}
+
+// checkNamespaces ensures the namespaces set in the options exist.
+func (c *Checker) checkNamespaces(ctx context.Context, r *Report) {
+	o := c.Options
+
+	type namespace struct{ name, flag string }
+	var namespaces []namespace
+
+	if !o.DisableClusterOriginIssuer {
+		namespaces = append(namespaces, namespace{o.ClusterResourceNamespace, "cluster-resource-namespace"})
+		for _, ns := range o.ClusterSecretNamespaces {
+			namespaces = append(namespaces, namespace{ns, "cluster-secret-namespaces"})
+		}
+	}
+	for _, ns := range o.Namespaces {
+		namespaces = append(namespaces, namespace{ns, "namespaces"})
+	}
+	if o.LeaderElect && o.LeaderElectionNamespace != "" {
+		namespaces = append(namespaces, namespace{o.LeaderElectionNamespace, "leader-election-namespace"})
+	}
+
+	seen := map[string]bool{}
+	for _, ns := range namespaces {
+		if seen[ns.name] {
+			continue
+		}
+		seen[ns.name] = true
+
+		err := c.Client.Get(ctx, client.ObjectKey{Name: ns.name}, &corev1.Namespace{})
+		switch {
+		case apierrors.IsNotFound(err):
+			r.Add(sectionNamespaces, StatusFailed, "namespace %s, set by --%s, does not exist", ns.name, ns.flag)
+		case err != nil:
+			r.Add(sectionNamespaces, StatusWarning, "could not verify namespace %s, set by --%s, exists: %v", ns.name, ns.flag, err)
+		default:
+			r.Add(sectionNamespaces, StatusOK, "namespace %s exists", ns.name)
+		}
+	}
+}
+
+// checkAccess reviews whether the controller is allowed every verb declared in the RBAC markers of
+// the enabled controllers. Reading Secrets is only reviewed if secretCredentials is set.
+func (c *Checker) checkAccess(ctx context.Context, r *Report, secretCredentials bool) {
+	rules, err := rules()
+	if err != nil {
+		r.Add(sectionAccess, StatusFailed, "%v", err)
+		return
+	}
+
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, namespace := range c.namespaces(resource) {
+					for _, name := range orEmpty(rule.ResourceNames) {
+						c.reviewAccess(ctx, r, group, resource, resourceName(c.Options, group, resource, name), namespace, rule.Verbs, secretCredentials)
+					}
+				}
+			}
+		}
+	}
+}
+
+// reviewAccess reviews whether the controller is allowed verbs on a resource, recording a single
+// result for all of them.
+func (c *Checker) reviewAccess(ctx context.Context, r *Report, group, resource, name, namespace string, verbs []string, secretCredentials bool) {
+	var allowed, denied []string
+	res, sub, _ := strings.Cut(resource, "/")
+
+	for _, verb := range verbs {
+		if !required(c.Options, secretCredentials, group, resource, verb) {
+			continue
+		}
+
+		ok, err := c.allowed(ctx, &authorizationv1.ResourceAttributes{
+			Namespace:   namespace,
+			Verb:        verb,
+			Group:       group,
+			Resource:    res,
+			Subresource: sub,
+			Name:        name,
+		})
+		if err != nil {
+			r.Add(sectionAccess, StatusFailed, "could not review access to %s %s: %v", verb, resource, err)
+			return
+		}
+
+		if ok {
+			allowed = append(allowed, verb)
+		} else {
+			denied = append(denied, verb)
+		}
+	}
+
+	target := schema.GroupResource{Group: group, Resource: res}.String()
+	if sub != "" {
+		target += "/" + sub
+	}
+	if name != "" {
+		target += " " + name
+	}
+	if namespace != "" {
+		target += " in namespace " + namespace
+	}
+
+	switch {
+	case len(denied) > 0:
+		r.Add(sectionAccess, StatusFailed, "%s is not allowed to %s %s", c.subject(), strings.Join(denied, ", "), target)
+	case len(allowed) > 0:
+		r.Add(sectionAccess, StatusOK, "%s %s", strings.Join(allowed, ", "), target)
+	}
+}
+
+// namespaces returns the namespaces access to resource is reviewed in. The empty namespace reviews
+// access across all namespaces.
+func (c *Checker) namespaces(resource string) []string {
+	o := c.Options
+	resource, _, _ = strings.Cut(resource, "/")
+
+	switch {
+	case clusterScoped[resource]:
+		return []string{""}
+	case resource == "leases":
+		return []string{c.leaderElectionNamespace()}
+	case len(o.Namespaces) == 0:
+		return []string{""}
+	}
+
+	namespaces := slices.Clone(o.Namespaces)
+	if !o.DisableClusterOriginIssuer && (resource == "secrets" || resource == "configmaps") {
+		namespaces = append(namespaces, o.ClusterResourceNamespace)
+		if resource == "secrets" {
+			namespaces = append(namespaces, o.ClusterSecretNamespaces...)
+		}
+	}
+
+	slices.Sort(namespaces)
+
+	return slices.Compact(namespaces)
+}
+
+// leaderElectionNamespace returns the namespace of the leader election Lease. Like the controller, it
+// defaults to the namespace the controller runs in, that of the reviewed service account or, when
+// run from within the controller pod, that of the pod.
+func (c *Checker) leaderElectionNamespace() string {
+	if ns := c.Options.LeaderElectionNamespace; ns != "" {
+		return ns
+	}
+
+	if ns, _, ok := strings.Cut(c.ServiceAccount, "/"); ok {
+		return ns
+	}
+
+	if b, err := os.ReadFile(inClusterNamespaceFile); err == nil {
+		return strings.TrimSpace(string(b))
+	}
+
+	return ""
+}
+
+// orEmpty returns s, or a slice of the empty string if s is empty.
+func orEmpty(s []string) []string {
+	if len(s) == 0 {
+		return []string{""}
+	}
+
+	return s
+}
+
+// subject describes whose access is reviewed.
+func (c *Checker) subject() string {
+	if c.ServiceAccount == "" {
+		return "the current user"
+	}
+
+	return "service account " + c.ServiceAccount
+}
+
+// allowed reviews whether the controller is allowed access to attrs.
+func (c *Checker) allowed(ctx context.Context, attrs *authorizationv1.ResourceAttributes) (bool, error) {
+	if c.ServiceAccount == "" {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attrs},
+		}
+		if err := c.Client.Create(ctx, review); err != nil {
+			return false, err
+		}
+
+		return review.Status.Allowed, nil
+	}
+
+	namespace, name, _ := strings.Cut(c.ServiceAccount, "/")
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attrs,
+			User:               fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+			Groups:             []string{"system:serviceaccounts", "system:serviceaccounts:" + namespace, "system:authenticated"},
+		},
+	}
+	if err := c.Client.Create(ctx, review); err != nil {
+		return false, err
+	}
+
+	return review.Status.Allowed, nil
+}
+
+// issuer identifies an issuer whose credentials are checked.
+type issuer struct {
+	kind      string
+	namespace string
+	name      string
+	spec      v1.OriginIssuerSpec
+}
+
+func (i issuer) String() string {
+	if i.namespace == "" {
+		return i.kind + " " + i.name
+	}
+
+	return i.kind + " " + i.namespace + "/" + i.name
+}
+
+// issuers lists the issuers whose credentials are checked.
+func (c *Checker) issuers(ctx context.Context) ([]issuer, error) {
+	o := c.Options
+
+	var issuers []issuer
+	for _, namespace := range orEmpty(o.Namespaces) {
+		var list v1.OriginIssuerList
+		if err := c.Client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("could not list OriginIssuers: %w", err)
+		}
+
+		for _, iss := range list.Items {
+			issuers = append(issuers, issuer{"OriginIssuer", iss.Namespace, iss.Name, iss.Spec})
+		}
+	}
+
+	if !o.DisableClusterOriginIssuer {
+		var list v1.ClusterOriginIssuerList
+		if err := c.Client.List(ctx, &list); err != nil {
+			return nil, fmt.Errorf("could not list ClusterOriginIssuers: %w", err)
+		}
+
+		for _, iss := range list.Items {
+			issuers = append(issuers, issuer{"ClusterOriginIssuer", "", iss.Name, iss.Spec})
+		}
+	}
+
+	return issuers, nil
+}
+
+// secretCredentials reports whether any of issuers reference credentials stored in Secrets.
+func secretCredentials(issuers []issuer) bool {
+	for _, iss := range issuers {
+		for _, cred := range controllers.IssuerCredentials(iss.spec.Auth) {
+			if cred.TokenRef != nil || cred.ServiceKeyRef != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// checkCredentials ensures every credential of issuers can be read, verifying API tokens with
+// the Cloudflare API. err is the error listing the issuers, if any.
+func (c *Checker) checkCredentials(ctx context.Context, r *Report, issuers []issuer, err error) {
+	if err != nil {
+		r.Add(sectionCredentials, StatusFailed, "%v", err)
+		return
+	}
+
+	if len(issuers) == 0 {
+		r.Add(sectionCredentials, StatusWarning, "no issuers were found")
+		return
+	}
+
+	for _, iss := range issuers {
+		if c.Options.LocalSigning || (iss.spec.SigningMode == v1.SigningModeLocal && c.Options.AllowLocalSigningMode) {
+			r.Add(sectionCredentials, StatusOK, "%s signs certificates with the local CA", iss)
+			continue
+		}
+
+		if iss.spec.SigningMode == v1.SigningModeLocal {
+			r.Add(sectionCredentials, StatusFailed, "%s is in the Local signing mode, which requires --allow-local-signing-mode", iss)
+			continue
+		}
+
+		creds := controllers.IssuerCredentials(iss.spec.Auth)
+		if len(creds) == 0 {
+			r.Add(sectionCredentials, StatusFailed, "%s does not have an authentication method configured", iss)
+			continue
+		}
+
+		for _, cred := range creds {
+			c.checkCredential(ctx, r, iss, cred)
+		}
+	}
+}
+
+// checkCredential reads cred of iss, verifying it with the Cloudflare API if it is an API token.
+// Service keys can only be verified by signing a certificate, so they are only read.
+func (c *Checker) checkCredential(ctx context.Context, r *Report, iss issuer, cred v1.OriginIssuerCredential) {
+	o := c.Options
+	name := cmp.Or(cred.Name, "spec.auth")
+
+	api, _, err := controllers.IssuerClient(ctx, c.Client, c.Builder, controllers.IssuerClientConfig{
+		Kind:                     iss.kind,
+		Name:                     iss.name,
+		Namespace:                iss.namespace,
+		Credential:               cred.Name,
+		ClusterResourceNamespace: o.ClusterResourceNamespace,
+		ClusterSecretNamespaces:  o.ClusterSecretNamespaces,
+		Files:                    c.Files,
+	})
+	if err != nil {
+		r.Add(sectionCredentials, StatusFailed, "%s credential %s could not be read: %v", iss, name, err)
+		return
+	}
+
+	if cred.ServiceKeyRef != nil || cred.ServiceKeyFile != "" {
+		r.Add(sectionCredentials, StatusOK, "%s credential %s is a service key, and was read", iss, name)
+		return
+	}
+
+	status, err := api.VerifyToken(ctx)
+	switch {
+	case err != nil:
+		r.Add(sectionCredentials, StatusFailed, "%s credential %s could not be verified: %v", iss, name, err)
+	case status.Status != "active":
+		r.Add(sectionCredentials, StatusFailed, "%s credential %s is %s", iss, name, status.Status)
+	default:
+		r.Add(sectionCredentials, StatusOK, "%s credential %s is an active API token", iss, name)
+	}
+}
@@ -0,0 +1,321 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	certmanager "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cloudflare/origin-ca-issuer/cmd/controller/options"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestCheckerRun(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/client/v4/user/tokens/verify")
+
+		status := "active"
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			status = "disabled"
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"result":  map[string]string{"id": "token", "status": status},
+		})
+	}))
+	defer ts.Close()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.AddToScheme(scheme)
+
+	var reviews []authorizationv1.ResourceAttributes
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "origin-ca-issuer"}},
+			&v1.OriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginECC,
+					Auth: v1.OriginIssuerAuthentication{
+						TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+						Credentials: []v1.OriginIssuerCredential{
+							{Name: "disabled", TokenRef: &v1.SecretKeySelector{Name: "token", Key: "disabled"}},
+							{Name: "service-key", ServiceKeyRef: &v1.SecretKeySelector{Name: "token", Key: "key"}},
+						},
+					},
+				},
+			},
+			&v1.ClusterOriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginECC,
+					Auth: v1.OriginIssuerAuthentication{
+						TokenRef: &v1.SecretKeySelector{Name: "missing", Key: "token"},
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+				Data: map[string][]byte{
+					"token":    []byte("valid-token"),
+					"disabled": []byte("disabled-token"),
+					"key":      []byte("v1.0-service-key"),
+				},
+			},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+
+				assert.Equal(t, review.Spec.User, "system:serviceaccount:origin-ca-issuer:origin-ca-issuer")
+
+				attrs := *review.Spec.ResourceAttributes
+				reviews = append(reviews, attrs)
+				review.Status.Allowed = !(attrs.Resource == "secrets" && attrs.Verb == "watch")
+
+				return nil
+			},
+		}).
+		Build()
+
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metav1.APIResourceList{
+			{
+				GroupVersion: v1.GroupVersion.String(),
+				APIResources: []metav1.APIResource{{Name: "originissuers"}, {Name: "originissuers/status"}},
+			},
+		},
+	}}
+
+	o := options.NewControllerOptions()
+	o.ClusterResourceNamespace = "origin-ca-issuer"
+	o.ClusterSecretNamespaces = []string{"shared"}
+	o.LeaderElect = false
+
+	checker := &Checker{
+		Options:        o,
+		Client:         c,
+		Discovery:      dc,
+		ServiceAccount: "origin-ca-issuer/origin-ca-issuer",
+		Builder:        cfapi.NewBuilder().WithEndpoint(ts.URL + "/client/v4/certificates"),
+	}
+
+	report := &Report{}
+	checker.Run(context.Background(), report)
+
+	var failed []string
+	for _, res := range report.Results {
+		if res.Status == StatusFailed {
+			failed = append(failed, res.Section+": "+res.Message)
+		}
+	}
+
+	assert.DeepEqual(t, failed, []string{
+		"API resources: clusteroriginissuers.cert-manager.k8s.cloudflare.com is not served by API cert-manager.k8s.cloudflare.com/v1; are the CRDs installed?",
+		`API resources: certificaterequests.cert-manager.io is not served: API cert-manager.io/v1 is unavailable: the server could not find the requested resource, GroupVersion "cert-manager.io/v1" not found`,
+		"Namespaces: namespace shared, set by --cluster-secret-namespaces, does not exist",
+		"Access: service account origin-ca-issuer/origin-ca-issuer is not allowed to watch secrets",
+		"Issuer credentials: OriginIssuer default/foobar credential disabled is disabled",
+		`Issuer credentials: ClusterOriginIssuer cluster credential spec.auth could not be read: secrets "missing" not found`,
+	})
+	assert.Assert(t, report.Failed())
+
+	// Disabled controllers are not reviewed.
+	for _, attrs := range reviews {
+		switch attrs.Resource {
+		case "origincertificates", "origininventories", "origincertificaterecords", "certificatesigningrequests", "signers", "subjectaccessreviews", "leases":
+			t.Errorf("unexpected review of %s %s", attrs.Verb, attrs.Resource)
+		case "secrets":
+			assert.Assert(t, attrs.Verb != "create" && attrs.Verb != "update", "unexpected review of %s secrets", attrs.Verb)
+		}
+	}
+
+	var b strings.Builder
+	assert.NilError(t, report.Write(&b))
+	assert.Assert(t, strings.Contains(b.String(), "Issuer credentials\n  ok    OriginIssuer default/foobar credential spec.auth is an active API token\n"))
+	assert.Assert(t, strings.HasSuffix(b.String(), ", 6 failed, 0 warnings\n"))
+}
+
+func TestRequired(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(o *options.ControllerOptions)
+		secrets  bool
+		group    string
+		resource string
+		verb     string
+		required bool
+	}{
+		{name: "origin issuers", group: v1.GroupVersion.Group, resource: "originissuers/status", verb: "update", required: true},
+		{name: "disabled cluster issuers", modify: func(o *options.ControllerOptions) { o.DisableClusterOriginIssuer = true }, group: v1.GroupVersion.Group, resource: "clusteroriginissuers", verb: "get"},
+		{name: "certificate requests", group: certmanager.SchemeGroupVersion.Group, resource: "certificaterequests", verb: "update", required: true},
+		{name: "secret credentials", secrets: true, group: "", resource: "secrets", verb: "watch", required: true},
+		{name: "file credentials", group: "", resource: "secrets", verb: "get"},
+		{name: "origin certificate secrets are watched", modify: func(o *options.ControllerOptions) { o.EnableOriginCertificates = true }, group: "", resource: "secrets", verb: "watch", required: true},
+		{name: "local CA secret is read", modify: func(o *options.ControllerOptions) { o.LocalSigning = true }, group: "", resource: "secrets", verb: "get", required: true},
+		{name: "inventory lists secrets", modify: func(o *options.ControllerOptions) { o.EnableInventory = true }, group: "", resource: "secrets", verb: "list", required: true},
+		{name: "secrets create", group: "", resource: "secrets", verb: "create"},
+		{name: "origin certificate secrets", modify: func(o *options.ControllerOptions) { o.EnableOriginCertificates = true }, group: "", resource: "secrets", verb: "create", required: true},
+		{name: "local CA secret", modify: func(o *options.ControllerOptions) { o.LocalSigning = true }, group: "", resource: "secrets", verb: "create", required: true},
+		{name: "local CA secret is not updated", modify: func(o *options.ControllerOptions) { o.LocalSigning = true }, group: "", resource: "secrets", verb: "update"},
+		{name: "inventory reads signing requests from the cache", modify: func(o *options.ControllerOptions) { o.EnableInventory = true }, group: "certificates.k8s.io", resource: "certificatesigningrequests", verb: "list"},
+		{name: "signing requests", modify: func(o *options.ControllerOptions) { o.EnableCertificateSigningRequests = true }, group: "certificates.k8s.io", resource: "certificatesigningrequests", verb: "watch", required: true},
+		{name: "approver", modify: func(o *options.ControllerOptions) { o.EnableApprover = true }, group: certmanager.SchemeGroupVersion.Group, resource: "signers", verb: "approve", required: true},
+		{name: "disabled approver", group: certmanager.SchemeGroupVersion.Group, resource: "signers", verb: "approve"},
+		{name: "leader election", modify: func(o *options.ControllerOptions) { o.LeaderElect = true }, group: "coordination.k8s.io", resource: "leases", verb: "create", required: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			o := options.NewControllerOptions()
+			if tt.modify != nil {
+				tt.modify(o)
+			}
+
+			assert.Equal(t, required(o, tt.secrets, tt.group, tt.resource, tt.verb), tt.required)
+		})
+	}
+}
+
+func TestCheckerRunWithoutSecretCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.AddToScheme(scheme)
+
+	var reviews []authorizationv1.ResourceAttributes
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&v1.OriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginECC,
+					SigningMode: v1.SigningModeLocal,
+				},
+			},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review := obj.(*authorizationv1.SubjectAccessReview)
+				reviews = append(reviews, *review.Spec.ResourceAttributes)
+				review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "secrets"
+
+				return nil
+			},
+		}).
+		Build()
+
+	o := options.NewControllerOptions()
+	o.DisableClusterOriginIssuer = true
+	o.LeaderElect = false
+
+	checker := &Checker{
+		Options:        o,
+		Client:         c,
+		Discovery:      &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}},
+		ServiceAccount: "origin-ca-issuer/origin-ca-issuer",
+	}
+
+	report := &Report{}
+	checker.Run(context.Background(), report)
+
+	// Secrets are not read when no issuer references them, such as with global.rbac.secrets=false.
+	for _, attrs := range reviews {
+		assert.Assert(t, attrs.Resource != "secrets", "unexpected review of %s secrets", attrs.Verb)
+	}
+
+	for _, res := range report.Results {
+		assert.Assert(t, res.Section != sectionAccess || res.Status == StatusOK, res.Message)
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	o := options.NewControllerOptions()
+	o.CSRSignerDomain = "example.com"
+
+	assert.Equal(t, resourceName(o, "certificates.k8s.io", "signers", "originissuers.cert-manager.k8s.cloudflare.com/*"), "originissuers.example.com/*")
+	assert.Equal(t, resourceName(o, "certificates.k8s.io", "signers", "clusteroriginissuers.cert-manager.k8s.cloudflare.com/*"), "clusteroriginissuers.example.com/*")
+	assert.Equal(t, resourceName(o, "", "secrets", "local-ca"), "local-ca")
+}
+
+func TestCheckerRunLeaderElection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.AddToScheme(scheme)
+
+	tests := []struct {
+		name      string
+		namespace string
+		want      string
+	}{
+		{name: "namespace of the service account", want: "origin-ca-issuer"},
+		{name: "leader election namespace", namespace: "leases", want: "leases"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var leases []authorizationv1.ResourceAttributes
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						review := obj.(*authorizationv1.SubjectAccessReview)
+						attrs := review.Spec.ResourceAttributes
+						if attrs.Resource == "leases" {
+							leases = append(leases, *attrs)
+						}
+						// Leases are only granted in the namespace of the leader election Role.
+						review.Status.Allowed = attrs.Resource != "leases" || attrs.Namespace == tt.want
+
+						return nil
+					},
+				}).
+				Build()
+
+			o := options.NewControllerOptions()
+			o.LeaderElect = true
+			o.LeaderElectionNamespace = tt.namespace
+
+			checker := &Checker{
+				Options:        o,
+				Client:         c,
+				Discovery:      &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}},
+				ServiceAccount: "origin-ca-issuer/origin-ca-issuer",
+			}
+
+			report := &Report{}
+			checker.Run(context.Background(), report)
+
+			assert.Assert(t, len(leases) > 0)
+			for _, attrs := range leases {
+				assert.Equal(t, attrs.Namespace, tt.want)
+			}
+			for _, res := range report.Results {
+				assert.Assert(t, res.Section != sectionAccess || res.Status == StatusOK, res.Message)
+			}
+		})
+	}
+}
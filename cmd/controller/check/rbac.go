@@ -0,0 +1,96 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/origin-ca-issuer/cmd/controller/options"
+	"github.com/cloudflare/origin-ca-issuer/deploy/rbac"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/controllers"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// rules returns the rules of the ClusterRole generated from the kubebuilder RBAC markers of the
+// controllers, followed by the rules of the leader election Role.
+func rules() ([]rbacv1.PolicyRule, error) {
+	var cr rbacv1.ClusterRole
+	if err := yaml.Unmarshal(rbac.Role, &cr); err != nil {
+		return nil, fmt.Errorf("failed to parse generated role: %w", err)
+	}
+
+	var le rbacv1.Role
+	if err := yaml.Unmarshal(rbac.LeaderElectionRole, &le); err != nil {
+		return nil, fmt.Errorf("failed to parse leader election role: %w", err)
+	}
+
+	return append(cr.Rules, le.Rules...), nil
+}
+
+// clusterScoped lists the resources in the generated role that are not namespaced.
+var clusterScoped = map[string]bool{
+	"clusteroriginissuers":       true,
+	"origincertificaterecords":   true,
+	"certificatesigningrequests": true,
+	"signers":                    true,
+	"subjectaccessreviews":       true,
+}
+
+// required reports whether the controller, configured with o, needs to be allowed verb on the
+// resource of group. Rules for disabled controllers are not required, nor is reading Secrets
+// unless secretCredentials reports that issuers reference credentials stored in Secrets.
+func required(o *options.ControllerOptions, secretCredentials bool, group, resource, verb string) bool {
+	resource, _, _ = strings.Cut(resource, "/")
+
+	switch group + "/" + resource {
+	case "cert-manager.k8s.cloudflare.com/clusteroriginissuers":
+		return !o.DisableClusterOriginIssuer
+	case "cert-manager.k8s.cloudflare.com/origincertificates":
+		return o.EnableOriginCertificates
+	case "cert-manager.k8s.cloudflare.com/origininventories":
+		return o.EnableInventory
+	case "cert-manager.k8s.cloudflare.com/origincertificaterecords":
+		return o.EnableCertificateRecords
+	case "cert-manager.io/certificaterequests":
+		return !o.DisableCertificateRequests
+	case "certificates.k8s.io/certificatesigningrequests":
+		return o.EnableCertificateSigningRequests
+	case "cert-manager.io/signers":
+		return o.EnableApprover
+	case "certificates.k8s.io/signers", "authorization.k8s.io/subjectaccessreviews":
+		return o.EnableCertificateSigningRequests
+	case "coordination.k8s.io/leases":
+		return o.LeaderElect
+	case "/secrets":
+		switch verb {
+		case "create":
+			return o.EnableOriginCertificates || o.LocalSigning || o.AllowLocalSigningMode
+		case "update":
+			return o.EnableOriginCertificates
+		case "get":
+			return secretCredentials || o.EnableOriginCertificates || o.LocalSigning || o.AllowLocalSigningMode
+		case "list":
+			return secretCredentials || o.EnableOriginCertificates || o.EnableInventory
+		case "watch":
+			return secretCredentials || o.EnableOriginCertificates
+		}
+	}
+
+	return true
+}
+
+// resourceName returns the name of the resource the controller needs access to, for a resource name
+// of the generated role. Signers are named for controllers.DefaultSignerDomain in the generated role,
+// so their names are derived for the signer domain the controller is configured with.
+func resourceName(o *options.ControllerOptions, group, resource, name string) string {
+	if group != "certificates.k8s.io" || resource != "signers" {
+		return name
+	}
+
+	prefix, ref, ok := strings.Cut(name, "/")
+	if kind, found := strings.CutSuffix(prefix, "."+controllers.DefaultSignerDomain); ok && found {
+		return kind + "." + o.CSRSignerDomain + "/" + ref
+	}
+
+	return name
+}
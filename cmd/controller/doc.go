@@ -2,11 +2,19 @@
 Controller runs the OriginIssuer and CertificateRequest controllers
 for the origin-ca-issuer project.
 
-Command Line
+# Command Line
 
-Flags:
+Usage:
 
-    none
+	controller [flags]
+	controller check [flags] [--service-account namespace/name] [--timeout duration]
 
+Run with --help for the full list of flags. Each flag can also be set with an
+ORIGIN_CA_ISSUER_ environment variable, such as ORIGIN_CA_ISSUER_LOG_LEVEL for
+--log-level, or in the ControllerConfiguration file named by --config. Flags
+take precedence over environment variables, which take precedence over the file.
+
+The check subcommand accepts the same flags as the controller, runs preflight
+checks of its deployment, and exits non-zero when a check fails.
 */
 package main
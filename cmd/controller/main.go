@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"os"
 	"time"
@@ -8,52 +11,95 @@ import (
 	certmanager "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cloudflare/origin-ca-issuer/cmd/controller/options"
 	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"github.com/cloudflare/origin-ca-issuer/internal/credfile"
+	"github.com/cloudflare/origin-ca-issuer/internal/logging"
 	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
 	"github.com/cloudflare/origin-ca-issuer/pkgs/controllers"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"github.com/go-logr/logr"
 	"github.com/go-logr/zerologr"
 	"github.com/rs/zerolog"
 	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		os.Exit(runCheck(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	fs := pflag.CommandLine
 	o := options.NewControllerOptions()
 	o.AddFlags(fs)
+	printVersion := fs.Bool("version", false, "Print version information and exit.")
 
 	_ = fs.Parse(os.Args[1:])
 
+	info := readBuildInfo()
+	if *printVersion {
+		fmt.Printf("origin-ca-issuer %s (revision %s, %s)\n", info.Version, info.Revision, info.GoVersion)
+		os.Exit(0)
+	}
+
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnixMs
+	zerolog.SetGlobalLevel(logging.MinLevel)
 	zerologr.NameFieldName = "logger"
 	zerologr.NameSeparator = "/"
 
 	zl := zerolog.New(os.Stderr).With().Caller().Timestamp().Logger()
-	logf.SetLogger(zerologr.New(&zl))
-	log := logf.Log.WithName("origin-issuer").V(8)
+	log := zerologr.New(&zl).WithName("origin-issuer")
+
+	if err := o.Load(fs, os.LookupEnv); err != nil {
+		log.Error(err, "error loading options")
+		os.Exit(1)
+	}
 
 	if err := o.Validate(); err != nil {
 		log.Error(err, "error validating options")
 		os.Exit(1)
 	}
 
-	scheme := runtime.NewScheme()
-	if err := clientgoscheme.AddToScheme(scheme); err != nil {
-		log.Error(err, "could not add to scheme")
+	logs, err := newLoggers(o)
+	if err != nil {
+		log.Error(err, "could not configure logging")
 		os.Exit(1)
 	}
-	if err := certmanager.AddToScheme(scheme); err != nil {
-		log.Error(err, "could not add to scheme")
+
+	log = logs.logger("origin-issuer", o.LogLevel)
+	logf.SetLogger(log)
+	log = log.WithName("origin-issuer")
+
+	log.Info("starting origin-ca-issuer", "version", info.Version, "revision", info.Revision, "goversion", info.GoVersion)
+
+	if err := registerBuildInfoMetric(info); err != nil {
+		log.Error(err, "could not register build info metric")
 		os.Exit(1)
 	}
-	if err := v1.AddToScheme(scheme); err != nil {
+
+	scheme, err := newScheme()
+	if err != nil {
 		log.Error(err, "could not add to scheme")
 		os.Exit(1)
 	}
@@ -67,22 +113,146 @@ func main() {
 	kubeCfg.QPS = o.KubernetesAPIQPS
 	kubeCfg.Burst = o.KubernetesAPIBurst
 
+	cacheOpts := cache.Options{
+		SyncPeriod: &o.SyncPeriod,
+	}
+	if len(o.Namespaces) > 0 {
+		cacheOpts.DefaultNamespaces = make(map[string]cache.Config, len(o.Namespaces))
+		for _, ns := range o.Namespaces {
+			cacheOpts.DefaultNamespaces[ns] = cache.Config{}
+		}
+
+		// Secrets and ConfigMaps referenced by ClusterOriginIssuers live in the
+		// cluster resource namespace, or for Secrets any of the cluster secret
+		// namespaces, which may not be watched otherwise.
+		if !o.DisableClusterOriginIssuer {
+			namespaces := make(map[string]cache.Config, len(o.Namespaces)+1)
+			for ns := range cacheOpts.DefaultNamespaces {
+				namespaces[ns] = cache.Config{}
+			}
+			namespaces[o.ClusterResourceNamespace] = cache.Config{}
+
+			secretNamespaces := maps.Clone(namespaces)
+			for _, ns := range o.ClusterSecretNamespaces {
+				secretNamespaces[ns] = cache.Config{}
+			}
+
+			cacheOpts.ByObject = map[client.Object]cache.ByObject{
+				metadataObject("Secret"):    {Namespaces: secretNamespaces},
+				metadataObject("ConfigMap"): {Namespaces: namespaces},
+			}
+		}
+	}
+
 	mgr, err := manager.New(kubeCfg, manager.Options{
 		Scheme: scheme,
+		Cache:  cacheOpts,
+
+		LeaderElection:          o.LeaderElect,
+		LeaderElectionID:        o.LeaderElectionID,
+		LeaderElectionNamespace: o.LeaderElectionNamespace,
+		LeaseDuration:           &o.LeaderElectionLeaseDuration,
+		RenewDeadline:           &o.LeaderElectionRenewDeadline,
+		RetryPeriod:             &o.LeaderElectionRetryPeriod,
+
+		HealthProbeBindAddress: o.HealthProbeBindAddress,
+		Metrics: metricsserver.Options{
+			BindAddress: o.MetricsBindAddress,
+		},
 	})
 	if err != nil {
 		log.Error(err, "could not create manager")
 		os.Exit(1)
 	}
 
+	// Log levels can be changed at runtime, so the endpoint is served on its own address, bound
+	// to localhost by default, rather than alongside metrics.
+	if o.AdminBindAddress != "0" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/loglevel", logs.levels)
+
+		if err := mgr.Add(&manager.Server{
+			Name:   "admin",
+			Server: &http.Server{Addr: o.AdminBindAddress, Handler: mux, ReadHeaderTimeout: 10 * time.Second},
+		}); err != nil {
+			log.Error(err, "could not add admin server")
+			os.Exit(1)
+		}
+	}
+
+	credentials, err := credfile.NewStore(o.CredentialDirectories, logs.logger("credentials", o.LogLevel).WithName("origin-issuer").WithName("credentials"))
+	if err != nil {
+		log.Error(err, "could not create credential file store")
+		os.Exit(1)
+	}
+	if err := mgr.Add(credentials); err != nil {
+		log.Error(err, "could not add credential file store")
+		os.Exit(1)
+	}
+
+	hc := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		log.Error(err, "could not add health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("ping", healthz.Ping); err != nil {
+		log.Error(err, "could not add readiness check")
+		os.Exit(1)
+	}
+
+	localSigner := provisioners.NewLocalSigner(&controllers.LocalCA{
+		Client: mgr.GetClient(),
+		Reader: mgr.GetAPIReader(),
+		Clock:  clock.RealClock{},
+		Secret: types.NamespacedName{Namespace: o.ClusterResourceNamespace, Name: o.LocalCASecret},
+	}, clock.RealClock{})
+
+	// Breakers are shared by every controller, so an outage observed by one pauses calls from all.
+	breakers := provisioners.NewBreakers(o.APIBreakerThreshold, o.APIBreakerCooldown, clock.RealClock{})
+
+	// Issuers may reach the Cloudflare API through their own endpoint, proxy and CA bundle, whose
+	// failures are reported on their conditions, so only issuers using the controller's settings
+	// of the API count towards readiness.
+	if err := mgr.AddReadyzCheck("cloudflare", cloudflareCheck(breakers)); err != nil {
+		log.Error(err, "could not add readiness check")
+		os.Exit(1)
+	}
+
+	// Quotas are shared by every controller signing certificates, so each counts the others' reservations.
+	quotas := &controllers.Quotas{}
+
+	// Every controller signs certificates and calls the Cloudflare API with the same settings.
+	signing := controllers.SigningSettings{
+		Credentials:           credentials,
+		LocalSigner:           localSigner,
+		LocalSigning:          o.LocalSigning,
+		AllowLocalSigningMode: o.AllowLocalSigningMode,
+		RecordCertificates:    o.EnableCertificateRecords,
+		Breakers:              breakers,
+		Quotas:                quotas,
+		APIPolicy: controllers.APIPolicy{
+			AllowInsecureEndpoints: o.AllowInsecureAPIEndpoints,
+			AllowedEndpoints:       o.AllowedAPIEndpoints,
+			AllowedProxies:         o.AllowedAPIProxies,
+		},
+	}
+
 	err = builder.
 		ControllerManagedBy(mgr).
-		For(&v1.OriginIssuer{}).
+		For(&v1.OriginIssuer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: o.OriginIssuerConcurrentReconciles,
+			RateLimiter:             rateLimiter(o),
+		}).
 		Complete(reconcile.AsReconciler(mgr.GetClient(), &controllers.OriginIssuerController{
-			Client: mgr.GetClient(),
-			Reader: mgr.GetAPIReader(),
-			Clock:  clock.RealClock{},
-			Log:    log.WithName("controllers").WithName("OriginIssuer"),
+			Client:          mgr.GetClient(),
+			Reader:          mgr.GetAPIReader(),
+			Clock:           clock.RealClock{},
+			SigningSettings: signing,
+			Log:             logs.controller("OriginIssuer", o),
 		}))
 
 	if err != nil {
@@ -90,41 +260,182 @@ func main() {
 		os.Exit(1)
 	}
 
-	err = builder.
-		ControllerManagedBy(mgr).
-		For(&v1.ClusterOriginIssuer{}).
-		Complete(reconcile.AsReconciler(mgr.GetClient(), &controllers.ClusterOriginIssuerController{
+	if !o.DisableClusterOriginIssuer {
+		err = builder.
+			ControllerManagedBy(mgr).
+			For(&v1.ClusterOriginIssuer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			WithOptions(controller.Options{
+				MaxConcurrentReconciles: o.ClusterOriginIssuerConcurrentReconciles,
+				RateLimiter:             rateLimiter(o),
+			}).
+			Complete(reconcile.AsReconciler(mgr.GetClient(), &controllers.ClusterOriginIssuerController{
+				Client:                   mgr.GetClient(),
+				Reader:                   mgr.GetAPIReader(),
+				ClusterResourceNamespace: o.ClusterResourceNamespace,
+				ClusterSecretNamespaces:  o.ClusterSecretNamespaces,
+				Clock:                    clock.RealClock{},
+				SigningSettings:          signing,
+				Log:                      logs.controller("ClusterOriginIssuer", o),
+			}))
+
+		if err != nil {
+			log.Error(err, "could not create cluster origin issuer controller")
+			os.Exit(1)
+		}
+	}
+
+	if !o.DisableCertificateRequests {
+		err = builder.
+			ControllerManagedBy(mgr).
+			For(&certmanager.CertificateRequest{}).
+			WithOptions(controller.Options{
+				MaxConcurrentReconciles: o.CertificateRequestConcurrentReconciles,
+				RateLimiter:             rateLimiter(o),
+			}).
+			Complete(reconcile.AsReconciler(mgr.GetClient(), &controllers.CertificateRequestController{
+				Client:                   mgr.GetClient(),
+				Reader:                   mgr.GetAPIReader(),
+				ClusterResourceNamespace: o.ClusterResourceNamespace,
+				ClusterSecretNamespaces:  o.ClusterSecretNamespaces,
+				DisableClusterIssuers:    o.DisableClusterOriginIssuer,
+				Builder:                  cfapi.NewBuilder().WithClient(hc).WithUserAgent(info.userAgent()),
+				Log:                      logs.controller("CertificateRequest", o),
+				Recorder:                 mgr.GetEventRecorderFor("origin-ca-issuer"),
+
+				Clock:                  clock.RealClock{},
+				SigningSettings:        signing,
+				CheckApprovedCondition: !o.DisableApprovedCheck,
+				MaxRetryDuration:       o.MaxRetryDuration,
+
+				MaxConcurrentSignsPerIssuer: o.MaxConcurrentSignsPerIssuer,
+			}))
+
+		if err != nil {
+			log.Error(err, "could not create certificaterequest controller")
+			os.Exit(1)
+		}
+	}
+
+	if o.EnableApprover {
+		err = builder.
+			ControllerManagedBy(mgr).
+			Named("certificaterequest-approver").
+			For(&certmanager.CertificateRequest{}).
+			WithOptions(controller.Options{
+				RateLimiter: rateLimiter(o),
+			}).
+			Complete(reconcile.AsReconciler(mgr.GetClient(), &controllers.CertificateRequestApprover{
+				Client:                mgr.GetClient(),
+				DisableClusterIssuers: o.DisableClusterOriginIssuer,
+				Log:                   logs.controller("CertificateRequestApprover", o),
+			}))
+
+		if err != nil {
+			log.Error(err, "could not create certificaterequest approver")
+			os.Exit(1)
+		}
+	}
+
+	if o.EnableOriginCertificates {
+		certificates := &controllers.OriginCertificateController{
 			Client:                   mgr.GetClient(),
 			Reader:                   mgr.GetAPIReader(),
 			ClusterResourceNamespace: o.ClusterResourceNamespace,
+			ClusterSecretNamespaces:  o.ClusterSecretNamespaces,
+			DisableClusterIssuers:    o.DisableClusterOriginIssuer,
+			Builder:                  cfapi.NewBuilder().WithClient(hc).WithUserAgent(info.userAgent()),
+			Log:                      logs.controller("OriginCertificate", o),
+			Recorder:                 mgr.GetEventRecorderFor("origin-ca-issuer"),
 			Clock:                    clock.RealClock{},
-			Log:                      log.WithName("controllers").WithName("ClusterOriginIssuer"),
-		}))
+			SigningSettings:          signing,
+		}
 
-	if err != nil {
-		log.Error(err, "could not create cluster origin issuer controller")
-		os.Exit(1)
+		b := builder.
+			ControllerManagedBy(mgr).
+			For(&v1.OriginCertificate{}).
+			Owns(&corev1.Secret{}, builder.OnlyMetadata).
+			Watches(&v1.OriginIssuer{}, handler.EnqueueRequestsFromMapFunc(certificates.IssuerCertificates), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+		if !o.DisableClusterOriginIssuer {
+			b = b.Watches(&v1.ClusterOriginIssuer{}, handler.EnqueueRequestsFromMapFunc(certificates.IssuerCertificates), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+		}
+
+		err = b.
+			WithOptions(controller.Options{
+				MaxConcurrentReconciles: o.OriginCertificateConcurrentReconciles,
+				RateLimiter:             rateLimiter(o),
+			}).
+			Complete(reconcile.AsReconciler(mgr.GetClient(), certificates))
+
+		if err != nil {
+			log.Error(err, "could not create origincertificate controller")
+			os.Exit(1)
+		}
 	}
 
-	err = builder.
-		ControllerManagedBy(mgr).
-		For(&certmanager.CertificateRequest{}).
-		Complete(reconcile.AsReconciler(mgr.GetClient(), &controllers.CertificateRequestController{
-			Client:                   mgr.GetClient(),
-			Reader:                   mgr.GetAPIReader(),
-			ClusterResourceNamespace: o.ClusterResourceNamespace,
-			Builder: cfapi.NewBuilder().WithClient(&http.Client{
-				Timeout: 30 * time.Second,
-			}),
-			Log: log.WithName("controllers").WithName("CertificateRequest"),
+	if o.EnableCertificateSigningRequests {
+		err = builder.
+			ControllerManagedBy(mgr).
+			For(&certificatesv1.CertificateSigningRequest{}).
+			WithOptions(controller.Options{
+				MaxConcurrentReconciles: o.CertificateSigningRequestConcurrentReconciles,
+				RateLimiter:             rateLimiter(o),
+			}).
+			Complete(reconcile.AsReconciler(mgr.GetClient(), &controllers.CertificateSigningRequestController{
+				Client:                   mgr.GetClient(),
+				Reader:                   mgr.GetAPIReader(),
+				ClusterResourceNamespace: o.ClusterResourceNamespace,
+				ClusterSecretNamespaces:  o.ClusterSecretNamespaces,
+				DisableClusterIssuers:    o.DisableClusterOriginIssuer,
+				SignerDomain:             o.CSRSignerDomain,
+				Builder:                  cfapi.NewBuilder().WithClient(hc).WithUserAgent(info.userAgent()),
+				Log:                      logs.controller("CertificateSigningRequest", o),
+				Recorder:                 mgr.GetEventRecorderFor("origin-ca-issuer"),
+				Clock:                    clock.RealClock{},
+				SigningSettings:          signing,
+			}))
 
-			Clock:                  clock.RealClock{},
-			CheckApprovedCondition: !o.DisableApprovedCheck,
-		}))
+		if err != nil {
+			log.Error(err, "could not create certificatesigningrequest controller")
+			os.Exit(1)
+		}
+	}
 
-	if err != nil {
-		log.Error(err, "could not create certificaterequest controller")
-		os.Exit(1)
+	if o.EnableInventory {
+		err = builder.
+			ControllerManagedBy(mgr).
+			For(&v1.OriginInventory{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			WithOptions(controller.Options{
+				RateLimiter: rateLimiter(o),
+			}).
+			Complete((&controllers.OriginInventoryController{
+				Client:                     mgr.GetClient(),
+				Reader:                     mgr.GetAPIReader(),
+				Namespaces:                 o.Namespaces,
+				DisableCertificateRequests: o.DisableCertificateRequests,
+				CertificateSigningRequests: o.EnableCertificateSigningRequests,
+				Builder:                    cfapi.NewBuilder().WithClient(hc).WithUserAgent(info.userAgent()),
+				Log:                        logs.controller("OriginInventory", o),
+				Recorder:                   mgr.GetEventRecorderFor("origin-ca-issuer"),
+				Clock:                      clock.RealClock{},
+				SigningSettings:            signing,
+			}).Reconciler(mgr.GetClient()))
+
+		if err != nil {
+			log.Error(err, "could not create origininventory controller")
+			os.Exit(1)
+		}
+	}
+
+	if o.EnableCertificateRecords || o.EnableOriginCertificates {
+		metrics.Registry.MustRegister(&controllers.CertificateExpiryCollector{
+			Reader:             mgr.GetClient(),
+			Log:                logs.logger("expiry", o.LogLevel).WithName("origin-issuer").WithName("expiry"),
+			Clock:              clock.RealClock{},
+			Records:            o.EnableCertificateRecords,
+			OriginCertificates: o.EnableOriginCertificates,
+			Windows:            o.CertificateExpiryWindows,
+		})
 	}
 
 	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
@@ -132,3 +443,72 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newScheme returns a scheme with the types read and written by the controllers.
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, certmanager.AddToScheme, v1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	return scheme, nil
+}
+
+// cloudflareCheck returns a readiness check that fails while breakers report the
+// Cloudflare API as unavailable through the controller's settings of the API.
+func cloudflareCheck(breakers *provisioners.Breakers) healthz.Checker {
+	return func(*http.Request) error {
+		return breakers.Unavailable(controllers.DefaultAPIBreaker)
+	}
+}
+
+// metadataObject returns an object selecting the metadata of the named core kind.
+func metadataObject(kind string) client.Object {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+
+	return obj
+}
+
+// rateLimiter returns the rate limiter used to queue and retry reconciles of a
+// single controller.
+func rateLimiter(o *options.ControllerOptions) workqueue.TypedRateLimiter[reconcile.Request] {
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](o.RetryBaseDelay, o.RetryMaxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(o.RateLimitQPS), o.RateLimitBurst)},
+	)
+}
+
+// loggers creates zerolog-backed loggers whose levels are registered for runtime
+// adjustment through the "/debug/loglevel" endpoint of the admin server.
+type loggers struct {
+	w      io.Writer
+	levels *logging.Registry
+}
+
+func newLoggers(o *options.ControllerOptions) (*loggers, error) {
+	w, err := logging.NewWriter(o.LogFormat, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loggers{w: w, levels: logging.NewRegistry()}, nil
+}
+
+// logger returns a logger registered as name, initially logging at level.
+func (l *loggers) logger(name, level string) logr.Logger {
+	lvl, _ := logging.ParseLevel(level)
+	ll := logging.NewLevel(lvl)
+	l.levels.Register(name, ll)
+
+	zl := zerolog.New(l.w).Hook(ll.Hook()).With().Caller().Timestamp().Logger()
+
+	return zerologr.New(&zl)
+}
+
+// controller returns the logger of the named controller.
+func (l *loggers) controller(name string, o *options.ControllerOptions) logr.Logger {
+	return l.logger(name, o.ControllerLogLevel(name)).WithName("origin-issuer").WithName("controllers").WithName(name)
+}
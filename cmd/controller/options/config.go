@@ -0,0 +1,283 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigAPIVersion is the apiVersion of supported configuration files.
+	ConfigAPIVersion = "controller.cert-manager.k8s.cloudflare.com/v1alpha1"
+
+	// ConfigKind is the kind of supported configuration files.
+	ConfigKind = "ControllerConfiguration"
+
+	// EnvPrefix is prepended to a flag name, upper-cased with dashes replaced
+	// by underscores, to form the environment variable overriding that flag.
+	EnvPrefix = "ORIGIN_CA_ISSUER_"
+)
+
+// ControllerConfiguration is the configuration file format for the controller.
+// Every field is optional; unset fields keep their default values.
+type ControllerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	KubernetesAPI *KubernetesAPIConfiguration `json:"kubernetesAPI,omitempty"`
+
+	ClusterResourceNamespace   *string  `json:"clusterResourceNamespace,omitempty"`
+	Namespaces                 []string `json:"namespaces,omitempty"`
+	DisableClusterOriginIssuer *bool    `json:"disableClusterOriginIssuer,omitempty"`
+	ClusterSecretNamespaces    []string `json:"clusterSecretNamespaces,omitempty"`
+
+	DisableCertificateRequests *bool `json:"disableCertificateRequests,omitempty"`
+	EnableOriginCertificates   *bool `json:"enableOriginCertificates,omitempty"`
+
+	EnableCertificateSigningRequests *bool   `json:"enableCertificateSigningRequests,omitempty"`
+	CSRSignerDomain                  *string `json:"csrSignerDomain,omitempty"`
+
+	EnableInventory          *bool `json:"enableInventory,omitempty"`
+	EnableCertificateRecords *bool `json:"enableCertificateRecords,omitempty"`
+
+	CertificateExpiryWindows []metav1.Duration `json:"certificateExpiryWindows,omitempty"`
+
+	LocalSigning          *bool   `json:"localSigning,omitempty"`
+	AllowLocalSigningMode *bool   `json:"allowLocalSigningMode,omitempty"`
+	LocalCASecret         *string `json:"localCASecret,omitempty"`
+
+	CredentialDirectories []string `json:"credentialDirectories,omitempty"`
+
+	DisableApprovedCheck *bool            `json:"disableApprovedCheck,omitempty"`
+	EnableApprover       *bool            `json:"enableApprover,omitempty"`
+	MaxRetryDuration     *metav1.Duration `json:"maxRetryDuration,omitempty"`
+
+	APIBreaker *APIBreakerConfiguration `json:"apiBreaker,omitempty"`
+	APIAccess  *APIAccessConfiguration  `json:"apiAccess,omitempty"`
+
+	LeaderElection *LeaderElectionConfiguration `json:"leaderElection,omitempty"`
+
+	HealthProbeBindAddress *string `json:"healthProbeBindAddress,omitempty"`
+	MetricsBindAddress     *string `json:"metricsBindAddress,omitempty"`
+	AdminBindAddress       *string `json:"adminBindAddress,omitempty"`
+
+	Concurrency *ConcurrencyConfiguration `json:"concurrency,omitempty"`
+
+	SyncPeriod  *metav1.Duration          `json:"syncPeriod,omitempty"`
+	RateLimiter *RateLimiterConfiguration `json:"rateLimiter,omitempty"`
+
+	Logging *LoggingConfiguration `json:"logging,omitempty"`
+}
+
+// KubernetesAPIConfiguration configures the client used to talk to the Kubernetes apiserver.
+type KubernetesAPIConfiguration struct {
+	QPS   *float32 `json:"qps,omitempty"`
+	Burst *int     `json:"burst,omitempty"`
+}
+
+// LeaderElectionConfiguration configures leader election between controller replicas.
+type LeaderElectionConfiguration struct {
+	Enabled       *bool            `json:"enabled,omitempty"`
+	ID            *string          `json:"id,omitempty"`
+	Namespace     *string          `json:"namespace,omitempty"`
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	RetryPeriod   *metav1.Duration `json:"retryPeriod,omitempty"`
+}
+
+// APIBreakerConfiguration configures the circuit breakers of the Cloudflare API.
+type APIBreakerConfiguration struct {
+	Threshold *int             `json:"threshold,omitempty"`
+	Cooldown  *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// APIAccessConfiguration restricts the settings of the Cloudflare API issuers may use.
+type APIAccessConfiguration struct {
+	AllowInsecureEndpoints *bool    `json:"allowInsecureEndpoints,omitempty"`
+	AllowedEndpoints       []string `json:"allowedEndpoints,omitempty"`
+	AllowedProxies         []string `json:"allowedProxies,omitempty"`
+}
+
+// ConcurrencyConfiguration configures how much work the controllers perform in parallel.
+type ConcurrencyConfiguration struct {
+	OriginIssuer              *int `json:"originIssuer,omitempty"`
+	ClusterOriginIssuer       *int `json:"clusterOriginIssuer,omitempty"`
+	CertificateRequest        *int `json:"certificateRequest,omitempty"`
+	OriginCertificate         *int `json:"originCertificate,omitempty"`
+	CertificateSigningRequest *int `json:"certificateSigningRequest,omitempty"`
+	SignsPerIssuer            *int `json:"signsPerIssuer,omitempty"`
+}
+
+// RateLimiterConfiguration configures how quickly reconciles are queued and retried.
+type RateLimiterConfiguration struct {
+	BaseDelay *metav1.Duration `json:"baseDelay,omitempty"`
+	MaxDelay  *metav1.Duration `json:"maxDelay,omitempty"`
+	QPS       *float64         `json:"qps,omitempty"`
+	Burst     *int             `json:"burst,omitempty"`
+}
+
+// LoggingConfiguration configures the level and format of the controller's logs.
+type LoggingConfiguration struct {
+	Level       *string           `json:"level,omitempty"`
+	Format      *string           `json:"format,omitempty"`
+	Controllers map[string]string `json:"controllers,omitempty"`
+}
+
+// LoadConfigFile reads and strictly decodes a ControllerConfiguration from path.
+// Unknown fields, and unknown apiVersions or kinds, are rejected.
+func LoadConfigFile(path string) (*ControllerConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	cfg := &ControllerConfiguration{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration file %s: %w", path, err)
+	}
+
+	if cfg.APIVersion != ConfigAPIVersion {
+		return nil, fmt.Errorf("invalid value for apiVersion in configuration file %s: %q must be %q", path, cfg.APIVersion, ConfigAPIVersion)
+	}
+
+	if cfg.Kind != ConfigKind {
+		return nil, fmt.Errorf("invalid value for kind in configuration file %s: %q must be %q", path, cfg.Kind, ConfigKind)
+	}
+
+	return cfg, nil
+}
+
+// Load completes the options once fs has been parsed. Each option is resolved
+// with the following precedence, highest first: flags set on the command line,
+// environment variables, the configuration file, and finally the defaults.
+func (o *ControllerOptions) Load(fs *pflag.FlagSet, lookupEnv func(string) (string, bool)) error {
+	if !fs.Changed("config") {
+		if v, ok := lookupEnv(EnvName("config")); ok {
+			o.ConfigFile = v
+		}
+	}
+
+	if o.ConfigFile != "" {
+		cfg, err := LoadConfigFile(o.ConfigFile)
+		if err != nil {
+			return err
+		}
+
+		cfg.applyTo(o, fs)
+	}
+
+	var errs []error
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Changed || f.Name == "config" {
+			return
+		}
+
+		v, ok := lookupEnv(EnvName(f.Name))
+		if !ok {
+			return
+		}
+
+		if err := fs.Set(f.Name, v); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for %s: %w", EnvName(f.Name), err))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// EnvName returns the environment variable overriding the named flag.
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// applyTo copies the values set in the configuration file to o, skipping options
+// whose flags were explicitly set on the command line.
+func (c *ControllerConfiguration) applyTo(o *ControllerOptions, fs *pflag.FlagSet) {
+	set := func(flag string, isSet bool, apply func()) {
+		if isSet && !fs.Changed(flag) {
+			apply()
+		}
+	}
+
+	if api := c.KubernetesAPI; api != nil {
+		set("kube-api-qps", api.QPS != nil, func() { o.KubernetesAPIQPS = *api.QPS })
+		set("kube-api-burst", api.Burst != nil, func() { o.KubernetesAPIBurst = *api.Burst })
+	}
+
+	set("cluster-resource-namespace", c.ClusterResourceNamespace != nil, func() { o.ClusterResourceNamespace = *c.ClusterResourceNamespace })
+	set("namespaces", c.Namespaces != nil, func() { o.Namespaces = c.Namespaces })
+	set("disable-cluster-origin-issuer", c.DisableClusterOriginIssuer != nil, func() { o.DisableClusterOriginIssuer = *c.DisableClusterOriginIssuer })
+	set("cluster-secret-namespaces", c.ClusterSecretNamespaces != nil, func() { o.ClusterSecretNamespaces = c.ClusterSecretNamespaces })
+	set("disable-certificate-requests", c.DisableCertificateRequests != nil, func() { o.DisableCertificateRequests = *c.DisableCertificateRequests })
+	set("enable-origin-certificates", c.EnableOriginCertificates != nil, func() { o.EnableOriginCertificates = *c.EnableOriginCertificates })
+	set("enable-certificate-signing-requests", c.EnableCertificateSigningRequests != nil, func() { o.EnableCertificateSigningRequests = *c.EnableCertificateSigningRequests })
+	set("csr-signer-domain", c.CSRSignerDomain != nil, func() { o.CSRSignerDomain = *c.CSRSignerDomain })
+	set("enable-certificate-records", c.EnableCertificateRecords != nil, func() { o.EnableCertificateRecords = *c.EnableCertificateRecords })
+	set("certificate-expiry-windows", c.CertificateExpiryWindows != nil, func() {
+		o.CertificateExpiryWindows = make([]time.Duration, len(c.CertificateExpiryWindows))
+		for i, window := range c.CertificateExpiryWindows {
+			o.CertificateExpiryWindows[i] = window.Duration
+		}
+	})
+	set("local-signing", c.LocalSigning != nil, func() { o.LocalSigning = *c.LocalSigning })
+	set("allow-local-signing-mode", c.AllowLocalSigningMode != nil, func() { o.AllowLocalSigningMode = *c.AllowLocalSigningMode })
+	set("local-ca-secret", c.LocalCASecret != nil, func() { o.LocalCASecret = *c.LocalCASecret })
+	set("enable-inventory", c.EnableInventory != nil, func() { o.EnableInventory = *c.EnableInventory })
+	set("credential-directories", c.CredentialDirectories != nil, func() { o.CredentialDirectories = c.CredentialDirectories })
+	set("disable-approved-check", c.DisableApprovedCheck != nil, func() { o.DisableApprovedCheck = *c.DisableApprovedCheck })
+	set("enable-approver", c.EnableApprover != nil, func() { o.EnableApprover = *c.EnableApprover })
+	set("max-retry-duration", c.MaxRetryDuration != nil, func() { o.MaxRetryDuration = c.MaxRetryDuration.Duration })
+
+	if ab := c.APIBreaker; ab != nil {
+		set("api-breaker-threshold", ab.Threshold != nil, func() { o.APIBreakerThreshold = *ab.Threshold })
+		set("api-breaker-cooldown", ab.Cooldown != nil, func() { o.APIBreakerCooldown = ab.Cooldown.Duration })
+	}
+
+	if aa := c.APIAccess; aa != nil {
+		set("allow-insecure-api-endpoints", aa.AllowInsecureEndpoints != nil, func() { o.AllowInsecureAPIEndpoints = *aa.AllowInsecureEndpoints })
+		set("allowed-api-endpoints", aa.AllowedEndpoints != nil, func() { o.AllowedAPIEndpoints = aa.AllowedEndpoints })
+		set("allowed-api-proxies", aa.AllowedProxies != nil, func() { o.AllowedAPIProxies = aa.AllowedProxies })
+	}
+
+	if le := c.LeaderElection; le != nil {
+		set("leader-elect", le.Enabled != nil, func() { o.LeaderElect = *le.Enabled })
+		set("leader-election-id", le.ID != nil, func() { o.LeaderElectionID = *le.ID })
+		set("leader-election-namespace", le.Namespace != nil, func() { o.LeaderElectionNamespace = *le.Namespace })
+		set("leader-election-lease-duration", le.LeaseDuration != nil, func() { o.LeaderElectionLeaseDuration = le.LeaseDuration.Duration })
+		set("leader-election-renew-deadline", le.RenewDeadline != nil, func() { o.LeaderElectionRenewDeadline = le.RenewDeadline.Duration })
+		set("leader-election-retry-period", le.RetryPeriod != nil, func() { o.LeaderElectionRetryPeriod = le.RetryPeriod.Duration })
+	}
+
+	set("health-probe-bind-address", c.HealthProbeBindAddress != nil, func() { o.HealthProbeBindAddress = *c.HealthProbeBindAddress })
+	set("metrics-bind-address", c.MetricsBindAddress != nil, func() { o.MetricsBindAddress = *c.MetricsBindAddress })
+	set("admin-bind-address", c.AdminBindAddress != nil, func() { o.AdminBindAddress = *c.AdminBindAddress })
+
+	if cc := c.Concurrency; cc != nil {
+		set("origin-issuer-concurrent-reconciles", cc.OriginIssuer != nil, func() { o.OriginIssuerConcurrentReconciles = *cc.OriginIssuer })
+		set("cluster-origin-issuer-concurrent-reconciles", cc.ClusterOriginIssuer != nil, func() { o.ClusterOriginIssuerConcurrentReconciles = *cc.ClusterOriginIssuer })
+		set("certificate-request-concurrent-reconciles", cc.CertificateRequest != nil, func() { o.CertificateRequestConcurrentReconciles = *cc.CertificateRequest })
+		set("origin-certificate-concurrent-reconciles", cc.OriginCertificate != nil, func() { o.OriginCertificateConcurrentReconciles = *cc.OriginCertificate })
+		set("certificate-signing-request-concurrent-reconciles", cc.CertificateSigningRequest != nil, func() { o.CertificateSigningRequestConcurrentReconciles = *cc.CertificateSigningRequest })
+		set("max-concurrent-signs-per-issuer", cc.SignsPerIssuer != nil, func() { o.MaxConcurrentSignsPerIssuer = *cc.SignsPerIssuer })
+	}
+
+	set("sync-period", c.SyncPeriod != nil, func() { o.SyncPeriod = c.SyncPeriod.Duration })
+
+	if rl := c.RateLimiter; rl != nil {
+		set("retry-base-delay", rl.BaseDelay != nil, func() { o.RetryBaseDelay = rl.BaseDelay.Duration })
+		set("retry-max-delay", rl.MaxDelay != nil, func() { o.RetryMaxDelay = rl.MaxDelay.Duration })
+		set("rate-limit-qps", rl.QPS != nil, func() { o.RateLimitQPS = *rl.QPS })
+		set("rate-limit-burst", rl.Burst != nil, func() { o.RateLimitBurst = *rl.Burst })
+	}
+
+	if lc := c.Logging; lc != nil {
+		set("log-level", lc.Level != nil, func() { o.LogLevel = *lc.Level })
+		set("log-format", lc.Format != nil, func() { o.LogFormat = *lc.Format })
+		set("controller-log-levels", lc.Controllers != nil, func() { o.ControllerLogLevels = lc.Controllers })
+	}
+}
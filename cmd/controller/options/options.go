@@ -1,36 +1,219 @@
 package options
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/cloudflare/origin-ca-issuer/internal/logging"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type ControllerOptions struct {
+	ConfigFile string
+
 	KubernetesAPIQPS         float32
 	KubernetesAPIBurst       int
 	ClusterResourceNamespace string
 
+	Namespaces                 []string
+	DisableClusterOriginIssuer bool
+	ClusterSecretNamespaces    []string
+
+	DisableCertificateRequests bool
+	EnableOriginCertificates   bool
+
+	EnableCertificateSigningRequests bool
+	CSRSignerDomain                  string
+
+	EnableInventory          bool
+	EnableCertificateRecords bool
+
+	CertificateExpiryWindows []time.Duration
+
+	LocalSigning          bool
+	AllowLocalSigningMode bool
+	LocalCASecret         string
+
+	CredentialDirectories []string
+
 	DisableApprovedCheck bool
+	EnableApprover       bool
+	MaxRetryDuration     time.Duration
+
+	APIBreakerThreshold int
+	APIBreakerCooldown  time.Duration
+
+	AllowInsecureAPIEndpoints bool
+	AllowedAPIEndpoints       []string
+	AllowedAPIProxies         []string
+
+	LeaderElect                 bool
+	LeaderElectionID            string
+	LeaderElectionNamespace     string
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
+
+	HealthProbeBindAddress string
+	MetricsBindAddress     string
+	AdminBindAddress       string
+
+	OriginIssuerConcurrentReconciles              int
+	ClusterOriginIssuerConcurrentReconciles       int
+	CertificateRequestConcurrentReconciles        int
+	OriginCertificateConcurrentReconciles         int
+	CertificateSigningRequestConcurrentReconciles int
+	MaxConcurrentSignsPerIssuer                   int
+
+	SyncPeriod     time.Duration
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	RateLimitQPS   float64
+	RateLimitBurst int
+
+	LogLevel            string
+	LogFormat           string
+	ControllerLogLevels map[string]string
 }
 
+// ControllerNames lists the controllers whose log level can be configured
+// independently.
+var ControllerNames = []string{"OriginIssuer", "ClusterOriginIssuer", "CertificateRequest", "OriginCertificate", "CertificateSigningRequest", "OriginInventory", "CertificateRequestApprover"}
+
 const (
 	defaultKubernetesAPIQPS   float32 = 20
 	defaultKubernetesAPIBurst int     = 50
+
+	defaultCSRSignerDomain = "cert-manager.k8s.cloudflare.com"
+	defaultLocalCASecret   = "origin-ca-issuer-local-ca"
+
+	defaultAPIBreakerThreshold = 5
+	defaultAPIBreakerCooldown  = time.Minute
+
+	defaultLeaderElect                 = false
+	defaultLeaderElectionID            = "origin-ca-issuer-leader-election"
+	defaultLeaderElectionLeaseDuration = 15 * time.Second
+	defaultLeaderElectionRenewDeadline = 10 * time.Second
+	defaultLeaderElectionRetryPeriod   = 2 * time.Second
+
+	defaultHealthProbeBindAddress = ":8081"
+	defaultMetricsBindAddress     = ":8080"
+	defaultAdminBindAddress       = "127.0.0.1:8082"
+
+	defaultConcurrentReconciles = 1
+
+	defaultSyncPeriod     = 10 * time.Hour
+	defaultRetryBaseDelay = 5 * time.Millisecond
+	defaultRetryMaxDelay  = 1000 * time.Second
+	defaultRateLimitQPS   = 10
+	defaultRateLimitBurst = 100
+
+	defaultLogLevel  = "info"
+	defaultLogFormat = "json"
 )
 
 func NewControllerOptions() *ControllerOptions {
 	return &ControllerOptions{
 		KubernetesAPIQPS:   defaultKubernetesAPIQPS,
 		KubernetesAPIBurst: defaultKubernetesAPIBurst,
+
+		CSRSignerDomain: defaultCSRSignerDomain,
+
+		CertificateExpiryWindows: []time.Duration{7 * 24 * time.Hour, 30 * 24 * time.Hour, 90 * 24 * time.Hour},
+
+		LocalCASecret: defaultLocalCASecret,
+
+		APIBreakerThreshold: defaultAPIBreakerThreshold,
+		APIBreakerCooldown:  defaultAPIBreakerCooldown,
+
+		LeaderElect:                 defaultLeaderElect,
+		LeaderElectionID:            defaultLeaderElectionID,
+		LeaderElectionLeaseDuration: defaultLeaderElectionLeaseDuration,
+		LeaderElectionRenewDeadline: defaultLeaderElectionRenewDeadline,
+		LeaderElectionRetryPeriod:   defaultLeaderElectionRetryPeriod,
+
+		HealthProbeBindAddress: defaultHealthProbeBindAddress,
+		MetricsBindAddress:     defaultMetricsBindAddress,
+		AdminBindAddress:       defaultAdminBindAddress,
+
+		OriginIssuerConcurrentReconciles:              defaultConcurrentReconciles,
+		ClusterOriginIssuerConcurrentReconciles:       defaultConcurrentReconciles,
+		CertificateRequestConcurrentReconciles:        defaultConcurrentReconciles,
+		OriginCertificateConcurrentReconciles:         defaultConcurrentReconciles,
+		CertificateSigningRequestConcurrentReconciles: defaultConcurrentReconciles,
+
+		SyncPeriod:     defaultSyncPeriod,
+		RetryBaseDelay: defaultRetryBaseDelay,
+		RetryMaxDelay:  defaultRetryMaxDelay,
+		RateLimitQPS:   defaultRateLimitQPS,
+		RateLimitBurst: defaultRateLimitBurst,
+
+		LogLevel:  defaultLogLevel,
+		LogFormat: defaultLogFormat,
 	}
 }
 
 func (o *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ConfigFile, "config", o.ConfigFile, "Path to a ControllerConfiguration file. Flags and environment variables take precedence over values in the file.")
 	fs.Float32Var(&o.KubernetesAPIQPS, "kube-api-qps", defaultKubernetesAPIQPS, "Maximium queries-per-second of requests to the Kubernetes apiserver.")
 	fs.IntVar(&o.KubernetesAPIBurst, "kube-api-burst", defaultKubernetesAPIBurst, "Maximium queries-per-second burst of request send to the Kubernetes apiserver.")
 	fs.BoolVar(&o.DisableApprovedCheck, "disable-approved-check", o.DisableApprovedCheck, "Disables waiting for CertificateRequests to have an approved condition before signing.")
+	fs.BoolVar(&o.EnableApprover, "enable-approver", o.EnableApprover, "Enables the built-in approver, approving CertificateRequests that satisfy the policy of the referenced issuer and denying all others, including requests for issuers without a policy.")
+	fs.DurationVar(&o.MaxRetryDuration, "max-retry-duration", o.MaxRetryDuration, "Maximum duration since creation that CertificateRequests failing with transient errors are retried before being marked as Failed. Zero retries indefinitely.")
 	fs.StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", o.ClusterResourceNamespace, "Namespace used for cluster-scoped resources, such as secrets used by ClusterOriginIssuer")
+	fs.StringSliceVar(&o.Namespaces, "namespaces", o.Namespaces, "Comma-separated list of namespaces to watch for OriginIssuers and CertificateRequests. By default, all namespaces are watched.")
+	fs.BoolVar(&o.DisableClusterOriginIssuer, "disable-cluster-origin-issuer", o.DisableClusterOriginIssuer, "Disables the ClusterOriginIssuer controller, and ignores CertificateRequests referencing ClusterOriginIssuers.")
+	fs.StringSliceVar(&o.ClusterSecretNamespaces, "cluster-secret-namespaces", o.ClusterSecretNamespaces, "Comma-separated list of namespaces, besides cluster-resource-namespace, that ClusterOriginIssuers may select secrets from using the namespace field of a secret reference.")
+	fs.BoolVar(&o.DisableCertificateRequests, "disable-certificate-requests", o.DisableCertificateRequests, "Disables the CertificateRequest controller, allowing the controller to run without cert-manager installed.")
+	fs.BoolVar(&o.EnableOriginCertificates, "enable-origin-certificates", o.EnableOriginCertificates, "Enables the OriginCertificate controller, issuing certificates requested by OriginCertificates without cert-manager.")
+	fs.BoolVar(&o.EnableCertificateSigningRequests, "enable-certificate-signing-requests", o.EnableCertificateSigningRequests, "Enables the CertificateSigningRequest controller, signing approved Kubernetes CertificateSigningRequests that reference an issuer.")
+	fs.StringVar(&o.CSRSignerDomain, "csr-signer-domain", o.CSRSignerDomain, "Domain of the signer names handled by the CertificateSigningRequest controller, such as originissuers.<domain>/<namespace>.<name> and clusteroriginissuers.<domain>/<name>.")
+	fs.BoolVar(&o.EnableCertificateRecords, "enable-certificate-records", o.EnableCertificateRecords, "Enables creating an OriginCertificateRecord for each certificate issued for a CertificateRequest, OriginCertificate or CertificateSigningRequest.")
+	fs.DurationSliceVar(&o.CertificateExpiryWindows, "certificate-expiry-windows", o.CertificateExpiryWindows, "Comma-separated list of windows the origin_ca_issuer_certificates_expiring metric counts the certificates expiring within, e.g. 168h,720h.")
+	fs.BoolVar(&o.LocalSigning, "local-signing", o.LocalSigning, "Sign the certificates of every issuer with a local CA instead of the Cloudflare Origin CA, as if their signingMode were Local. Intended for staging clusters.")
+	fs.BoolVar(&o.AllowLocalSigningMode, "allow-local-signing-mode", o.AllowLocalSigningMode, "Honour the Local signing mode of issuers, signing their certificates with a local CA. Otherwise, issuers in the Local signing mode are not ready. Intended for staging clusters.")
+	fs.StringVar(&o.LocalCASecret, "local-ca-secret", o.LocalCASecret, "Name of the kubernetes.io/tls Secret in the cluster resource namespace storing the CA issuers in the Local signing mode sign with. The CA is generated if the Secret does not exist.")
+	fs.BoolVar(&o.EnableInventory, "enable-inventory", o.EnableInventory, "Enables the OriginInventory controller, correlating the certificates listed by the Cloudflare API with those found in the cluster.")
+	fs.IntVar(&o.APIBreakerThreshold, "api-breaker-threshold", o.APIBreakerThreshold, "Number of consecutive calls to the Cloudflare API failing because it is unavailable after which the circuit breaker of the credential opens, marking its issuers as not ready. Zero disables the circuit breaker.")
+	fs.DurationVar(&o.APIBreakerCooldown, "api-breaker-cooldown", o.APIBreakerCooldown, "Duration an open circuit breaker rejects calls to the Cloudflare API before allowing a call through to probe whether it recovered.")
+	fs.BoolVar(&o.AllowInsecureAPIEndpoints, "allow-insecure-api-endpoints", o.AllowInsecureAPIEndpoints, "Allow issuers to set a plain HTTP spec.api.endpoint, sending their credentials to the Cloudflare API in cleartext. Intended for stand-in APIs of test clusters.")
+	fs.StringSliceVar(&o.AllowedAPIEndpoints, "allowed-api-endpoints", o.AllowedAPIEndpoints, "Comma-separated list of base URLs of the Cloudflare API that OriginIssuers may set as spec.api.endpoint, matched by scheme and host. By default, OriginIssuers may not set an endpoint. ClusterOriginIssuers may set any endpoint.")
+	fs.StringSliceVar(&o.AllowedAPIProxies, "allowed-api-proxies", o.AllowedAPIProxies, "Comma-separated list of proxy URLs that OriginIssuers may set as spec.api.proxy, matched by scheme and host. By default, OriginIssuers may not set a proxy. ClusterOriginIssuers may set any proxy.")
+	fs.StringSliceVar(&o.CredentialDirectories, "credential-directories", o.CredentialDirectories, "Comma-separated list of directories that issuers may read serviceKeyFile and tokenFile credentials from. OriginIssuers may only read files within the subdirectory named after their namespace. By default, credential files are disabled.")
+
+	fs.BoolVar(&o.LeaderElect, "leader-elect", o.LeaderElect, "Enable leader election, ensuring only one replica of the controller signs certificates at a time. Requires a namespace for the Lease when running outside of a cluster.")
+	fs.StringVar(&o.LeaderElectionID, "leader-election-id", o.LeaderElectionID, "Name of the Lease resource used for leader election.")
+	fs.StringVar(&o.LeaderElectionNamespace, "leader-election-namespace", o.LeaderElectionNamespace, "Namespace of the Lease resource used for leader election. Defaults to the namespace of the controller when running in-cluster.")
+	fs.DurationVar(&o.LeaderElectionLeaseDuration, "leader-election-lease-duration", o.LeaderElectionLeaseDuration, "Duration non-leader candidates wait after observing a leadership renewal before attempting to acquire leadership.")
+	fs.DurationVar(&o.LeaderElectionRenewDeadline, "leader-election-renew-deadline", o.LeaderElectionRenewDeadline, "Duration the acting leader will retry refreshing leadership before giving it up.")
+	fs.DurationVar(&o.LeaderElectionRetryPeriod, "leader-election-retry-period", o.LeaderElectionRetryPeriod, "Duration leader election clients wait between attempts of actions.")
+
+	fs.StringVar(&o.HealthProbeBindAddress, "health-probe-bind-address", o.HealthProbeBindAddress, "Address the liveness (/healthz) and readiness (/readyz) probe endpoints bind to. Set to \"0\" to disable.")
+	fs.StringVar(&o.MetricsBindAddress, "metrics-bind-address", o.MetricsBindAddress, "Address the Prometheus metrics endpoint binds to. Set to \"0\" to disable.")
+	fs.StringVar(&o.AdminBindAddress, "admin-bind-address", o.AdminBindAddress, "Address the administrative endpoints, such as /debug/loglevel, bind to. They are unauthenticated, so bind to localhost unless access is otherwise restricted. Set to \"0\" to disable.")
+
+	fs.IntVar(&o.OriginIssuerConcurrentReconciles, "origin-issuer-concurrent-reconciles", o.OriginIssuerConcurrentReconciles, "Maximum number of OriginIssuers reconciled concurrently.")
+	fs.IntVar(&o.ClusterOriginIssuerConcurrentReconciles, "cluster-origin-issuer-concurrent-reconciles", o.ClusterOriginIssuerConcurrentReconciles, "Maximum number of ClusterOriginIssuers reconciled concurrently.")
+	fs.IntVar(&o.CertificateRequestConcurrentReconciles, "certificate-request-concurrent-reconciles", o.CertificateRequestConcurrentReconciles, "Maximum number of CertificateRequests reconciled concurrently.")
+	fs.IntVar(&o.OriginCertificateConcurrentReconciles, "origin-certificate-concurrent-reconciles", o.OriginCertificateConcurrentReconciles, "Maximum number of OriginCertificates reconciled concurrently.")
+	fs.IntVar(&o.CertificateSigningRequestConcurrentReconciles, "certificate-signing-request-concurrent-reconciles", o.CertificateSigningRequestConcurrentReconciles, "Maximum number of CertificateSigningRequests reconciled concurrently.")
+	fs.IntVar(&o.MaxConcurrentSignsPerIssuer, "max-concurrent-signs-per-issuer", o.MaxConcurrentSignsPerIssuer, "Maximum number of CertificateRequests signed concurrently for a single issuer. Zero is unbounded.")
+
+	fs.DurationVar(&o.SyncPeriod, "sync-period", o.SyncPeriod, "Minimum frequency at which all watched resources are reconciled.")
+	fs.DurationVar(&o.RetryBaseDelay, "retry-base-delay", o.RetryBaseDelay, "Initial delay before retrying a failed reconcile, doubled on each subsequent failure.")
+	fs.DurationVar(&o.RetryMaxDelay, "retry-max-delay", o.RetryMaxDelay, "Maximum delay before retrying a failed reconcile.")
+	fs.Float64Var(&o.RateLimitQPS, "rate-limit-qps", o.RateLimitQPS, "Maximum queries-per-second of reconciles queued by each controller.")
+	fs.IntVar(&o.RateLimitBurst, "rate-limit-burst", o.RateLimitBurst, "Maximum burst of reconciles queued by each controller.")
+
+	fs.StringVar(&o.LogLevel, "log-level", o.LogLevel, "Log level, one of error, warn, info, debug, trace, or a numeric verbosity.")
+	fs.StringVar(&o.LogFormat, "log-format", o.LogFormat, "Log format, one of json, console, or logfmt.")
+	fs.StringToStringVar(&o.ControllerLogLevels, "controller-log-levels", o.ControllerLogLevels, "Comma-separated list of controller=level pairs overriding log-level for individual controllers, e.g. CertificateRequest=debug.")
 }
 
 func (o *ControllerOptions) Validate() error {
@@ -42,8 +225,190 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("invalid value for kube-api-qps: %v must be higher than 0", o.KubernetesAPIQPS)
 	}
 
-	if o.ClusterResourceNamespace == "" {
-		return fmt.Errorf("invalid value for cluster-resource-namespace: must be set")
+	if o.OriginIssuerConcurrentReconciles <= 0 {
+		return fmt.Errorf("invalid value for origin-issuer-concurrent-reconciles: %v must be higher than 0", o.OriginIssuerConcurrentReconciles)
+	}
+
+	if o.ClusterOriginIssuerConcurrentReconciles <= 0 {
+		return fmt.Errorf("invalid value for cluster-origin-issuer-concurrent-reconciles: %v must be higher than 0", o.ClusterOriginIssuerConcurrentReconciles)
+	}
+
+	if o.CertificateRequestConcurrentReconciles <= 0 {
+		return fmt.Errorf("invalid value for certificate-request-concurrent-reconciles: %v must be higher than 0", o.CertificateRequestConcurrentReconciles)
+	}
+
+	if o.OriginCertificateConcurrentReconciles <= 0 {
+		return fmt.Errorf("invalid value for origin-certificate-concurrent-reconciles: %v must be higher than 0", o.OriginCertificateConcurrentReconciles)
+	}
+
+	if o.CertificateSigningRequestConcurrentReconciles <= 0 {
+		return fmt.Errorf("invalid value for certificate-signing-request-concurrent-reconciles: %v must be higher than 0", o.CertificateSigningRequestConcurrentReconciles)
+	}
+
+	if o.MaxConcurrentSignsPerIssuer < 0 {
+		return fmt.Errorf("invalid value for max-concurrent-signs-per-issuer: %v must not be negative", o.MaxConcurrentSignsPerIssuer)
+	}
+
+	if o.SyncPeriod <= 0 {
+		return fmt.Errorf("invalid value for sync-period: %v must be higher than 0", o.SyncPeriod)
+	}
+
+	if o.RetryBaseDelay <= 0 {
+		return fmt.Errorf("invalid value for retry-base-delay: %v must be higher than 0", o.RetryBaseDelay)
+	}
+
+	if o.RetryMaxDelay < o.RetryBaseDelay {
+		return fmt.Errorf("invalid value for retry-max-delay: %v must not be lower than retry-base-delay %v", o.RetryMaxDelay, o.RetryBaseDelay)
+	}
+
+	if o.RateLimitQPS <= 0 {
+		return fmt.Errorf("invalid value for rate-limit-qps: %v must be higher than 0", o.RateLimitQPS)
+	}
+
+	if o.RateLimitBurst <= 0 {
+		return fmt.Errorf("invalid value for rate-limit-burst: %v must be higher than 0", o.RateLimitBurst)
+	}
+
+	if _, err := logging.ParseLevel(o.LogLevel); err != nil {
+		return fmt.Errorf("invalid value for log-level: %w", err)
+	}
+
+	if !slices.Contains(logging.Formats, o.LogFormat) {
+		return fmt.Errorf("invalid value for log-format: %q must be one of %v", o.LogFormat, logging.Formats)
+	}
+
+	for name, level := range o.ControllerLogLevels {
+		if !slices.Contains(ControllerNames, name) {
+			return fmt.Errorf("invalid value for controller-log-levels: unknown controller %q must be one of %v", name, ControllerNames)
+		}
+
+		if _, err := logging.ParseLevel(level); err != nil {
+			return fmt.Errorf("invalid value for controller-log-levels: %w", err)
+		}
+	}
+
+	for i, window := range o.CertificateExpiryWindows {
+		if window <= 0 {
+			return fmt.Errorf("invalid value for certificate-expiry-windows: %v must be higher than 0", window)
+		}
+
+		if slices.Contains(o.CertificateExpiryWindows[:i], window) {
+			return fmt.Errorf("invalid value for certificate-expiry-windows: %v must not be repeated", window)
+		}
+	}
+
+	if o.MaxRetryDuration < 0 {
+		return fmt.Errorf("invalid value for max-retry-duration: %v must not be negative", o.MaxRetryDuration)
+	}
+
+	if o.APIBreakerThreshold < 0 {
+		return fmt.Errorf("invalid value for api-breaker-threshold: %v must not be negative", o.APIBreakerThreshold)
+	}
+
+	if o.APIBreakerCooldown <= 0 {
+		return fmt.Errorf("invalid value for api-breaker-cooldown: %v must be higher than 0", o.APIBreakerCooldown)
+	}
+
+	if o.EnableApprover && o.DisableCertificateRequests {
+		return fmt.Errorf("invalid value for enable-approver: cannot be set when disable-certificate-requests is set")
+	}
+
+	if o.ClusterResourceNamespace == "" && !o.DisableClusterOriginIssuer {
+		return fmt.Errorf("invalid value for cluster-resource-namespace: must be set unless disable-cluster-origin-issuer is set")
+	}
+
+	for _, ns := range o.ClusterSecretNamespaces {
+		if ns == "" {
+			return fmt.Errorf("invalid value for cluster-secret-namespaces: must not contain empty namespaces")
+		}
+	}
+
+	if errs := validation.IsDNS1123Subdomain(o.CSRSignerDomain); len(errs) > 0 {
+		return fmt.Errorf("invalid value for csr-signer-domain: %q %s", o.CSRSignerDomain, strings.Join(errs, ", "))
+	}
+
+	if errs := validation.IsDNS1123Subdomain(o.LocalCASecret); len(errs) > 0 {
+		return fmt.Errorf("invalid value for local-ca-secret: %q %s", o.LocalCASecret, strings.Join(errs, ", "))
+	}
+
+	if o.LocalSigning && o.ClusterResourceNamespace == "" {
+		return fmt.Errorf("invalid value for cluster-resource-namespace: must be set when local-signing is set, to store the local CA")
+	}
+
+	if o.AllowLocalSigningMode && o.ClusterResourceNamespace == "" {
+		return fmt.Errorf("invalid value for cluster-resource-namespace: must be set when allow-local-signing-mode is set, to store the local CA")
+	}
+
+	for _, endpoint := range o.AllowedAPIEndpoints {
+		if err := validateAllowedURL(endpoint); err != nil {
+			return fmt.Errorf("invalid value for allowed-api-endpoints: %q %w", endpoint, err)
+		}
+
+		if strings.HasPrefix(endpoint, "http://") && !o.AllowInsecureAPIEndpoints {
+			return fmt.Errorf("invalid value for allowed-api-endpoints: %q must use https unless allow-insecure-api-endpoints is set", endpoint)
+		}
+	}
+
+	for _, proxy := range o.AllowedAPIProxies {
+		if err := validateAllowedURL(proxy); err != nil {
+			return fmt.Errorf("invalid value for allowed-api-proxies: %q %w", proxy, err)
+		}
+	}
+
+	for _, dir := range o.CredentialDirectories {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("invalid value for credential-directories: %q must be an absolute path", dir)
+		}
+	}
+
+	for _, ns := range o.Namespaces {
+		if ns == "" {
+			return fmt.Errorf("invalid value for namespaces: must not contain empty namespaces")
+		}
+	}
+
+	if o.LeaderElect {
+		if o.LeaderElectionID == "" {
+			return fmt.Errorf("invalid value for leader-election-id: must be set when leader election is enabled")
+		}
+
+		if o.LeaderElectionRetryPeriod <= 0 {
+			return fmt.Errorf("invalid value for leader-election-retry-period: %v must be higher than 0", o.LeaderElectionRetryPeriod)
+		}
+
+		if o.LeaderElectionRenewDeadline <= o.LeaderElectionRetryPeriod {
+			return fmt.Errorf("invalid value for leader-election-renew-deadline: %v must be higher than leader-election-retry-period %v", o.LeaderElectionRenewDeadline, o.LeaderElectionRetryPeriod)
+		}
+
+		if o.LeaderElectionLeaseDuration <= o.LeaderElectionRenewDeadline {
+			return fmt.Errorf("invalid value for leader-election-lease-duration: %v must be higher than leader-election-renew-deadline %v", o.LeaderElectionLeaseDuration, o.LeaderElectionRenewDeadline)
+		}
+	}
+
+	return nil
+}
+
+// ControllerLogLevel returns the log level of the named controller, falling back to
+// the global log level.
+func (o *ControllerOptions) ControllerLogLevel(name string) string {
+	if level, ok := o.ControllerLogLevels[name]; ok {
+		return level
+	}
+
+	return o.LogLevel
+}
+
+// validateAllowedURL ensures s is an http or https URL with a host, as the URLs allowed for the
+// Cloudflare API settings of issuers are matched by scheme and host.
+func validateAllowedURL(s string) error {
+	u, err := url.Parse(s)
+	switch {
+	case err != nil:
+		return errors.New("must be a URL")
+	case u.Scheme != "http" && u.Scheme != "https":
+		return errors.New("must use http or https")
+	case u.Host == "":
+		return errors.New("must have a host")
 	}
 
 	return nil
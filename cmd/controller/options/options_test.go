@@ -0,0 +1,328 @@
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestLoadPrecedence(t *testing.T) {
+	config := writeConfig(t, `
+apiVersion: controller.cert-manager.k8s.cloudflare.com/v1alpha1
+kind: ControllerConfiguration
+clusterResourceNamespace: from-file
+namespaces: [tenant-a, tenant-b]
+kubernetesAPI:
+  qps: 5
+  burst: 10
+leaderElection:
+  enabled: true
+syncPeriod: 1h
+rateLimiter:
+  maxDelay: 5m
+certificateExpiryWindows: [24h, 336h]
+apiAccess:
+  allowedEndpoints: [https://api.cloudflare.example]
+`)
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		validate func(t *testing.T, o *ControllerOptions)
+	}{
+		{
+			name: "defaults without configuration file",
+			validate: func(t *testing.T, o *ControllerOptions) {
+				assert.DeepEqual(t, o, NewControllerOptions())
+			},
+		},
+		{
+			name: "configuration file overrides defaults",
+			args: []string{"--config", config},
+			validate: func(t *testing.T, o *ControllerOptions) {
+				assert.Equal(t, o.ClusterResourceNamespace, "from-file")
+				assert.DeepEqual(t, o.Namespaces, []string{"tenant-a", "tenant-b"})
+				assert.Equal(t, o.KubernetesAPIQPS, float32(5))
+				assert.Equal(t, o.KubernetesAPIBurst, 10)
+				assert.Equal(t, o.LeaderElect, true)
+				assert.Equal(t, o.SyncPeriod, time.Hour)
+				assert.Equal(t, o.RetryMaxDelay, 5*time.Minute)
+				assert.DeepEqual(t, o.CertificateExpiryWindows, []time.Duration{24 * time.Hour, 14 * 24 * time.Hour})
+				assert.DeepEqual(t, o.AllowedAPIEndpoints, []string{"https://api.cloudflare.example"})
+				assert.Equal(t, o.RetryBaseDelay, defaultRetryBaseDelay, "unset values keep their defaults")
+			},
+		},
+		{
+			name: "environment overrides configuration file",
+			args: []string{"--config", config},
+			env: map[string]string{
+				"ORIGIN_CA_ISSUER_CLUSTER_RESOURCE_NAMESPACE": "from-env",
+				"ORIGIN_CA_ISSUER_NAMESPACES":                 "tenant-c",
+				"ORIGIN_CA_ISSUER_LEADER_ELECT":               "false",
+			},
+			validate: func(t *testing.T, o *ControllerOptions) {
+				assert.Equal(t, o.ClusterResourceNamespace, "from-env")
+				assert.DeepEqual(t, o.Namespaces, []string{"tenant-c"})
+				assert.Equal(t, o.LeaderElect, false)
+				assert.Equal(t, o.SyncPeriod, time.Hour)
+			},
+		},
+		{
+			name: "flags override environment and configuration file",
+			args: []string{"--config", config, "--cluster-resource-namespace", "from-flag", "--sync-period", "2h"},
+			env: map[string]string{
+				"ORIGIN_CA_ISSUER_CLUSTER_RESOURCE_NAMESPACE": "from-env",
+			},
+			validate: func(t *testing.T, o *ControllerOptions) {
+				assert.Equal(t, o.ClusterResourceNamespace, "from-flag")
+				assert.Equal(t, o.SyncPeriod, 2*time.Hour)
+				assert.Equal(t, o.KubernetesAPIBurst, 10)
+			},
+		},
+		{
+			name: "configuration file from environment",
+			env: map[string]string{
+				"ORIGIN_CA_ISSUER_CONFIG": config,
+			},
+			validate: func(t *testing.T, o *ControllerOptions) {
+				assert.Equal(t, o.ClusterResourceNamespace, "from-file")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			o := NewControllerOptions()
+			o.AddFlags(fs)
+
+			assert.NilError(t, fs.Parse(tt.args))
+			assert.NilError(t, o.Load(fs, lookupMap(tt.env)))
+
+			o.ConfigFile = ""
+			tt.validate(t, o)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		env    map[string]string
+		error  string
+	}{
+		{
+			name: "unknown field",
+			config: `
+apiVersion: controller.cert-manager.k8s.cloudflare.com/v1alpha1
+kind: ControllerConfiguration
+clusterResourceNamespaces: typo
+`,
+			error: `unknown field "clusterResourceNamespaces"`,
+		},
+		{
+			name: "unknown apiVersion",
+			config: `
+apiVersion: controller.cert-manager.k8s.cloudflare.com/v1
+kind: ControllerConfiguration
+`,
+			error: `"controller.cert-manager.k8s.cloudflare.com/v1" must be "controller.cert-manager.k8s.cloudflare.com/v1alpha1"`,
+		},
+		{
+			name: "invalid environment variable",
+			config: `
+apiVersion: controller.cert-manager.k8s.cloudflare.com/v1alpha1
+kind: ControllerConfiguration
+`,
+			env: map[string]string{
+				"ORIGIN_CA_ISSUER_KUBE_API_BURST": "lots",
+			},
+			error: "invalid value for ORIGIN_CA_ISSUER_KUBE_API_BURST",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			o := NewControllerOptions()
+			o.AddFlags(fs)
+
+			assert.NilError(t, fs.Parse([]string{"--config", writeConfig(t, tt.config)}))
+			assert.ErrorContains(t, o.Load(fs, lookupMap(tt.env)), tt.error)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(o *ControllerOptions)
+		error  string
+	}{
+		{
+			name:   "valid",
+			modify: func(o *ControllerOptions) {},
+		},
+		{
+			name:   "missing cluster resource namespace",
+			modify: func(o *ControllerOptions) { o.ClusterResourceNamespace = "" },
+			error:  "invalid value for cluster-resource-namespace: must be set unless disable-cluster-origin-issuer is set",
+		},
+		{
+			name: "cluster resource namespace not required without cluster issuers",
+			modify: func(o *ControllerOptions) {
+				o.ClusterResourceNamespace = ""
+				o.DisableClusterOriginIssuer = true
+			},
+		},
+		{
+			name:   "empty cluster secret namespace",
+			modify: func(o *ControllerOptions) { o.ClusterSecretNamespaces = []string{"team-secrets", ""} },
+			error:  "invalid value for cluster-secret-namespaces: must not contain empty namespaces",
+		},
+		{
+			name:   "no concurrent origin certificate reconciles",
+			modify: func(o *ControllerOptions) { o.OriginCertificateConcurrentReconciles = 0 },
+			error:  "invalid value for origin-certificate-concurrent-reconciles: 0 must be higher than 0",
+		},
+		{
+			name:   "invalid csr signer domain",
+			modify: func(o *ControllerOptions) { o.CSRSignerDomain = "Example.com/" },
+			error:  `invalid value for csr-signer-domain: "Example.com/" a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+		},
+		{
+			name:   "invalid local ca secret",
+			modify: func(o *ControllerOptions) { o.LocalCASecret = "" },
+			error:  `invalid value for local-ca-secret: "" a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+		},
+		{
+			name: "local signing without cluster resource namespace",
+			modify: func(o *ControllerOptions) {
+				o.LocalSigning = true
+				o.DisableClusterOriginIssuer = true
+				o.ClusterResourceNamespace = ""
+			},
+			error: "invalid value for cluster-resource-namespace: must be set when local-signing is set, to store the local CA",
+		},
+		{
+			name: "local signing mode without cluster resource namespace",
+			modify: func(o *ControllerOptions) {
+				o.AllowLocalSigningMode = true
+				o.DisableClusterOriginIssuer = true
+				o.ClusterResourceNamespace = ""
+			},
+			error: "invalid value for cluster-resource-namespace: must be set when allow-local-signing-mode is set, to store the local CA",
+		},
+		{
+			name:   "negative api breaker threshold",
+			modify: func(o *ControllerOptions) { o.APIBreakerThreshold = -1 },
+			error:  "invalid value for api-breaker-threshold: -1 must not be negative",
+		},
+		{
+			name:   "non-positive api breaker cooldown",
+			modify: func(o *ControllerOptions) { o.APIBreakerCooldown = 0 },
+			error:  "invalid value for api-breaker-cooldown: 0s must be higher than 0",
+		},
+		{
+			name: "approver without certificate requests",
+			modify: func(o *ControllerOptions) {
+				o.EnableApprover = true
+				o.DisableCertificateRequests = true
+			},
+			error: "invalid value for enable-approver: cannot be set when disable-certificate-requests is set",
+		},
+		{
+			name:   "non-positive certificate expiry window",
+			modify: func(o *ControllerOptions) { o.CertificateExpiryWindows = []time.Duration{time.Hour, 0} },
+			error:  "invalid value for certificate-expiry-windows: 0s must be higher than 0",
+		},
+		{
+			name: "repeated certificate expiry window",
+			modify: func(o *ControllerOptions) {
+				o.CertificateExpiryWindows = []time.Duration{24 * time.Hour, 1440 * time.Minute}
+			},
+			error: "invalid value for certificate-expiry-windows: 24h0m0s must not be repeated",
+		},
+		{
+			name:   "relative credential directory",
+			modify: func(o *ControllerOptions) { o.CredentialDirectories = []string{"secrets"} },
+			error:  `invalid value for credential-directories: "secrets" must be an absolute path`,
+		},
+		{
+			name:   "allowed api endpoint without scheme",
+			modify: func(o *ControllerOptions) { o.AllowedAPIEndpoints = []string{"api.cloudflare.example"} },
+			error:  `invalid value for allowed-api-endpoints: "api.cloudflare.example" must use http or https`,
+		},
+		{
+			name:   "insecure allowed api endpoint",
+			modify: func(o *ControllerOptions) { o.AllowedAPIEndpoints = []string{"http://api.cloudflare.example"} },
+			error:  `invalid value for allowed-api-endpoints: "http://api.cloudflare.example" must use https unless allow-insecure-api-endpoints is set`,
+		},
+		{
+			name: "allowed insecure api endpoint",
+			modify: func(o *ControllerOptions) {
+				o.AllowInsecureAPIEndpoints = true
+				o.AllowedAPIEndpoints = []string{"http://api.cloudflare.example"}
+			},
+		},
+		{
+			name:   "allowed api proxy without host",
+			modify: func(o *ControllerOptions) { o.AllowedAPIProxies = []string{"http://"} },
+			error:  `invalid value for allowed-api-proxies: "http://" must have a host`,
+		},
+		{
+			name: "renew deadline longer than lease duration",
+			modify: func(o *ControllerOptions) {
+				o.LeaderElect = true
+				o.LeaderElectionRenewDeadline = time.Minute
+			},
+			error: "invalid value for leader-election-lease-duration: 15s must be higher than leader-election-renew-deadline 1m0s",
+		},
+		{
+			name:   "max delay shorter than base delay",
+			modify: func(o *ControllerOptions) { o.RetryMaxDelay = time.Millisecond },
+			error:  "invalid value for retry-max-delay: 1ms must not be lower than retry-base-delay 5ms",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewControllerOptions()
+			o.ClusterResourceNamespace = "origin-ca-issuer"
+			tt.modify(o)
+
+			if tt.error != "" {
+				assert.Error(t, o.Validate(), tt.error)
+			} else {
+				assert.NilError(t, o.Validate())
+			}
+		})
+	}
+}
+
+func writeConfig(t *testing.T, config string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func lookupMap(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
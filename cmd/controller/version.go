@@ -0,0 +1,53 @@
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "devel"
+
+// buildInfo describes the running binary.
+type buildInfo struct {
+	Version   string
+	Revision  string
+	GoVersion string
+}
+
+func readBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   version,
+		Revision:  "unknown",
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" {
+				info.Revision = s.Value
+			}
+		}
+	}
+
+	return info
+}
+
+// userAgent is sent with every request to the Cloudflare API.
+func (b buildInfo) userAgent() string {
+	return "github.com/cloudflare/origin-ca-issuer/" + b.Version
+}
+
+// registerBuildInfoMetric exposes b as the origin_ca_issuer_build_info metric.
+func registerBuildInfoMetric(b buildInfo) error {
+	g := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "origin_ca_issuer_build_info",
+		Help: "A metric with a constant '1' value labeled by the version, revision, and Go version the controller was built from.",
+	}, []string{"version", "revision", "goversion"})
+	g.WithLabelValues(b.Version, b.Revision, b.GoVersion).Set(1)
+
+	return metrics.Registry.Register(g)
+}
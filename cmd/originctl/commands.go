@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"github.com/spf13/pflag"
+)
+
+var signCommand = command{
+	name:    "sign",
+	summary: "Sign a certificate signing request, printing the certificate",
+	setup: func(fs *pflag.FlagSet) func(ctx context.Context, o *options, args []string) error {
+		var csrFile, requestType string
+		var hostnames []string
+		validity := provisioners.DefaultDurationInternval
+
+		fs.StringVar(&csrFile, "csr", "", `PEM-encoded certificate signing request to sign, or "-" to read it from stdin.`)
+		fs.StringSliceVar(&hostnames, "hostnames", nil, "Comma-separated list of hostnames of the certificate. Defaults to the DNS names of the request.")
+		fs.IntVar(&validity, "validity", validity, "Validity of the certificate in days, one of 7, 30, 90, 365, 730, 1095 or 5475.")
+		fs.StringVar(&requestType, "request-type", "", "Signature type of the certificate, origin-ecc or origin-rsa. Defaults to the request type of the issuer, or the key type of the request.")
+
+		return func(ctx context.Context, o *options, args []string) error {
+			if err := exactArgs(args, 0, "none"); err != nil {
+				return err
+			}
+
+			if csrFile == "" {
+				return errors.New("--csr must be set")
+			}
+
+			if allowed := provisioners.AllowedValidities(); !slices.Contains(allowed, validity) {
+				return fmt.Errorf("invalid value for validity: %d must be one of %v", validity, allowed)
+			}
+
+			var pem []byte
+			var err error
+			if csrFile == "-" {
+				pem, err = io.ReadAll(o.stdin)
+			} else {
+				pem, err = os.ReadFile(csrFile)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read certificate signing request: %w", err)
+			}
+
+			csr, err := pki.DecodeX509CertificateRequestBytes(pem)
+			if err != nil {
+				return fmt.Errorf("failed to decode certificate signing request: %w", err)
+			}
+
+			c, spec, err := apiClient(ctx, o)
+			if err != nil {
+				return err
+			}
+
+			if len(hostnames) == 0 {
+				hostnames = csr.DNSNames
+			}
+
+			if requestType == "" {
+				requestType = defaultRequestType(spec, csr.PublicKey)
+			}
+
+			resp, err := c.Sign(ctx, &cfapi.SignRequest{
+				Hostnames: hostnames,
+				Validity:  validity,
+				Type:      requestType,
+				CSR:       string(pem),
+			})
+			if err != nil {
+				return err
+			}
+
+			if o.Output == "table" {
+				_, err := io.WriteString(o.stdout, resp.Certificate)
+				return err
+			}
+
+			return printCertificate(o, *resp)
+		}
+	},
+}
+
+var listCommand = command{
+	name:    "list",
+	summary: "List the certificates of a zone",
+	setup: func(fs *pflag.FlagSet) func(ctx context.Context, o *options, args []string) error {
+		var zone string
+
+		fs.StringVar(&zone, "zone", "", "ID of the zone to list the certificates of.")
+
+		return func(ctx context.Context, o *options, args []string) error {
+			if err := exactArgs(args, 0, "none"); err != nil {
+				return err
+			}
+
+			if zone == "" {
+				return errors.New("--zone must be set")
+			}
+
+			c, _, err := apiClient(ctx, o)
+			if err != nil {
+				return err
+			}
+
+			certs, err := c.List(ctx, zone)
+			if err != nil {
+				return err
+			}
+
+			return printCertificates(o, certs)
+		}
+	},
+}
+
+var getCommand = command{
+	name:    "get",
+	args:    "<certificate-id>",
+	summary: "Get a certificate",
+	setup: func(fs *pflag.FlagSet) func(ctx context.Context, o *options, args []string) error {
+		return func(ctx context.Context, o *options, args []string) error {
+			if err := exactArgs(args, 1, "<certificate-id>"); err != nil {
+				return err
+			}
+
+			c, _, err := apiClient(ctx, o)
+			if err != nil {
+				return err
+			}
+
+			cert, err := c.Get(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return printCertificate(o, *cert)
+		}
+	},
+}
+
+var revokeCommand = command{
+	name:    "revoke",
+	args:    "<certificate-id>",
+	summary: "Revoke a certificate",
+	setup: func(fs *pflag.FlagSet) func(ctx context.Context, o *options, args []string) error {
+		return func(ctx context.Context, o *options, args []string) error {
+			if err := exactArgs(args, 1, "<certificate-id>"); err != nil {
+				return err
+			}
+
+			c, _, err := apiClient(ctx, o)
+			if err != nil {
+				return err
+			}
+
+			if err := c.Revoke(ctx, args[0]); err != nil {
+				return err
+			}
+
+			if o.Output == "json" {
+				return printJSON(o.stdout, map[string]string{"id": args[0]})
+			}
+
+			_, err = fmt.Fprintf(o.stdout, "certificate %s revoked\n", args[0])
+			return err
+		}
+	},
+}
+
+var verifyCommand = command{
+	name:    "verify",
+	summary: "Verify the API token",
+	setup: func(fs *pflag.FlagSet) func(ctx context.Context, o *options, args []string) error {
+		return func(ctx context.Context, o *options, args []string) error {
+			if err := exactArgs(args, 0, "none"); err != nil {
+				return err
+			}
+
+			c, _, err := apiClient(ctx, o)
+			if err != nil {
+				return err
+			}
+
+			status, err := c.VerifyToken(ctx)
+			if err != nil {
+				return err
+			}
+
+			return printTokenStatus(o, status)
+		}
+	},
+}
+
+// defaultRequestType returns the request type of the issuer, if any, or the request type matching
+// the type of key.
+func defaultRequestType(spec *v1.OriginIssuerSpec, key any) string {
+	if spec != nil {
+		switch spec.RequestType {
+		case v1.RequestTypeOriginECC:
+			return "origin-ecc"
+		case v1.RequestTypeOriginRSA:
+			return "origin-rsa"
+		}
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey:
+		return "origin-ecc"
+	case *rsa.PublicKey:
+		return "origin-rsa"
+	}
+
+	return "origin-ecc"
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/controllers"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiClient returns a Cloudflare API client authenticating with the configured credentials, along
+// with the spec of the issuer the credentials were read from, if any. Credentials are read from, in
+// order, --token-file or --service-key-file, the Secret of --issuer, or the environment.
+func apiClient(ctx context.Context, o *options) (*cfapi.Client, *v1.OriginIssuerSpec, error) {
+	b := cfapi.NewBuilder().WithUserAgent("github.com/cloudflare/origin-ca-issuer/originctl/" + version)
+
+	set := 0
+	for _, v := range []string{o.TokenFile, o.ServiceKeyFile, o.Issuer} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, nil, fmt.Errorf("only one of --token-file, --service-key-file or --issuer may be set")
+	}
+
+	if o.Issuer != "" {
+		return issuerClient(ctx, o, b)
+	}
+
+	switch {
+	case o.TokenFile != "":
+		token, err := readFile(o.TokenFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		b.WithToken(token)
+	case o.ServiceKeyFile != "":
+		key, err := readFile(o.ServiceKeyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		b.WithServiceKey(key)
+	default:
+		if token, ok := o.lookupEnv(tokenEnv); ok && token != "" {
+			b.WithToken([]byte(token))
+		} else if key, ok := o.lookupEnv(serviceKeyEnv); ok && key != "" {
+			b.WithServiceKey([]byte(key))
+		} else {
+			return nil, nil, fmt.Errorf("no credentials configured: set --token-file, --service-key-file, --issuer, or the %s or %s environment variables", tokenEnv, serviceKeyEnv)
+		}
+	}
+
+	if o.Endpoint != "" {
+		b.WithEndpoint(o.Endpoint)
+	}
+
+	c, err := b.Build()
+	return c, nil, err
+}
+
+// issuerClient returns a Cloudflare API client authenticating with a credential of --issuer.
+func issuerClient(ctx context.Context, o *options, b *cfapi.Builder) (*cfapi.Client, *v1.OriginIssuerSpec, error) {
+	kind, name, err := parseIssuer(o.Issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kc, namespace, err := o.kubeClient(o)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	if o.Namespace != "" {
+		namespace = o.Namespace
+	}
+
+	c, spec, err := controllers.IssuerClient(ctx, kc, b, controllers.IssuerClientConfig{
+		Kind:                     kind,
+		Name:                     name,
+		Namespace:                namespace,
+		Credential:               o.Credential,
+		ClusterResourceNamespace: o.ClusterResourceNamespace,
+		ClusterSecretNamespaces:  o.ClusterSecretNamespaces,
+		Endpoint:                 o.Endpoint,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c, &spec, nil
+}
+
+// parseIssuer parses an issuer reference such as originissuer/name or clusteroriginissuer/name. A
+// name without kind references an OriginIssuer.
+func parseIssuer(ref string) (kind, name string, err error) {
+	kind, name, ok := strings.Cut(ref, "/")
+	if !ok {
+		kind, name = "originissuer", ref
+	}
+
+	switch strings.ToLower(kind) {
+	case "originissuer", "originissuers":
+		kind = "OriginIssuer"
+	case "clusteroriginissuer", "clusteroriginissuers":
+		kind = "ClusterOriginIssuer"
+	default:
+		return "", "", fmt.Errorf("invalid value for issuer: unknown issuer kind %q", kind)
+	}
+
+	if name == "" {
+		return "", "", fmt.Errorf("invalid value for issuer: %q does not contain an issuer name", ref)
+	}
+
+	return kind, name, nil
+}
+
+// kubeClient returns a Kubernetes client for the kubeconfig, along with the namespace of its context.
+func kubeClient(o *options) (client.Client, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.Kubeconfig
+
+	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: o.Context})
+
+	cfg, err := cc.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	namespace, _, err := cc.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+	if err := v1.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return c, namespace, nil
+}
+
+// readFile reads a credential from path, trimming surrounding whitespace.
+func readFile(path string) ([]byte, error) {
+	p, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(p), nil
+}
@@ -0,0 +1,13 @@
+/*
+Originctl runs Cloudflare Origin CA operations, such as signing,
+listing, inspecting and revoking certificates, and verifying API tokens.
+
+Credentials are read from a file, from the environment, or from the
+Secret referenced by an OriginIssuer or ClusterOriginIssuer using the
+kubeconfig.
+
+Usage:
+
+	originctl <command> [flags]
+*/
+package main
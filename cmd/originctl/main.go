@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "devel"
+
+// Environment variables credentials are read from when no other credentials are configured.
+const (
+	tokenEnv      = "CLOUDFLARE_API_TOKEN"
+	serviceKeyEnv = "CLOUDFLARE_API_USER_SERVICE_KEY"
+)
+
+// outputFormats are the supported values of --output.
+var outputFormats = []string{"table", "json"}
+
+// options are the flags shared by every command.
+type options struct {
+	TokenFile      string
+	ServiceKeyFile string
+
+	Issuer                   string
+	Credential               string
+	Namespace                string
+	Kubeconfig               string
+	Context                  string
+	ClusterResourceNamespace string
+	ClusterSecretNamespaces  []string
+
+	Endpoint string
+	Timeout  time.Duration
+	Output   string
+
+	stdout    io.Writer
+	stdin     io.Reader
+	lookupEnv func(string) (string, bool)
+
+	// kubeClient returns a Kubernetes client and the default namespace of the kubeconfig.
+	kubeClient func(o *options) (client.Client, string, error)
+}
+
+func (o *options) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.TokenFile, "token-file", o.TokenFile, "File containing the API token to authenticate with.")
+	fs.StringVar(&o.ServiceKeyFile, "service-key-file", o.ServiceKeyFile, "File containing the Origin CA service key to authenticate with.")
+	fs.StringVar(&o.Issuer, "issuer", o.Issuer, "Authenticate with the credentials of an issuer, read from its Secret using the kubeconfig, e.g. originissuer/prod-issuer or clusteroriginissuer/prod-issuer.")
+	fs.StringVar(&o.Credential, "credential", o.Credential, "Name of the issuer credential, listed in spec.auth.credentials, to authenticate with. Defaults to the first credential.")
+	fs.StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "Namespace of the OriginIssuer. Defaults to the namespace of the kubeconfig context.")
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file.")
+	fs.StringVar(&o.Context, "context", o.Context, "Name of the kubeconfig context to use.")
+	fs.StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", o.ClusterResourceNamespace, "Namespace the controller reads ClusterOriginIssuer secrets from.")
+	fs.StringSliceVar(&o.ClusterSecretNamespaces, "cluster-secret-namespaces", o.ClusterSecretNamespaces, "Comma-separated list of namespaces, besides cluster-resource-namespace, ClusterOriginIssuers may select secrets from.")
+	fs.StringVar(&o.Endpoint, "endpoint", o.Endpoint, "Base URL of the Cloudflare API. Defaults to the endpoint of the issuer, or https://api.cloudflare.com.")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "Timeout of the command.")
+	fs.StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("Output format, one of %v.", outputFormats))
+}
+
+// command is a subcommand of originctl.
+type command struct {
+	name    string
+	args    string
+	summary string
+
+	// setup registers the flags of the command, and returns the function running it
+	// with the remaining positional arguments.
+	setup func(fs *pflag.FlagSet) func(ctx context.Context, o *options, args []string) error
+}
+
+var commands = []command{
+	signCommand,
+	listCommand,
+	getCommand,
+	revokeCommand,
+	verifyCommand,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	o := &options{
+		stdout:     os.Stdout,
+		stdin:      os.Stdin,
+		lookupEnv:  os.LookupEnv,
+		kubeClient: kubeClient,
+	}
+
+	if err := run(ctx, o, os.Args[1:], os.Stderr); err != nil {
+		if !errors.Is(err, pflag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+// run parses args and runs the selected command, writing usage to stderr.
+func run(ctx context.Context, o *options, args []string, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		return pflag.ErrHelp
+	}
+
+	i := slices.IndexFunc(commands, func(c command) bool { return c.name == args[0] })
+	if i < 0 {
+		usage(stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	cmd := commands[i]
+
+	o.Timeout = time.Minute
+	o.Output = "table"
+
+	fs := pflag.NewFlagSet("originctl "+cmd.name, pflag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "%s\n\nUsage:\n  originctl %s [flags] %s\n\nFlags:\n%s", cmd.summary, cmd.name, cmd.args, fs.FlagUsages())
+	}
+	o.addFlags(fs)
+	runCmd := cmd.setup(fs)
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if !slices.Contains(outputFormats, o.Output) {
+		return fmt.Errorf("invalid value for output: %q must be one of %v", o.Output, outputFormats)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	return runCmd(ctx, o, fs.Args())
+}
+
+func usage(w io.Writer) {
+	var b strings.Builder
+	for _, c := range commands {
+		fmt.Fprintf(&b, "  %-8s %s\n", c.name, c.summary)
+	}
+
+	fmt.Fprintf(w, `originctl runs Cloudflare Origin CA operations, authenticating with an API token,
+a service key, or the credentials of an OriginIssuer or ClusterOriginIssuer.
+
+Usage:
+  originctl <command> [flags]
+
+Commands:
+%s
+Run "originctl <command> --help" for the flags of a command.
+`, b.String())
+}
+
+// exactArgs returns an error unless args has n arguments.
+func exactArgs(args []string, n int, usage string) error {
+	if len(args) != n {
+		return fmt.Errorf("expected arguments %s, got %d arguments", usage, len(args))
+	}
+
+	return nil
+}
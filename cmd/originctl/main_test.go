@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRun(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+
+		var result any
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("zone_id") == "single":
+			result = []map[string]any{
+				{"id": "1", "hostnames": []string{"example.com"}, "request_type": "origin-ecc", "expires_on": "2030-01-02T03:04:05Z"},
+			}
+		case r.Method == http.MethodGet && r.URL.Query().Get("zone_id") == "zone":
+			result = []map[string]any{
+				{"id": "1", "hostnames": []string{"example.com"}, "request_type": "origin-ecc", "expires_on": "2030-01-02T03:04:05Z"},
+				{"id": "2", "hostnames": []string{"a.example.com", "b.example.com"}, "request_type": "origin-rsa", "expires_on": "2031-01-02T03:04:05Z"},
+			}
+		case r.Method == http.MethodGet:
+			result = map[string]any{"id": path.Base(r.URL.Path), "hostnames": []string{"example.com"}, "request_type": "origin-ecc", "expires_on": "2030-01-02T03:04:05Z"}
+		case r.Method == http.MethodDelete:
+			result = map[string]any{"id": path.Base(r.URL.Path)}
+		}
+
+		p, _ := json.Marshal(result)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success":     true,
+			"result":      json.RawMessage(p),
+			"result_info": map[string]int{"page": 1, "total_pages": 1},
+		})
+	}))
+	defer ts.Close()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.AddToScheme(scheme)
+
+	kc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1.OriginIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+			Spec: v1.OriginIssuerSpec{
+				RequestType: v1.RequestTypeOriginECC,
+				Auth: v1.OriginIssuerAuthentication{
+					TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+				},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("issuer-token")},
+		},
+	).Build()
+
+	tests := []struct {
+		name   string
+		args   []string
+		env    map[string]string
+		output string
+		auth   string
+		error  string
+	}{
+		{
+			name: "list table",
+			args: []string{"list", "--zone", "zone"},
+			env:  map[string]string{tokenEnv: "env-token"},
+			output: "ID  SERIAL  TYPE        EXPIRES               HOSTNAMES\n" +
+				"1           origin-ecc  2030-01-02T03:04:05Z  example.com\n" +
+				"2           origin-rsa  2031-01-02T03:04:05Z  a.example.com,b.example.com\n",
+			auth: "Bearer env-token",
+		},
+		{
+			name: "get json",
+			args: []string{"get", "-o", "json", "1"},
+			env:  map[string]string{tokenEnv: "env-token"},
+			output: `{
+  "id": "1",
+  "hostnames": [
+    "example.com"
+  ],
+  "requestType": "origin-ecc",
+  "expiresOn": "2030-01-02T03:04:05Z"
+}
+`,
+			auth: "Bearer env-token",
+		},
+		{
+			name: "list json with a single certificate",
+			args: []string{"list", "--zone", "single", "-o", "json"},
+			env:  map[string]string{tokenEnv: "env-token"},
+			output: `[
+  {
+    "id": "1",
+    "hostnames": [
+      "example.com"
+    ],
+    "requestType": "origin-ecc",
+    "expiresOn": "2030-01-02T03:04:05Z"
+  }
+]
+`,
+			auth: "Bearer env-token",
+		},
+		{
+			name:   "revoke with issuer credentials",
+			args:   []string{"revoke", "--issuer", "originissuer/foobar", "1"},
+			output: "certificate 1 revoked\n",
+			auth:   "Bearer issuer-token",
+		},
+		{
+			name:  "missing credentials",
+			args:  []string{"get", "1"},
+			error: "no credentials configured: set --token-file, --service-key-file, --issuer, or the CLOUDFLARE_API_TOKEN or CLOUDFLARE_API_USER_SERVICE_KEY environment variables",
+		},
+		{
+			name:  "conflicting credentials",
+			args:  []string{"get", "--token-file", "token", "--issuer", "foobar", "1"},
+			error: "only one of --token-file, --service-key-file or --issuer may be set",
+		},
+		{
+			name:  "missing issuer",
+			args:  []string{"get", "--issuer", "clusteroriginissuer/foobar", "1"},
+			error: `clusteroriginissuers.cert-manager.k8s.cloudflare.com "foobar" not found`,
+		},
+		{
+			name:  "missing argument",
+			args:  []string{"get"},
+			env:   map[string]string{tokenEnv: "env-token"},
+			error: "expected arguments <certificate-id>, got 0 arguments",
+		},
+		{
+			name:  "invalid validity",
+			args:  []string{"sign", "--csr", "-", "--validity", "10"},
+			env:   map[string]string{tokenEnv: "env-token"},
+			error: "invalid value for validity: 10 must be one of [7 30 90 365 730 1095 5475]",
+		},
+		{
+			name:  "invalid output",
+			args:  []string{"list", "--zone", "zone", "-o", "yaml"},
+			error: `invalid value for output: "yaml" must be one of [table json]`,
+		},
+		{
+			name:  "unknown command",
+			args:  []string{"renew"},
+			error: `unknown command "renew"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			auth = ""
+
+			var stdout, stderr bytes.Buffer
+			o := &options{
+				stdout: &stdout,
+				lookupEnv: func(key string) (string, bool) {
+					v, ok := tt.env[key]
+					return v, ok
+				},
+				kubeClient: func(o *options) (client.Client, string, error) {
+					return kc, "default", nil
+				},
+			}
+
+			args := append(tt.args, "--endpoint", ts.URL+"/client/v4/certificates")
+			err := run(context.Background(), o, args, &stderr)
+			if tt.error != "" {
+				assert.Error(t, err, tt.error)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, stdout.String(), tt.output)
+			assert.Equal(t, auth, tt.auth)
+		})
+	}
+}
+
+func TestParseIssuer(t *testing.T) {
+	tests := []struct {
+		ref   string
+		kind  string
+		name  string
+		error string
+	}{
+		{ref: "foobar", kind: "OriginIssuer", name: "foobar"},
+		{ref: "originissuer/foobar", kind: "OriginIssuer", name: "foobar"},
+		{ref: "ClusterOriginIssuer/foobar", kind: "ClusterOriginIssuer", name: "foobar"},
+		{ref: "issuer/foobar", error: `invalid value for issuer: unknown issuer kind "issuer"`},
+		{ref: "originissuer/", error: `invalid value for issuer: "originissuer/" does not contain an issuer name`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.ref, func(t *testing.T) {
+			kind, name, err := parseIssuer(tt.ref)
+			if tt.error != "" {
+				assert.Error(t, err, tt.error)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, kind, tt.kind)
+			assert.Equal(t, name, tt.name)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+)
+
+// certificate is the JSON representation of a certificate, including the details read from
+// the certificate itself.
+type certificate struct {
+	ID           string     `json:"id"`
+	SerialNumber string     `json:"serialNumber,omitempty"`
+	Hostnames    []string   `json:"hostnames"`
+	RequestType  string     `json:"requestType,omitempty"`
+	Validity     int        `json:"requestedValidity,omitempty"`
+	NotBefore    *time.Time `json:"notBefore,omitempty"`
+	ExpiresOn    time.Time  `json:"expiresOn"`
+	Certificate  string     `json:"certificate,omitempty"`
+}
+
+func newCertificate(resp cfapi.SignResponse) certificate {
+	c := certificate{
+		ID:          resp.Id,
+		Hostnames:   resp.Hostnames,
+		RequestType: resp.Type,
+		Validity:    resp.Validity,
+		ExpiresOn:   resp.Expiration,
+		Certificate: resp.Certificate,
+	}
+
+	if cert, err := pki.DecodeX509CertificateBytes([]byte(resp.Certificate)); err == nil {
+		c.SerialNumber = cert.SerialNumber.Text(16)
+		c.NotBefore = &cert.NotBefore
+	}
+
+	return c
+}
+
+// printCertificate prints cert in the configured output format, as a single JSON object.
+func printCertificate(o *options, cert cfapi.SignResponse) error {
+	if o.Output == "json" {
+		return printJSON(o.stdout, newCertificate(cert))
+	}
+
+	return printCertificates(o, []cfapi.SignResponse{cert})
+}
+
+// printCertificates prints certs in the configured output format, as a JSON array regardless
+// of the number of certificates.
+func printCertificates(o *options, certs []cfapi.SignResponse) error {
+	out := make([]certificate, len(certs))
+	for i, resp := range certs {
+		out[i] = newCertificate(resp)
+	}
+
+	if o.Output == "json" {
+		return printJSON(o.stdout, out)
+	}
+
+	w := tabwriter.NewWriter(o.stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSERIAL\tTYPE\tEXPIRES\tHOSTNAMES")
+	for _, c := range out {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ID, c.SerialNumber, c.RequestType, c.ExpiresOn.UTC().Format(time.RFC3339), strings.Join(c.Hostnames, ","))
+	}
+
+	return w.Flush()
+}
+
+// printTokenStatus prints status in the configured output format.
+func printTokenStatus(o *options, status *cfapi.TokenStatus) error {
+	if o.Output == "json" {
+		return printJSON(o.stdout, status)
+	}
+
+	expires := "never"
+	if status.ExpiresOn != nil {
+		expires = status.ExpiresOn.UTC().Format(time.RFC3339)
+	}
+
+	w := tabwriter.NewWriter(o.stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tEXPIRES")
+	fmt.Fprintf(w, "%s\t%s\t%s\n", status.ID, status.Status, expires)
+
+	return w.Flush()
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
@@ -40,3 +40,98 @@ Create the name of the service account to use
     {{ default "default" .Values.controller.serviceAccount.name }}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Whether the controller only watches controller.namespaces without cluster-scoped issuers, in which
+case it is granted Roles in those namespaces rather than a ClusterRole.
+*/}}
+{{- define "origin-ca-issuer.namespaced" -}}
+{{- if and .Values.controller.namespaces .Values.controller.disableClusterOriginIssuer -}}
+true
+{{- end -}}
+{{- end -}}
+
+{{/*
+Rules of the controller for namespaced resources, granted cluster-wide or in each watched namespace.
+*/}}
+{{- define "origin-ca-issuer.namespacedRules" -}}
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["create", "patch"]
+- apiGroups: [""]
+  resources: ["configmaps"]
+  verbs: ["get", "list", "watch"]
+{{- if .Values.global.rbac.secrets }}
+- apiGroups: [""]
+  resources: ["secrets"]
+  verbs: ["get", "list", "watch"]
+{{- end }}
+{{- if .Values.controller.enableOriginCertificates }}
+- apiGroups: [""]
+  resources: ["secrets"]
+  verbs: ["create", "get", "list", "update", "watch"]
+{{- end }}
+{{- if .Values.controller.enableInventory }}
+- apiGroups: [""]
+  resources: ["secrets"]
+  verbs: ["list"]
+{{- end }}
+- apiGroups: ["cert-manager.io"]
+  resources: ["certificaterequests"]
+  verbs: ["get", "list", "update", "watch"]
+- apiGroups: ["cert-manager.io"]
+  resources: ["certificaterequests/status"]
+  verbs: ["get", "patch", "update"]
+- apiGroups: ["cert-manager.k8s.cloudflare.com"]
+  resources: ["originissuers"]
+  verbs: ["create", "get", "list", "watch"]
+- apiGroups: ["cert-manager.k8s.cloudflare.com"]
+  resources: ["originissuers/status"]
+  verbs: ["get", "patch", "update"]
+{{- if .Values.controller.enableOriginCertificates }}
+- apiGroups: ["cert-manager.k8s.cloudflare.com"]
+  resources: ["origincertificates"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["cert-manager.k8s.cloudflare.com"]
+  resources: ["origincertificates/status"]
+  verbs: ["get", "patch", "update"]
+{{- end }}
+{{- if .Values.controller.enableInventory }}
+- apiGroups: ["cert-manager.k8s.cloudflare.com"]
+  resources: ["origininventories"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["cert-manager.k8s.cloudflare.com"]
+  resources: ["origininventories/status"]
+  verbs: ["get", "patch", "update"]
+{{- end }}
+{{- if .Values.controller.enableApprover }}
+- apiGroups: ["cert-manager.io"]
+  resources: ["signers"]
+  verbs: ["approve"]
+  resourceNames:
+    - originissuers.cert-manager.k8s.cloudflare.com/*
+    - clusteroriginissuers.cert-manager.k8s.cloudflare.com/*
+{{- end }}
+{{- end -}}
+
+{{/*
+Rules allowing cert-manager's approver to approve requests for Origin issuers.
+*/}}
+{{- define "origin-ca-issuer.approveRules" -}}
+- apiGroups:
+  - cert-manager.io
+  resources:
+  - signers
+  verbs:
+  - approve
+  resourceNames:
+  - originissuers.cert-manager.k8s.cloudflare.com/*
+  - clusteroriginissuers.cert-manager.k8s.cloudflare.com/*
+{{- end -}}
+
+{{/*
+Whether leader election is enabled, as it always is when running more than one replica.
+*/}}
+{{- define "origin-ca-issuer.leaderElect" -}}
+{{- or .Values.controller.leaderElection.enabled (gt (int .Values.controller.replicaCount) 1) -}}
+{{- end -}}
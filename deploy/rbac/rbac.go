@@ -0,0 +1,16 @@
+// Package rbac embeds the ClusterRole generated from the kubebuilder RBAC markers of the
+// controllers, so the deployed role and the role checked by the controller share a source.
+package rbac
+
+import _ "embed"
+
+// Role is the generated ClusterRole of the controller.
+//
+//go:embed role.yaml
+var Role []byte
+
+// LeaderElectionRole is the Role granting the controller access to its leader election Lease,
+// in the namespace of the controller only.
+//
+//go:embed role-leader-election.yaml
+var LeaderElectionRole []byte
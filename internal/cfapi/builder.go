@@ -1,13 +1,24 @@
 package cfapi
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 type Builder struct {
 	hc         *http.Client
 	serviceKey []byte
 	token      []byte
+	userAgent  string
+	endpoint   string
+	timeout    time.Duration
+	proxy      string
+	caBundle   []byte
 }
 
 func NewBuilder() *Builder {
@@ -29,20 +40,139 @@ func (b *Builder) WithClient(hc *http.Client) *Builder {
 	return b
 }
 
+func (b *Builder) WithUserAgent(userAgent string) *Builder {
+	b.userAgent = userAgent
+	return b
+}
+
+// WithEndpoint sets the base URL of the Cloudflare API, such as "https://api.cloudflare.com".
+func (b *Builder) WithEndpoint(endpoint string) *Builder {
+	b.endpoint = endpoint
+	return b
+}
+
+// WithTimeout overrides the timeout of the configured HTTP client.
+func (b *Builder) WithTimeout(timeout time.Duration) *Builder {
+	b.timeout = timeout
+	return b
+}
+
+// WithProxy sets the URL of an HTTP or HTTPS proxy used to reach the Cloudflare API.
+func (b *Builder) WithProxy(proxy string) *Builder {
+	b.proxy = proxy
+	return b
+}
+
+// WithCABundle sets PEM-encoded CA certificates trusted when connecting to the
+// Cloudflare API, instead of the system's certificate roots.
+func (b *Builder) WithCABundle(caBundle []byte) *Builder {
+	b.caBundle = caBundle
+	return b
+}
+
 func (b *Builder) Clone() *Builder {
 	return &Builder{
 		hc:         b.hc,
 		serviceKey: b.serviceKey,
+		token:      b.token,
+		userAgent:  b.userAgent,
+		endpoint:   b.endpoint,
+		timeout:    b.timeout,
+		proxy:      b.proxy,
+		caBundle:   b.caBundle,
 	}
 }
 
-func (b *Builder) Build() *Client {
+func (b *Builder) Build() (*Client, error) {
+	hc, err := b.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []Options{WithClient(hc)}
+	if b.userAgent != "" {
+		opts = append(opts, WithUserAgent(b.userAgent))
+	}
+
+	if b.endpoint != "" {
+		opt, err := WithEndpoint(b.endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API endpoint: %w", err)
+		}
+
+		opts = append(opts, opt)
+	}
+
 	switch {
 	case b.serviceKey != nil:
-		return New(WithServiceKey(b.serviceKey), WithClient(b.hc))
+		opts = append(opts, WithServiceKey(b.serviceKey))
 	case b.token != nil:
-		return New(WithToken(b.token), WithClient(b.hc))
+		opts = append(opts, WithToken(b.token))
 	default:
-		return nil
+		return nil, errors.New("no authentication method configured")
 	}
+
+	c := New(opts...)
+	if b.hc == nil || hc.Transport != b.hc.Transport {
+		c.transport, _ = hc.Transport.(*http.Transport)
+	}
+
+	return c, nil
+}
+
+// httpClient returns the configured HTTP client, or a copy of it with the timeout,
+// proxy and CA bundle settings applied.
+func (b *Builder) httpClient() (*http.Client, error) {
+	hc := b.hc
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	if b.timeout == 0 && b.proxy == "" && b.caBundle == nil {
+		return hc, nil
+	}
+
+	c := *hc
+	if b.timeout != 0 {
+		c.Timeout = b.timeout
+	}
+
+	if b.proxy == "" && b.caBundle == nil {
+		return &c, nil
+	}
+
+	var t *http.Transport
+	switch base := c.Transport.(type) {
+	case nil:
+		t = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		t = base.Clone()
+	default:
+		return nil, fmt.Errorf("cannot configure proxy or CA bundle on HTTP transport %T", base)
+	}
+
+	if b.proxy != "" {
+		u, err := url.Parse(b.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	if b.caBundle != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b.caBundle) {
+			return nil, errors.New("CA bundle does not contain any PEM-encoded certificates")
+		}
+
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+
+	c.Transport = t
+
+	return &c, nil
 }
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"path"
+	"strconv"
 	"time"
 )
 
@@ -14,17 +18,26 @@ type Interface interface {
 	Sign(context.Context, *SignRequest) (*SignResponse, error)
 }
 
+// DefaultUserAgent is the User-Agent sent to the Cloudflare API when none is configured.
+const DefaultUserAgent = "github.com/cloudflare/origin-ca-issuer"
+
 type Client struct {
 	serviceKey []byte
 	token      []byte
 	client     *http.Client
 	endpoint   string
+	userAgent  string
+
+	// transport is the HTTP transport built for this client alone, whose idle connections are
+	// closed with the client.
+	transport *http.Transport
 }
 
 func New(options ...Options) *Client {
 	c := &Client{
-		client:   http.DefaultClient,
-		endpoint: "https://api.cloudflare.com/client/v4/certificates",
+		client:    http.DefaultClient,
+		endpoint:  "https://api.cloudflare.com/client/v4/certificates",
+		userAgent: DefaultUserAgent,
 	}
 
 	for _, opt := range options {
@@ -34,6 +47,15 @@ func New(options ...Options) *Client {
 	return c
 }
 
+// CloseIdleConnections closes the idle connections of the HTTP transport built for the client, such
+// as for a proxy or CA bundle, once the client is no longer used. Transports shared with other
+// clients are left open.
+func (c *Client) CloseIdleConnections() {
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+}
+
 type Options func(c *Client)
 
 func WithServiceKey(key []byte) Options {
@@ -54,6 +76,12 @@ func WithClient(client *http.Client) Options {
 	}
 }
 
+func WithUserAgent(userAgent string) Options {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
 func WithEndpoint(endpoint string) (Options, error) {
 	u, err := url.Parse(endpoint)
 	if err != nil {
@@ -89,6 +117,17 @@ type APIResponse struct {
 	Errors   []APIError      `json:"errors"`
 	Messages []string        `json:"messages"`
 	Result   json.RawMessage `json:"result"`
+
+	ResultInfo *ResultInfo `json:"result_info,omitempty"`
+}
+
+// ResultInfo describes the page of results returned by a list request.
+type ResultInfo struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	Count      int `json:"count"`
+	TotalCount int `json:"total_count"`
+	TotalPages int `json:"total_pages"`
 }
 
 type APIError struct {
@@ -110,18 +149,181 @@ func (a *APIError) Is(target error) bool {
 	}
 }
 
+// authenticationErrorCodes are the Cloudflare API error codes returned when a
+// credential is missing, malformed, invalid or revoked.
+var authenticationErrorCodes = map[int]bool{
+	6003:  true, // Invalid request headers
+	6103:  true, // Invalid format for X-Auth-Key header
+	6111:  true, // Invalid format for Authorization header
+	9103:  true, // Unknown X-Auth-Key or X-Auth-Email
+	9106:  true, // Missing X-Auth-Key, X-Auth-Email or Authorization headers
+	9107:  true, // Missing X-Auth-Key, X-Auth-Email or Authorization headers
+	9109:  true, // Invalid access token
+	10000: true, // Authentication error
+	10001: true, // Unable to authenticate request
+}
+
+// IsAuthenticationError reports whether err is a Cloudflare API error caused by
+// the credential used for the request, rather than by the request itself.
+func IsAuthenticationError(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+
+	return authenticationErrorCodes[apiError.Code]
+}
+
+// ServerError is returned when the Cloudflare API, or a proxy in front of it, responds with a
+// server error or rate limits the request. Err is the *APIError of the response, if it had one.
+type ServerError struct {
+	StatusCode int
+	RayID      string
+	Err        error
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("Cloudflare API responded with status %d ray_id=%s: %v", e.StatusCode, e.RayID, e.Err)
+}
+
+func (e *ServerError) Unwrap() error {
+	return e.Err
+}
+
+// IsUnavailableError reports whether err is caused by the Cloudflare API being unreachable or
+// failing, rather than by the request or its credential. Canceled requests are not.
+func IsUnavailableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var serverError *ServerError
+	if errors.As(err, &serverError) {
+		return true
+	}
+
+	var urlError *url.Error
+	return errors.As(err, &urlError)
+}
+
 func (c *Client) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
 	p, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(p))
+	api, err := c.do(ctx, "POST", c.endpoint, bytes.NewBuffer(p))
 	if err != nil {
 		return nil, err
 	}
 
-	r.Header.Add("User-Agent", "github.com/cloudflare/origin-ca-issuer")
+	signResp := SignResponse{}
+	if err := json.Unmarshal(api.Result, &signResp); err != nil {
+		return nil, err
+	}
+
+	return &signResp, nil
+}
+
+// listPageSize is the number of certificates requested per page when listing certificates.
+const listPageSize = 50
+
+// List returns every Origin CA certificate issued for the zone, in the same format as
+// signed certificates.
+func (c *Client) List(ctx context.Context, zoneID string) ([]SignResponse, error) {
+	var certificates []SignResponse
+
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Set("zone_id", zoneID)
+		q.Set("page", strconv.Itoa(page))
+		q.Set("per_page", strconv.Itoa(listPageSize))
+
+		api, err := c.do(ctx, "GET", c.endpoint+"?"+q.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result []SignResponse
+		if err := json.Unmarshal(api.Result, &result); err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, result...)
+
+		if api.ResultInfo == nil || page >= api.ResultInfo.TotalPages || len(result) == 0 {
+			return certificates, nil
+		}
+	}
+}
+
+// Get returns the Origin CA certificate with the given ID.
+func (c *Client) Get(ctx context.Context, id string) (*SignResponse, error) {
+	api, err := c.do(ctx, "GET", c.endpoint+"/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cert := SignResponse{}
+	if err := json.Unmarshal(api.Result, &cert); err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// TokenStatus describes an API token, as returned by VerifyToken.
+type TokenStatus struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	ExpiresOn *time.Time `json:"expires_on,omitempty"`
+}
+
+// VerifyToken checks that the API token the client authenticates with is valid, and returns its status.
+// Service keys cannot be verified.
+func (c *Client) VerifyToken(ctx context.Context) (*TokenStatus, error) {
+	if c.token == nil {
+		return nil, errors.New("only API tokens can be verified")
+	}
+
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(path.Dir(u.Path), "user/tokens/verify")
+	u.RawQuery = ""
+
+	api, err := c.do(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	status := TokenStatus{}
+	if err := json.Unmarshal(api.Result, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// Revoke revokes the Origin CA certificate with the given ID.
+func (c *Client) Revoke(ctx context.Context, id string) error {
+	_, err := c.do(ctx, "DELETE", c.endpoint+"/"+url.PathEscape(id), nil)
+
+	return err
+}
+
+// do sends an authenticated request to the Cloudflare API, and decodes its response.
+// Unsuccessful responses are returned as an *APIError, wrapped in a *ServerError for
+// server errors and rate limiting.
+func (c *Client) do(ctx context.Context, method, endpoint string, body io.Reader) (*APIResponse, error) {
+	r, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Add("User-Agent", c.userAgent)
 
 	if c.serviceKey != nil {
 		r.Header.Add("X-Auth-User-Service-Key", string(c.serviceKey))
@@ -139,22 +341,32 @@ func (c *Client) Sign(ctx context.Context, req *SignRequest) (*SignResponse, err
 	rayID := resp.Header.Get("CF-Ray")
 
 	api := APIResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(&api); err != nil {
-		return nil, err
+	err = json.NewDecoder(resp.Body).Decode(&api)
+	switch {
+	case err != nil:
+	case !api.Success && len(api.Errors) == 0:
+		err = fmt.Errorf("Cloudflare API request was unsuccessful with status %d ray_id=%s", resp.StatusCode, rayID)
+	case !api.Success:
+		apiError := &api.Errors[0]
+		apiError.RayID = rayID
+		err = apiError
 	}
 
-	if !api.Success {
-		err := &api.Errors[0]
-		err.RayID = rayID
-		return nil, err
+	// Server errors and rate limiting are reported as a *ServerError, whether or not the response
+	// could be decoded, as the API is failing or overloaded rather than rejecting the request.
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		if err == nil {
+			err = errors.New(http.StatusText(resp.StatusCode))
+		}
+
+		return nil, &ServerError{StatusCode: resp.StatusCode, RayID: rayID, Err: err}
 	}
 
-	signResp := SignResponse{}
-	if err := json.Unmarshal(api.Result, &signResp); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	return &signResp, nil
+	return &api, nil
 }
 
 // adapted from http://choly.ca/post/go-json-marshalling/
@@ -3,12 +3,14 @@ package cfapi
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"gotest.tools/v3/assert"
 )
 
@@ -151,6 +153,216 @@ func TestSign(t *testing.T) {
 
 }
 
+func TestList(t *testing.T) {
+	var queries []string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, "GET")
+		assert.Equal(t, r.URL.Path, "/client/v4/certificates")
+		queries = append(queries, r.URL.RawQuery)
+
+		id := "9001"
+		if r.URL.Query().Get("page") == "2" {
+			id = "9002"
+		}
+
+		fmt.Fprintf(w, `{
+	"success": true,
+	"errors": [],
+	"result": [{"id": %q, "hostnames": ["example.com"], "expires_on": "2020-12-25T06:27:00Z"}],
+	"result_info": {"page": 1, "per_page": 1, "count": 1, "total_count": 2, "total_pages": 2}
+}`, id)
+	}))
+	defer ts.Close()
+
+	client := New(
+		WithToken([]byte("api-token")),
+		WithClient(ts.Client()),
+		Must(WithEndpoint(ts.URL)),
+	)
+
+	certificates, err := client.List(context.Background(), "023e105f4ecef8ad9ca31a8372d0c353")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, queries, []string{
+		"page=1&per_page=50&zone_id=023e105f4ecef8ad9ca31a8372d0c353",
+		"page=2&per_page=50&zone_id=023e105f4ecef8ad9ca31a8372d0c353",
+	})
+	assert.Equal(t, len(certificates), 2)
+	assert.Equal(t, certificates[0].Id, "9001")
+	assert.Equal(t, certificates[1].Id, "9002")
+	assert.Equal(t, certificates[1].Expiration, time.Date(2020, time.December, 25, 6, 27, 0, 0, time.UTC))
+}
+
+func TestRevoke(t *testing.T) {
+	var method, path string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+
+		if r.URL.Path == "/client/v4/certificates/missing" {
+			fmt.Fprintln(w, `{"success": false, "errors": [{"code": 1004, "message": "Certificate not found"}]}`)
+			return
+		}
+
+		fmt.Fprintln(w, `{"success": true, "errors": [], "result": {"id": "9001", "revoked_at": "2020-12-25T06:27:00Z"}}`)
+	}))
+	defer ts.Close()
+
+	client := New(
+		WithToken([]byte("api-token")),
+		WithClient(ts.Client()),
+		Must(WithEndpoint(ts.URL)),
+	)
+
+	assert.NilError(t, client.Revoke(context.Background(), "9001"))
+	assert.Equal(t, method, "DELETE")
+	assert.Equal(t, path, "/client/v4/certificates/9001")
+
+	assert.ErrorIs(t, client.Revoke(context.Background(), "missing"), &APIError{Code: 1004})
+}
+
+func TestGet(t *testing.T) {
+	var path string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprintln(w, `{"success": true, "errors": [], "result": {"id": "9001", "certificate": "-----BEGIN CERTIFICATE-----", "hostnames": ["example.com"], "expires_on": "2014-01-01 05:20:00.12345 +0000 UTC", "request_type": "origin-rsa", "requested_validity": 5475}}`)
+	}))
+	defer ts.Close()
+
+	client := New(
+		WithToken([]byte("api-token")),
+		WithClient(ts.Client()),
+		Must(WithEndpoint(ts.URL)),
+	)
+
+	cert, err := client.Get(context.Background(), "9001")
+	assert.NilError(t, err)
+	assert.Equal(t, path, "/client/v4/certificates/9001")
+	assert.Equal(t, cert.Id, "9001")
+	assert.DeepEqual(t, cert.Hostnames, []string{"example.com"})
+}
+
+func TestVerifyToken(t *testing.T) {
+	var path string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprintln(w, `{"success": true, "errors": [], "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "active", "expires_on": "2030-01-01T00:00:00Z"}}`)
+	}))
+	defer ts.Close()
+
+	client := New(
+		WithToken([]byte("api-token")),
+		WithClient(ts.Client()),
+		Must(WithEndpoint(ts.URL)),
+	)
+
+	status, err := client.VerifyToken(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, path, "/client/v4/user/tokens/verify")
+	assert.Equal(t, status.Status, "active")
+	assert.Equal(t, *status.ExpiresOn, time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	client = New(
+		WithServiceKey([]byte("service-key")),
+		WithClient(ts.Client()),
+		Must(WithEndpoint(ts.URL)),
+	)
+
+	_, err = client.VerifyToken(context.Background())
+	assert.Error(t, err, "only API tokens can be verified")
+}
+
+func TestUserAgent(t *testing.T) {
+	var userAgent string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer ts.Close()
+
+	client := New(WithClient(ts.Client()), Must(WithEndpoint(ts.URL)))
+	_, _ = client.Get(context.Background(), "1")
+	assert.Equal(t, userAgent, DefaultUserAgent)
+
+	client, err := NewBuilder().
+		WithClient(ts.Client()).
+		WithEndpoint(ts.URL).
+		WithUserAgent("github.com/cloudflare/origin-ca-issuer/v1.2.3").
+		WithToken([]byte("api-token")).
+		Build()
+	assert.NilError(t, err)
+	_, _ = client.Get(context.Background(), "1")
+	assert.Equal(t, userAgent, "github.com/cloudflare/origin-ca-issuer/v1.2.3")
+}
+
+func TestBuilderTransport(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Slow") != "" {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	defer ts.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	tests := []struct {
+		name    string
+		builder *Builder
+		error   string
+	}{
+		{
+			name:    "untrusted certificate",
+			builder: NewBuilder().WithEndpoint(ts.URL),
+			error:   "certificate signed by unknown authority",
+		},
+		{
+			name:    "CA bundle",
+			builder: NewBuilder().WithEndpoint(ts.URL).WithCABundle(caBundle),
+		},
+		{
+			name:    "invalid CA bundle",
+			builder: NewBuilder().WithEndpoint(ts.URL).WithCABundle([]byte("not a certificate")),
+			error:   "CA bundle does not contain any PEM-encoded certificates",
+		},
+		{
+			name:    "proxy",
+			builder: NewBuilder().WithEndpoint("http://api.cloudflare.invalid").WithProxy(proxy.URL),
+		},
+		{
+			name:    "timeout",
+			builder: NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL).WithTimeout(10 * time.Millisecond),
+			error:   "Client.Timeout exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := tt.builder.WithToken([]byte("api-token")).Build()
+			if err == nil {
+				req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, client.endpoint, nil)
+				req.Header.Set("X-Slow", "true")
+				var resp *http.Response
+				resp, err = client.client.Do(req)
+				if err == nil {
+					resp.Body.Close()
+				}
+			}
+
+			if tt.error != "" {
+				assert.ErrorContains(t, err, tt.error)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+
+	assert.Equal(t, proxied, "http://api.cloudflare.invalid/client/v4/certificates")
+}
+
 func Must(opt Options, err error) Options {
 	if err != nil {
 		panic("option constructo returned error " + err.Error())
@@ -158,3 +370,129 @@ func Must(opt Options, err error) Options {
 
 	return opt
 }
+
+func TestBuilderClone(t *testing.T) {
+	b := NewBuilder().
+		WithClient(&http.Client{}).
+		WithServiceKey([]byte("service-key")).
+		WithToken([]byte("api-token")).
+		WithUserAgent("github.com/cloudflare/origin-ca-issuer/v1.2.3").
+		WithEndpoint("https://api.cloudflare.com").
+		WithTimeout(time.Minute).
+		WithProxy("http://proxy.example:3128").
+		WithCABundle([]byte("ca-bundle"))
+
+	assert.DeepEqual(t, b.Clone(), b, cmp.AllowUnexported(Builder{}))
+}
+
+func TestIsAuthenticationError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "authentication error",
+			err:      &APIError{Code: 10000, Message: "Authentication error"},
+			expected: true,
+		},
+		{
+			name:     "wrapped invalid token",
+			err:      fmt.Errorf("failed to sign: %w", &APIError{Code: 9109, Message: "Invalid access token"}),
+			expected: true,
+		},
+		{
+			name:     "database error",
+			err:      &APIError{Code: 1100, Message: "Failed to write certificate to Database"},
+			expected: false,
+		},
+		{
+			name:     "transport error",
+			err:      fmt.Errorf("connection refused"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, IsAuthenticationError(tt.err), tt.expected)
+		})
+	}
+}
+
+func TestIsUnavailableError(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprintln(w, `<html><body>502 Bad Gateway</body></html>`)
+	}))
+	defer ts.Close()
+
+	client := New(
+		WithClient(ts.Client()),
+		Must(WithEndpoint(ts.URL)),
+	)
+
+	_, err := client.Sign(context.Background(), &SignRequest{})
+	assert.ErrorContains(t, err, "Cloudflare API responded with status 502")
+	assert.Assert(t, IsUnavailableError(err))
+
+	for _, tt := range []struct {
+		status int
+		body   string
+		error  string
+	}{
+		{http.StatusServiceUnavailable, `{"success": false, "errors": [{"code": 1100, "message": "Service unavailable"}]}`, "Cloudflare API Error code=1100 message=Service unavailable"},
+		{http.StatusTooManyRequests, `{"success": false, "errors": [{"code": 971, "message": "Please wait and consider throttling your request speed"}]}`, "Cloudflare API Error code=971"},
+		{http.StatusServiceUnavailable, `{"success": false, "errors": []}`, "Cloudflare API request was unsuccessful with status 503"},
+	} {
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			fmt.Fprintln(w, tt.body)
+		}))
+		defer ts.Close()
+
+		client := New(
+			WithClient(ts.Client()),
+			Must(WithEndpoint(ts.URL)),
+		)
+
+		_, err := client.Sign(context.Background(), &SignRequest{})
+		assert.ErrorContains(t, err, fmt.Sprintf("Cloudflare API responded with status %d", tt.status))
+		assert.ErrorContains(t, err, tt.error)
+		assert.Assert(t, IsUnavailableError(err))
+	}
+
+	closed := New(
+		WithClient(ts.Client()),
+		Must(WithEndpoint("https://127.0.0.1:1")),
+	)
+
+	_, err = closed.Sign(context.Background(), &SignRequest{})
+	assert.Assert(t, IsUnavailableError(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.Sign(ctx, &SignRequest{})
+	assert.Assert(t, !IsUnavailableError(err))
+
+	assert.Assert(t, !IsUnavailableError(&APIError{Code: 1100, Message: "Failed to write certificate to Database"}))
+	assert.Assert(t, !IsUnavailableError(&APIError{Code: 10000, Message: "Authentication error"}))
+}
+
+func TestUnsuccessfulWithoutErrors(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"success": false, "errors": []}`)
+	}))
+	defer ts.Close()
+
+	client := New(
+		WithClient(ts.Client()),
+		Must(WithEndpoint(ts.URL)),
+	)
+
+	_, err := client.Sign(context.Background(), &SignRequest{})
+	assert.ErrorContains(t, err, "Cloudflare API request was unsuccessful with status 200")
+	assert.Assert(t, !IsUnavailableError(err))
+}
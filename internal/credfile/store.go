@@ -0,0 +1,224 @@
+// Package credfile reads Cloudflare API credentials from files mounted into the
+// controller, such as those projected by a CSI secrets driver.
+package credfile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+)
+
+// Store reads credentials from files within a set of allowed directories. File
+// contents are cached until a change to the file's directory is observed, so
+// rotated credentials are picked up without restarting the controller.
+type Store struct {
+	dirs    []string
+	watcher *fsnotify.Watcher
+	log     logr.Logger
+
+	mu      sync.Mutex
+	next    uint64
+	files   map[string]file
+	watched map[string]bool
+}
+
+type file struct {
+	data    []byte
+	version string
+}
+
+// NewStore returns a Store allowing reads from files within dirs. A Store without
+// directories rejects every file.
+func NewStore(dirs []string, log logr.Logger) (*Store, error) {
+	cleaned := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			return nil, fmt.Errorf("credential directory %q must be an absolute path", dir)
+		}
+
+		cleaned = append(cleaned, filepath.Clean(dir))
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	return &Store{
+		dirs:    cleaned,
+		watcher: w,
+		log:     log,
+		files:   make(map[string]file),
+		watched: make(map[string]bool),
+	}, nil
+}
+
+// Read returns the contents of the credential file at path, with surrounding whitespace
+// removed, and a version that changes whenever the file is re-read after a change. If subdir
+// is set, path must be within that subdirectory of an allowed directory, so credentials can be
+// set aside for each namespace. A nil Store rejects every file.
+func (s *Store) Read(path, subdir string) ([]byte, string, error) {
+	if s == nil {
+		return nil, "", errors.New("credential files are not enabled on the controller")
+	}
+
+	if err := s.allowed(path, subdir); err != nil {
+		return nil, "", err
+	}
+
+	path = filepath.Clean(path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.files[path]; ok {
+		return f.data, f.version, nil
+	}
+
+	// Watch before reading, so a change made after the read is never missed.
+	dir := filepath.Dir(path)
+	if !s.watched[dir] {
+		if err := s.watcher.Add(dir); err != nil {
+			return nil, "", fmt.Errorf("failed to watch credential directory %s: %w", dir, err)
+		}
+
+		s.watched[dir] = true
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, "", fmt.Errorf("credential file %s is empty", path)
+	}
+
+	s.next++
+	f := file{data: data, version: strconv.FormatUint(s.next, 10)}
+	s.files[path] = f
+
+	return f.data, f.version, nil
+}
+
+// allowed ensures path is within subdir of an allowed directory, both as written and once any
+// symbolic links are resolved.
+func (s *Store) allowed(path, subdir string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("credential file %q must be an absolute path", path)
+	}
+
+	if subdir != "" && (subdir != filepath.Base(subdir) || subdir == ".." || subdir == ".") {
+		return fmt.Errorf("credential subdirectory %q must be a single path element", subdir)
+	}
+
+	path = filepath.Clean(path)
+
+	for _, dir := range s.dirs {
+		dir = filepath.Join(dir, subdir)
+		if !within(dir, path) {
+			continue
+		}
+
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return err
+		}
+
+		resolvedDir, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return err
+		}
+
+		if within(resolvedDir, resolved) {
+			return nil
+		}
+	}
+
+	if subdir != "" {
+		return fmt.Errorf("credential file %s is not within the %s subdirectory of an allowed credential directory", path, subdir)
+	}
+
+	return fmt.Errorf("credential file %s is not within an allowed credential directory", path)
+}
+
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Start watches the directories of previously read credential files, dropping cached
+// contents when they change, until ctx is done.
+func (s *Store) Start(ctx context.Context) error {
+	defer s.watcher.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-s.watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			s.invalidate(ev)
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				s.invalidateAll()
+			}
+
+			s.log.Error(err, "error watching credential files")
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so credential files are
+// watched on every replica.
+func (s *Store) NeedLeaderElection() bool {
+	return false
+}
+
+// invalidate drops the cached contents of every file in the directory affected by ev.
+func (s *Store) invalidate(ev fsnotify.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Dir(ev.Name)
+	if s.watched[ev.Name] && ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+		// The watched directory itself went away; it is watched again on the next read.
+		dir = ev.Name
+		delete(s.watched, dir)
+	}
+
+	for path := range s.files {
+		if filepath.Dir(path) == dir {
+			s.log.V(4).Info("credential file changed", "path", path, "event", ev.Op.String())
+			delete(s.files, path)
+		}
+	}
+}
+
+func (s *Store) invalidateAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files = make(map[string]file)
+}
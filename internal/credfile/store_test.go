@@ -0,0 +1,160 @@
+package credfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestStoreRead(t *testing.T) {
+	allowed := t.TempDir()
+	other := t.TempDir()
+
+	writeFile(t, filepath.Join(allowed, "token"), "api-token\n")
+	writeFile(t, filepath.Join(allowed, "empty"), "\n")
+	assert.NilError(t, os.MkdirAll(filepath.Join(allowed, "default"), 0o755))
+	assert.NilError(t, os.MkdirAll(filepath.Join(allowed, "other"), 0o755))
+	writeFile(t, filepath.Join(allowed, "default", "token"), "default-token")
+	writeFile(t, filepath.Join(allowed, "other", "token"), "other-token")
+	writeFile(t, filepath.Join(other, "token"), "other-token")
+	assert.NilError(t, os.Symlink(filepath.Join(other, "token"), filepath.Join(allowed, "escape")))
+
+	s, err := NewStore([]string{allowed}, logf.Log)
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name   string
+		path   string
+		subdir string
+		data   string
+		error  string
+	}{
+		{
+			name: "allowed file",
+			path: filepath.Join(allowed, "token"),
+			data: "api-token",
+		},
+		{
+			name:  "relative path",
+			path:  "token",
+			error: `credential file "token" must be an absolute path`,
+		},
+		{
+			name:  "outside allowed directories",
+			path:  filepath.Join(other, "token"),
+			error: "is not within an allowed credential directory",
+		},
+		{
+			name:  "traversal outside allowed directories",
+			path:  filepath.Join(allowed, "..", filepath.Base(other), "token"),
+			error: "is not within an allowed credential directory",
+		},
+		{
+			name:  "symlink outside allowed directories",
+			path:  filepath.Join(allowed, "escape"),
+			error: "is not within an allowed credential directory",
+		},
+		{
+			name:  "missing file",
+			path:  filepath.Join(allowed, "missing"),
+			error: "no such file or directory",
+		},
+		{
+			name:  "empty file",
+			path:  filepath.Join(allowed, "empty"),
+			error: "is empty",
+		},
+		{
+			name:   "within subdirectory",
+			path:   filepath.Join(allowed, "default", "token"),
+			subdir: "default",
+			data:   "default-token",
+		},
+		{
+			name:   "outside subdirectory",
+			path:   filepath.Join(allowed, "token"),
+			subdir: "default",
+			error:  "is not within the default subdirectory of an allowed credential directory",
+		},
+		{
+			name:   "other subdirectory",
+			path:   filepath.Join(allowed, "other", "token"),
+			subdir: "default",
+			error:  "is not within the default subdirectory of an allowed credential directory",
+		},
+		{
+			name:   "traversal out of subdirectory",
+			path:   filepath.Join(allowed, "default", "..", "token"),
+			subdir: "default",
+			error:  "is not within the default subdirectory of an allowed credential directory",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, err := s.Read(tt.path, tt.subdir)
+			if tt.error != "" {
+				assert.ErrorContains(t, err, tt.error)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, string(data), tt.data)
+			}
+		})
+	}
+}
+
+func TestStoreRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	writeFile(t, path, "api-token")
+
+	s, err := NewStore([]string{dir}, logf.Log)
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = s.Start(ctx) }()
+
+	data, version, err := s.Read(path, "")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "api-token")
+
+	_, cached, err := s.Read(path, "")
+	assert.NilError(t, err)
+	assert.Equal(t, cached, version, "unchanged files should be served from the cache")
+
+	// Replace the file atomically, as secret volumes and CSI drivers do.
+	writeFile(t, path+".tmp", "rotated-api-token")
+	assert.NilError(t, os.Rename(path+".tmp", path))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, rotated, err := s.Read(path, "")
+		assert.NilError(t, err)
+
+		if rotated != version {
+			assert.Equal(t, string(data), "rotated-api-token")
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("rotated credential file was not re-read")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
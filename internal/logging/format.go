@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{"json", "console", "logfmt"}
+
+// NewWriter returns a writer emitting zerolog events to out in the named format.
+func NewWriter(format string, out io.Writer) (io.Writer, error) {
+	switch format {
+	case "json":
+		return out, nil
+	case "console":
+		return zerolog.ConsoleWriter{Out: out, TimeFormat: "2006-01-02T15:04:05.000Z07:00"}, nil
+	case "logfmt":
+		return &logfmtWriter{out: out}, nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// logfmtWriter converts the JSON events written by zerolog to logfmt.
+type logfmtWriter struct {
+	out io.Writer
+}
+
+func (w *logfmtWriter) Write(p []byte) (int, error) {
+	fields := map[string]any{}
+
+	d := json.NewDecoder(bytes.NewReader(p))
+	d.UseNumber()
+	if err := d.Decode(&fields); err != nil {
+		return 0, err
+	}
+
+	leading := []string{
+		zerolog.TimestampFieldName,
+		zerolog.LevelFieldName,
+		"logger",
+		zerolog.CallerFieldName,
+		zerolog.MessageFieldName,
+	}
+
+	var keys []string
+	for k := range fields {
+		if !slices.Contains(leading, k) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var b strings.Builder
+	for _, k := range append(leading, keys...) {
+		v, ok := fields[k]
+		if !ok {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(v))
+	}
+	b.WriteByte('\n')
+
+	if _, err := io.WriteString(w.out, b.String()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func logfmtValue(v any) string {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return "null"
+	default:
+		p, _ := json.Marshal(v)
+		s = string(p)
+	}
+
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
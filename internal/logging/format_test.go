@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"gotest.tools/v3/assert"
+)
+
+func TestLogfmtWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter("logfmt", &buf)
+	assert.NilError(t, err)
+
+	zl := zerolog.New(w)
+	zl.Error().
+		Str("logger", "origin-issuer").
+		Err(errors.New("it broke")).
+		Int("attempt", 3).
+		Str("name", "foo").
+		Msg("failed to sign certificate request")
+
+	assert.Equal(t, buf.String(), `level=error logger=origin-issuer message="failed to sign certificate request" attempt=3 error="it broke" name=foo`+"\n")
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	_, err := NewWriter("xml", &bytes.Buffer{})
+	assert.Error(t, err, `unknown log format "xml"`)
+}
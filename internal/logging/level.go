@@ -0,0 +1,96 @@
+// Package logging configures the zerolog loggers used by the controller,
+// allowing their level and output format to be chosen at startup and their
+// level to be adjusted at runtime.
+package logging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// MinLevel is the most verbose level, enabling messages of any logr verbosity.
+const MinLevel = zerolog.Level(-128)
+
+// ParseLevel parses a named level (error, warn, info, debug, or trace) or a
+// logr verbosity, where "0" is equivalent to info and higher values are more
+// verbose. The debug level enables messages up to verbosity 4, which is used
+// throughout the controllers for diagnostic messages.
+func ParseLevel(s string) (zerolog.Level, error) {
+	switch strings.ToLower(s) {
+	case "error":
+		return zerolog.ErrorLevel, nil
+	case "warn", "warning":
+		return zerolog.WarnLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "debug":
+		return verbosity(4), nil
+	case "trace":
+		return MinLevel, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return zerolog.NoLevel, fmt.Errorf("unknown log level %q", s)
+	}
+
+	return verbosity(v), nil
+}
+
+// verbosity converts a logr verbosity to the zerolog level used by zerologr.
+func verbosity(v int) zerolog.Level {
+	if 1-v < int(MinLevel) {
+		return MinLevel
+	}
+
+	return zerolog.Level(1 - v)
+}
+
+// Level is a log level that can be safely changed while in use.
+type Level struct {
+	v atomic.Int32
+}
+
+// NewLevel returns a Level set to l.
+func NewLevel(l zerolog.Level) *Level {
+	lvl := &Level{}
+	lvl.Set(l)
+
+	return lvl
+}
+
+// Get returns the current level.
+func (l *Level) Get() zerolog.Level {
+	return zerolog.Level(l.v.Load())
+}
+
+// Set changes the current level.
+func (l *Level) Set(lvl zerolog.Level) {
+	l.v.Store(int32(lvl))
+}
+
+// String returns the name of the current level, or its logr verbosity if it
+// has no name.
+func (l *Level) String() string {
+	switch lvl := l.Get(); lvl {
+	case zerolog.ErrorLevel, zerolog.WarnLevel, zerolog.InfoLevel:
+		return lvl.String()
+	case MinLevel:
+		return "trace"
+	default:
+		return strconv.Itoa(1 - int(lvl))
+	}
+}
+
+// Hook returns a zerolog hook discarding events less severe than l.
+func (l *Level) Hook() zerolog.Hook {
+	return zerolog.HookFunc(func(e *zerolog.Event, level zerolog.Level, _ string) {
+		if level < l.Get() {
+			e.Discard()
+		}
+	})
+}
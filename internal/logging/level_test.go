@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-logr/zerologr"
+	"github.com/rs/zerolog"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected zerolog.Level
+		error    string
+	}{
+		{level: "error", expected: zerolog.ErrorLevel},
+		{level: "WARN", expected: zerolog.WarnLevel},
+		{level: "info", expected: zerolog.InfoLevel},
+		{level: "0", expected: zerolog.InfoLevel},
+		{level: "debug", expected: zerolog.Level(-3)},
+		{level: "2", expected: zerolog.TraceLevel},
+		{level: "trace", expected: MinLevel},
+		{level: "1000", expected: MinLevel},
+		{level: "verbose", error: `unknown log level "verbose"`},
+		{level: "-1", error: `unknown log level "-1"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.level, func(t *testing.T) {
+			lvl, err := ParseLevel(tt.level)
+			if tt.error != "" {
+				assert.Error(t, err, tt.error)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, lvl, tt.expected)
+		})
+	}
+}
+
+func TestLevelHook(t *testing.T) {
+	prev := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(MinLevel)
+	t.Cleanup(func() { zerolog.SetGlobalLevel(prev) })
+
+	var buf bytes.Buffer
+	lvl := NewLevel(zerolog.InfoLevel)
+	zl := zerolog.New(&buf).Hook(lvl.Hook())
+	log := zerologr.New(&zl)
+
+	log.V(4).Info("hidden")
+	log.Info("shown")
+	assert.Equal(t, buf.String(), `{"level":"info","v":0,"message":"shown"}`+"\n")
+
+	buf.Reset()
+	lvl.Set(zerolog.Level(-3))
+	assert.Equal(t, lvl.String(), "4")
+
+	log.V(4).Info("now shown")
+	assert.Equal(t, buf.String(), `{"level":"-3","v":4,"message":"now shown"}`+"\n")
+}
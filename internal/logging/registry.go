@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Registry tracks the adjustable levels of named loggers, and serves an
+// administrative endpoint to inspect and change them at runtime.
+type Registry struct {
+	mu     sync.RWMutex
+	levels map[string]*Level
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{levels: make(map[string]*Level)}
+}
+
+// Register records lvl under name, replacing any existing level.
+func (r *Registry) Register(name string, lvl *Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.levels[name] = lvl
+}
+
+// Levels returns the current level of every registered logger.
+func (r *Registry) Levels() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	levels := make(map[string]string, len(r.levels))
+	for name, lvl := range r.levels {
+		levels[name] = lvl.String()
+	}
+
+	return levels
+}
+
+// ServeHTTP reports the registered levels as JSON. PUT requests change the level
+// given by the "level" query parameter, either of the logger named by the "logger"
+// query parameter, or of every registered logger if omitted.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		lvl, err := ParseLevel(req.URL.Query().Get("level"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !r.set(req.URL.Query().Get("logger"), lvl) {
+			http.Error(w, "unknown logger", http.StatusNotFound)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(r.Levels())
+}
+
+func (r *Registry) set(name string, lvl zerolog.Level) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name == "" {
+		for _, l := range r.levels {
+			l.Set(lvl)
+		}
+
+		return true
+	}
+
+	l, ok := r.levels[name]
+	if ok {
+		l.Set(lvl)
+	}
+
+	return ok
+}
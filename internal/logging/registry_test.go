@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"gotest.tools/v3/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("origin-issuer", NewLevel(zerolog.InfoLevel))
+	r.Register("CertificateRequest", NewLevel(zerolog.InfoLevel))
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		code     int
+		expected map[string]string
+	}{
+		{
+			name:     "get levels",
+			method:   http.MethodGet,
+			target:   "/debug/loglevel",
+			code:     http.StatusOK,
+			expected: map[string]string{"origin-issuer": "info", "CertificateRequest": "info"},
+		},
+		{
+			name:     "set single logger",
+			method:   http.MethodPut,
+			target:   "/debug/loglevel?logger=CertificateRequest&level=debug",
+			code:     http.StatusOK,
+			expected: map[string]string{"origin-issuer": "info", "CertificateRequest": "4"},
+		},
+		{
+			name:     "set all loggers",
+			method:   http.MethodPut,
+			target:   "/debug/loglevel?level=error",
+			code:     http.StatusOK,
+			expected: map[string]string{"origin-issuer": "error", "CertificateRequest": "error"},
+		},
+		{
+			name:     "unknown logger",
+			method:   http.MethodPut,
+			target:   "/debug/loglevel?logger=Nope&level=debug",
+			code:     http.StatusNotFound,
+			expected: map[string]string{"origin-issuer": "error", "CertificateRequest": "error"},
+		},
+		{
+			name:     "invalid level",
+			method:   http.MethodPut,
+			target:   "/debug/loglevel?level=loud",
+			code:     http.StatusBadRequest,
+			expected: map[string]string{"origin-issuer": "error", "CertificateRequest": "error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			assert.Equal(t, w.Code, tt.code)
+			assert.DeepEqual(t, r.Levels(), tt.expected)
+		})
+	}
+}
@@ -1,5 +1,6 @@
 // +k8s:deepcopy-gen=package
 // +groupName=cert-manager.k8s.cloudflare.com
+// +groupGoName=Cloudflare
 
 // Package v1 is the v1 version of the OriginIssuer API
 package v1
@@ -15,6 +16,9 @@ var (
 	// GroupVersion is group version used to register these objects
 	GroupVersion = schema.GroupVersion{Group: "cert-manager.k8s.cloudflare.com", Version: "v1"}
 
+	// SchemeGroupVersion is an alias of GroupVersion, used by the generated clientset.
+	SchemeGroupVersion = GroupVersion
+
 	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
 	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
 
@@ -22,6 +26,11 @@ var (
 	AddToScheme = SchemeBuilder.AddToScheme
 )
 
+// Resource takes an unqualified resource and returns a group-qualified GroupResource.
+func Resource(resource string) schema.GroupResource {
+	return GroupVersion.WithResource(resource).GroupResource()
+}
+
 func init() {
-	SchemeBuilder.Register(&OriginIssuer{}, &OriginIssuerList{}, &ClusterOriginIssuer{}, &ClusterOriginIssuerList{})
+	SchemeBuilder.Register(&OriginIssuer{}, &OriginIssuerList{}, &ClusterOriginIssuer{}, &ClusterOriginIssuerList{}, &OriginCertificate{}, &OriginCertificateList{}, &OriginInventory{}, &OriginInventoryList{}, &OriginCertificateRecord{}, &OriginCertificateRecordList{})
 }
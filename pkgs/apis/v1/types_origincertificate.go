@@ -0,0 +1,140 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretName"
+// +kubebuilder:printcolumn:name="Issuer",type="string",JSONPath=".spec.issuerRef.name",priority=1
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.notAfter"
+
+// An OriginCertificate requests a certificate from the Cloudflare Origin CA without
+// relying on cert-manager. A private key is generated by the controller, and the
+// certificate signed by the referenced OriginIssuer or ClusterOriginIssuer. Both are
+// written to a `kubernetes.io/tls` Secret, and renewed before the certificate expires.
+type OriginCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the OriginCertificate resource.
+	Spec OriginCertificateSpec `json:"spec,omitempty"`
+
+	// Status of the OriginCertificate. This is set and managed automatically.
+	// +optional
+	Status OriginCertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OriginCertificateList is a list of OriginCertificates.
+type OriginCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OriginCertificate `json:"items"`
+}
+
+// OriginCertificateSpec is the specification of an OriginCertificate.
+type OriginCertificateSpec struct {
+	// SecretName is the name of the Secret, in the same namespace, the certificate
+	// and private key are written to. The Secret is created if it does not exist,
+	// and an existing Secret is only overwritten if it is owned by the
+	// OriginCertificate.
+	SecretName string `json:"secretName"`
+
+	// DNSNames are the hostnames the certificate is valid for, such as
+	// "example.com" or "*.example.com".
+	// +kubebuilder:validation:MinItems=1
+	DNSNames []string `json:"dnsNames"`
+
+	// Duration is the requested validity of the certificate. It is rounded to the
+	// closest validity supported by the Cloudflare Origin CA: 7, 30, 90, 365, 730,
+	// 1095 or 5475 days. Defaults to 7 days.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before it expires the certificate is renewed.
+	// Defaults to a third of the certificate's validity.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// IssuerRef references the issuer signing the certificate.
+	IssuerRef IssuerReference `json:"issuerRef"`
+}
+
+// IssuerReference references an OriginIssuer or ClusterOriginIssuer.
+type IssuerReference struct {
+	// Name of the issuer. An OriginIssuer must be in the same namespace as the
+	// resource referencing it.
+	Name string `json:"name"`
+
+	// Kind of the issuer, either OriginIssuer or ClusterOriginIssuer. Defaults to
+	// OriginIssuer.
+	// +optional
+	// +kubebuilder:validation:Enum=OriginIssuer;ClusterOriginIssuer
+	Kind string `json:"kind,omitempty"`
+}
+
+// OriginCertificateStatus contains status information about an OriginCertificate.
+type OriginCertificateStatus struct {
+	// List of status conditions to indicate the status of an OriginCertificate.
+	// Known condition types are `Ready`.
+	// +optional
+	Conditions []OriginCertificateCondition `json:"conditions,omitempty"`
+
+	// NotBefore is the time the current certificate is valid from.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the time the current certificate expires.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is the time the current certificate will be renewed.
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// PendingCertificate is the certificate last issued for the OriginCertificate, until it
+	// is written to the Secret. If writing the Secret fails, the certificate is revoked
+	// before another one is issued.
+	// +optional
+	PendingCertificate *PendingCertificate `json:"pendingCertificate,omitempty"`
+}
+
+// PendingCertificate identifies a certificate issued for an OriginCertificate that is not
+// written to its Secret yet.
+type PendingCertificate struct {
+	// ID of the certificate in the Cloudflare API.
+	ID string `json:"id"`
+
+	// SerialNumber of the certificate, as a hexadecimal string.
+	SerialNumber string `json:"serialNumber"`
+}
+
+// OriginCertificateCondition contains condition information for the OriginCertificate.
+type OriginCertificateCondition struct {
+	// Type of the condition, known values are ('Ready')
+	Type ConditionType `json:"type"`
+
+	// Status of the condition, one of ('True', 'False', 'Unknown')
+	Status ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
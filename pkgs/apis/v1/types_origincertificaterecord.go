@@ -0,0 +1,119 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RecordNamespaceLabel is set on OriginCertificateRecords to the namespace of the
+// resource the certificate was issued for, or of the OriginIssuer that signed it for
+// cluster-scoped resources, so records can be selected by namespace.
+const RecordNamespaceLabel = "cert-manager.k8s.cloudflare.com/request-namespace"
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.source.namespace"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.name"
+// +kubebuilder:printcolumn:name="Issuer",type="string",JSONPath=".spec.issuerRef.name"
+// +kubebuilder:printcolumn:name="Hostnames",type="string",JSONPath=".spec.hostnames",priority=1
+// +kubebuilder:printcolumn:name="Not After",type="date",JSONPath=".spec.notAfter"
+// +kubebuilder:printcolumn:name="Revoked",type="date",JSONPath=".status.revocationTime",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// An OriginCertificateRecord records a certificate issued by the Origin CA. Records are
+// named after the serial number of the certificate, and are kept after the resource the
+// certificate was issued for is deleted, providing an audit trail of issued certificates.
+type OriginCertificateRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Details of the issued certificate.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+	Spec OriginCertificateRecordSpec `json:"spec"`
+
+	// Status of the issued certificate. This is set and managed automatically.
+	// +optional
+	Status OriginCertificateRecordStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OriginCertificateRecordList is a list of OriginCertificateRecords.
+type OriginCertificateRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OriginCertificateRecord `json:"items"`
+}
+
+// OriginCertificateRecordSpec describes a certificate issued by the Origin CA.
+type OriginCertificateRecordSpec struct {
+	// CertificateID is the ID of the certificate in the Cloudflare API, which
+	// may be used to revoke it.
+	CertificateID string `json:"certificateID"`
+
+	// SerialNumber of the certificate, hex-encoded.
+	SerialNumber string `json:"serialNumber"`
+
+	// Hostnames the certificate is valid for.
+	Hostnames []string `json:"hostnames"`
+
+	// RequestType is the signature type of the certificate.
+	// +optional
+	RequestType RequestType `json:"requestType,omitempty"`
+
+	// NotBefore is the time the certificate is valid from.
+	NotBefore metav1.Time `json:"notBefore"`
+
+	// NotAfter is the time the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// IssuerRef references the issuer that signed the certificate.
+	IssuerRef IssuerReference `json:"issuerRef"`
+
+	// Source references the resource the certificate was issued for.
+	Source RecordSource `json:"source"`
+}
+
+// OriginCertificateRecordStatus contains status information about a recorded certificate.
+type OriginCertificateRecordStatus struct {
+	// RevocationTime is the time the certificate was revoked by the controller.
+	// +optional
+	RevocationTime *metav1.Time `json:"revocationTime,omitempty"`
+
+	// OrphanedTime is the time an OriginInventory first found the certificate
+	// orphaned, no longer held by any resource in the cluster. It is cleared
+	// once the certificate is found in the cluster again.
+	// +optional
+	OrphanedTime *metav1.Time `json:"orphanedTime,omitempty"`
+}
+
+// RecordSource references the resource an OriginCertificateRecord was created for.
+type RecordSource struct {
+	// APIGroup of the resource.
+	// +optional
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	// Kind of the resource, such as CertificateRequest.
+	Kind string `json:"kind"`
+
+	// Namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// UID of the resource.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+
+	// Certificate is the name of the cert-manager Certificate, in the same
+	// namespace, a CertificateRequest was created for. Certificates renewed
+	// for the same Certificate supersede each other.
+	// +optional
+	Certificate string `json:"certificate,omitempty"`
+}
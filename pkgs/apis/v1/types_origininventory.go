@@ -0,0 +1,156 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Certificates",type="integer",JSONPath=".status.certificates"
+// +kubebuilder:printcolumn:name="Orphaned",type="integer",JSONPath=".status.orphaned"
+// +kubebuilder:printcolumn:name="Expiring",type="integer",JSONPath=".status.expiring"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+
+// An OriginInventory periodically lists the Origin CA certificates issued for a set of
+// zones, and correlates them with the certificates found in the cluster. Certificates
+// that are not held by any CertificateRequest, CertificateSigningRequest or TLS Secret
+// are reported as orphans. Orphans recorded by an OriginCertificateRecord as issued for
+// the namespace of the OriginInventory may be revoked once older than a grace period.
+type OriginInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the OriginInventory resource.
+	Spec OriginInventorySpec `json:"spec,omitempty"`
+
+	// Status of the OriginInventory. This is set and managed automatically.
+	// +optional
+	Status OriginInventoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OriginInventoryList is a list of OriginInventories.
+type OriginInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OriginInventory `json:"items"`
+}
+
+// OriginInventorySpec is the specification of an OriginInventory.
+type OriginInventorySpec struct {
+	// IssuerRef references the issuer whose credentials are used to list, and
+	// revoke, certificates. The credentials must be allowed to read the SSL
+	// settings of every zone, and to edit them if RevokeOrphans is set. Only
+	// OriginIssuers in the same namespace may be referenced.
+	// +kubebuilder:validation:XValidation:rule="!has(self.kind) || self.kind == 'OriginIssuer'",message="only OriginIssuers may be referenced"
+	IssuerRef IssuerReference `json:"issuerRef"`
+
+	// ZoneIDs are the IDs of the Cloudflare zones whose certificates are listed.
+	// +kubebuilder:validation:MinItems=1
+	ZoneIDs []string `json:"zoneIDs"`
+
+	// Interval between listings of the certificates. Defaults to 1 hour.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ExpiryThreshold is how long before it expires a certificate is reported
+	// as expiring. Defaults to 30 days.
+	// +optional
+	ExpiryThreshold *metav1.Duration `json:"expiryThreshold,omitempty"`
+
+	// RevokeOrphans enables revoking orphaned certificates once they have been
+	// orphaned for OrphanGracePeriod. Only certificates recorded as issued for
+	// the namespace of the OriginInventory are revoked, which requires
+	// certificate records to be enabled on the controller.
+	// +optional
+	RevokeOrphans bool `json:"revokeOrphans,omitempty"`
+
+	// OrphanGracePeriod is how long after it was first found orphaned, as
+	// recorded in its OriginCertificateRecord, a certificate may be revoked.
+	// Defaults to 7 days.
+	// +optional
+	OrphanGracePeriod *metav1.Duration `json:"orphanGracePeriod,omitempty"`
+}
+
+// OriginInventoryStatus contains status information about an OriginInventory.
+type OriginInventoryStatus struct {
+	// List of status conditions to indicate the status of an OriginInventory.
+	// Known condition types are `Ready`.
+	// +optional
+	Conditions []OriginInventoryCondition `json:"conditions,omitempty"`
+
+	// LastSyncTime is the time the certificates were last listed.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Certificates is the number of unexpired certificates listed.
+	// +optional
+	Certificates int `json:"certificates"`
+
+	// Orphaned is the number of unexpired certificates not found in the cluster.
+	// +optional
+	Orphaned int `json:"orphaned"`
+
+	// Expiring is the number of certificates expiring within the expiry threshold.
+	// +optional
+	Expiring int `json:"expiring"`
+
+	// Revoked is the number of orphaned certificates revoked during the last sync.
+	// +optional
+	Revoked int `json:"revoked,omitempty"`
+
+	// Orphans lists the orphaned certificates, up to a limit.
+	// +optional
+	Orphans []InventoryCertificate `json:"orphans,omitempty"`
+
+	// ExpiringCertificates lists the certificates expiring within the expiry
+	// threshold, up to a limit.
+	// +optional
+	ExpiringCertificates []InventoryCertificate `json:"expiringCertificates,omitempty"`
+}
+
+// InventoryCertificate describes a certificate listed by an OriginInventory.
+type InventoryCertificate struct {
+	// ID of the certificate in the Cloudflare API.
+	ID string `json:"id"`
+
+	// SerialNumber of the certificate, hex-encoded.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Hostnames the certificate is valid for.
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// ExpiresOn is the time the certificate expires.
+	// +optional
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+}
+
+// OriginInventoryCondition contains condition information for the OriginInventory.
+type OriginInventoryCondition struct {
+	// Type of the condition, known values are ('Ready')
+	Type ConditionType `json:"type"`
+
+	// Status of the condition, one of ('True', 'False', 'Unknown')
+	Status ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
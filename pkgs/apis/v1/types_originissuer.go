@@ -4,6 +4,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -32,6 +33,8 @@ type OriginIssuerList struct {
 	Items []OriginIssuer `json:"items"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:subresource:status
@@ -69,6 +72,128 @@ type OriginIssuerSpec struct {
 
 	// Auth configures how to authenticate with the Cloudflare API.
 	Auth OriginIssuerAuthentication `json:"auth"`
+
+	// API configures how the Cloudflare API is reached. If not set, the public
+	// Cloudflare API is used with the controller's default HTTP settings.
+	// +optional
+	API *OriginIssuerAPI `json:"api,omitempty"`
+
+	// SigningMode selects how certificates are signed. Cloudflare, the default,
+	// signs certificates with the Cloudflare Origin CA. Local signs certificates
+	// shaped like those of the Origin CA with a CA stored in the cluster, without
+	// calling the Cloudflare API, for staging clusters where real certificates are
+	// not wanted. Local is only honoured when the controller is started with
+	// --allow-local-signing-mode, otherwise the issuer is not ready.
+	// +optional
+	SigningMode SigningMode `json:"signingMode,omitempty"`
+
+	// Policy restricts the CertificateRequests the controller's built-in approver
+	// approves for this issuer. The approver denies requests for issuers without
+	// a policy. The policy of an OriginIssuer only governs requests from its own
+	// namespace, so it is as trusted as the OriginIssuer itself.
+	// +optional
+	Policy *OriginIssuerPolicy `json:"policy,omitempty"`
+
+	// Quota limits the certificates issued for CertificateRequests, so a
+	// misconfigured Certificate cannot issue certificates without bound.
+	// Requests over quota are held Pending until the quota allows them. Quotas
+	// are counted from OriginCertificateRecords, so the controller must record
+	// certificates.
+	// +optional
+	Quota *OriginIssuerQuota `json:"quota,omitempty"`
+}
+
+// OriginIssuerQuota limits the certificates an issuer issues.
+type OriginIssuerQuota struct {
+	// Issuer limits the certificates issued by the issuer across all
+	// namespaces.
+	// +optional
+	Issuer *IssuanceLimits `json:"issuer,omitempty"`
+
+	// Namespace limits the certificates issued by the issuer for each
+	// namespace.
+	// +optional
+	Namespace *IssuanceLimits `json:"namespace,omitempty"`
+}
+
+// IssuanceLimits limits the number of certificates issued. Unset limits, or
+// limits of zero, are not enforced.
+type IssuanceLimits struct {
+	// PerHour is the number of certificates that may be issued in any hour.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PerHour int32 `json:"perHour,omitempty"`
+
+	// PerDay is the number of certificates that may be issued in any day.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PerDay int32 `json:"perDay,omitempty"`
+
+	// MaxActive is the number of active certificates: unexpired certificates
+	// that were neither revoked nor superseded by a certificate issued later for
+	// the same Certificate or resource.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxActive int32 `json:"maxActive,omitempty"`
+}
+
+// OriginIssuerPolicy restricts the certificates that may be requested from an
+// issuer. Requests violating any of its rules are denied.
+type OriginIssuerPolicy struct {
+	// AllowedHostnames lists patterns that every hostname of a certificate must
+	// match, such as "*.example.com". Each label of a pattern is matched against
+	// a single label of the hostname in the syntax of Go's path.Match, so
+	// wildcards never match dots. If empty, any hostname is allowed.
+	// +optional
+	AllowedHostnames []string `json:"allowedHostnames,omitempty"`
+
+	// MaxDuration is the longest validity of certificates, after the requested
+	// duration is rounded to a validity supported by the Origin CA. If not set,
+	// any validity is allowed.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// AllowedNamespaces lists the namespaces certificates may be requested from.
+	// If empty, any namespace is allowed.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// OriginIssuerAPI configures the endpoint and HTTP transport used to reach the
+// Cloudflare API.
+type OriginIssuerAPI struct {
+	// Endpoint is the base URL of the Cloudflare API, such as "https://api.cloudflare.com".
+	// The endpoint must use https, unless the controller allows insecure endpoints, and
+	// OriginIssuers may only set endpoints allowed by the controller.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Timeout of requests made to the Cloudflare API. Defaults to the
+	// controller's timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Proxy is the URL of an HTTP or HTTPS proxy used to reach the Cloudflare API.
+	// OriginIssuers may only set proxies allowed by the controller.
+	// +optional
+	Proxy string `json:"proxy,omitempty"`
+
+	// CABundle references PEM-encoded CA certificates trusted when connecting to
+	// the Cloudflare API, instead of the system's certificate roots.
+	// +optional
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+}
+
+// CABundleSource references a CA bundle in a Secret or ConfigMap.
+// Only one of `secretRef` or `configMapRef` may be specified.
+type CABundleSource struct {
+	// SecretRef selects a CA bundle from a Secret.
+	// +optional
+	SecretRef *SecretKeySelector `json:"secretRef,omitempty"`
+
+	// ConfigMapRef selects a CA bundle from a ConfigMap.
+	// +optional
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty"`
 }
 
 // OriginIssuerStatus contains status information about an OriginIssuer
@@ -77,6 +202,55 @@ type OriginIssuerStatus struct {
 	// Known condition types are `Ready`.
 	// +optional
 	Conditions []OriginIssuerCondition `json:"conditions,omitempty"`
+
+	// Credentials reports the health of each of the issuer's credentials. The
+	// credential configured directly on `spec.auth` is reported as `spec.auth`.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Credentials []OriginIssuerCredentialStatus `json:"credentials,omitempty"`
+
+	// Quota reports the usage of the issuer's quota, if it has one.
+	// +optional
+	Quota *OriginIssuerQuotaStatus `json:"quota,omitempty"`
+}
+
+// OriginIssuerQuotaStatus reports the usage of an issuer's quota.
+type OriginIssuerQuotaStatus struct {
+	// Issuer is the usage of the issuer across all namespaces.
+	Issuer IssuanceUsage `json:"issuer"`
+
+	// Namespaces is the usage of each namespace that was issued certificates
+	// that are unexpired, or were issued in the last day.
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	Namespaces []NamespaceIssuanceUsage `json:"namespaces,omitempty"`
+
+	// LastUpdateTime is the time the usage last changed.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// IssuanceUsage counts the certificates issued, as limited by IssuanceLimits.
+type IssuanceUsage struct {
+	// IssuedLastHour is the number of certificates issued in the last hour.
+	IssuedLastHour int32 `json:"issuedLastHour"`
+
+	// IssuedLastDay is the number of certificates issued in the last day.
+	IssuedLastDay int32 `json:"issuedLastDay"`
+
+	// Active is the number of active certificates, unexpired and neither
+	// revoked nor superseded.
+	Active int32 `json:"active"`
+}
+
+// NamespaceIssuanceUsage counts the certificates issued for a namespace.
+type NamespaceIssuanceUsage struct {
+	// Namespace the certificates were issued for.
+	Namespace string `json:"namespace"`
+
+	IssuanceUsage `json:",inline"`
 }
 
 // OriginIssuerAuthentication defines how to authenticate with the Cloudflare API.
@@ -89,6 +263,88 @@ type OriginIssuerAuthentication struct {
 	// TokenRef authenticates with an API Token.
 	// +optional
 	TokenRef *SecretKeySelector `json:"tokenRef,omitempty"`
+
+	// ServiceKeyFile authenticates with an API Service Key read from a file
+	// mounted into the controller, such as one projected by a CSI secrets driver.
+	// The file must be within one of the credential directories allowed by the
+	// controller. Files of an OriginIssuer must be within the subdirectory of a
+	// credential directory named after its namespace.
+	// +optional
+	ServiceKeyFile string `json:"serviceKeyFile,omitempty"`
+
+	// TokenFile authenticates with an API Token read from a file mounted into
+	// the controller, such as one projected by a CSI secrets driver. The file
+	// must be within one of the credential directories allowed by the controller.
+	// Files of an OriginIssuer must be within the subdirectory of a credential
+	// directory named after its namespace.
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// Credentials is an ordered list of additional credentials. Certificates are
+	// signed with the first available credential, falling back to the next one
+	// when the Cloudflare API rejects it. Any credential configured directly on
+	// `auth` is tried first.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Credentials []OriginIssuerCredential `json:"credentials,omitempty"`
+}
+
+// OriginIssuerCredential is one of the credentials an issuer may authenticate with.
+// Only one of `serviceKeyRef`, `tokenRef`, `serviceKeyFile` or `tokenFile` may be specified.
+type OriginIssuerCredential struct {
+	// Name identifies the credential in the issuer's status. The name
+	// `spec.auth` is reserved for the credential configured directly on `auth`.
+	Name string `json:"name"`
+
+	// ServiceKeyRef authenticates with an API Service Key.
+	// +optional
+	ServiceKeyRef *SecretKeySelector `json:"serviceKeyRef,omitempty"`
+
+	// TokenRef authenticates with an API Token.
+	// +optional
+	TokenRef *SecretKeySelector `json:"tokenRef,omitempty"`
+
+	// ServiceKeyFile authenticates with an API Service Key read from a file
+	// mounted into the controller, with the same restrictions as on `auth`.
+	// +optional
+	ServiceKeyFile string `json:"serviceKeyFile,omitempty"`
+
+	// TokenFile authenticates with an API Token read from a file mounted into
+	// the controller, with the same restrictions as on `auth`.
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+}
+
+// OriginIssuerCredentialStatus contains status information about one of an
+// issuer's credentials.
+type OriginIssuerCredentialStatus struct {
+	// Name of the credential.
+	Name string `json:"name"`
+
+	// Status of the credential, one of ('True', 'False', 'Unknown'). A credential
+	// is healthy if it can be read and has not been rejected by the Cloudflare API.
+	Status ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this credential.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the credential's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedVersion identifies the revision of the credential's Secret or file
+	// the status was observed at.
+	// +optional
+	ObservedVersion string `json:"observedVersion,omitempty"`
 }
 
 // SecretKeySelector contains a reference to a secret.
@@ -99,6 +355,21 @@ type SecretKeySelector struct {
 	Name string `json:"name"`
 	// Key of the secret to select from. Must be a valid secret key.
 	Key string `json:"key"`
+	// Namespace of the secret to select. Only honoured for cluster-scoped issuers,
+	// and only for namespaces allowed by the controller's cluster-secret-namespaces
+	// setting. Defaults to the "cluster resource namespace".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ConfigMapKeySelector contains a reference to a config map.
+type ConfigMapKeySelector struct {
+	// Name of the config map in the issuer's namespace to select. If a cluster-scoped
+	// issuer, the config map is selected from the "cluster resource namespace" configured
+	// on the controller.
+	Name string `json:"name"`
+	// Key of the config map to select from. Must be a valid config map key.
+	Key string `json:"key"`
 }
 
 // OriginIssuerCondition contains condition information for the OriginIssuer.
@@ -138,6 +409,19 @@ const (
 	RequestTypeOriginECC RequestType = "OriginECC"
 )
 
+// +kubebuilder:validation:Enum=Cloudflare;Local
+
+// SigningMode represents how an issuer signs certificates.
+type SigningMode string
+
+const (
+	// SigningModeCloudflare signs certificates with the Cloudflare Origin CA.
+	SigningModeCloudflare SigningMode = "Cloudflare"
+
+	// SigningModeLocal signs certificates with a CA stored in the cluster.
+	SigningModeLocal SigningMode = "Local"
+)
+
 // +kubebuilder:validation:Enum=Ready
 
 // ConditionType represents an OriginIssuer condition value.
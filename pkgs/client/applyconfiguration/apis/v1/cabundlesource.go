@@ -0,0 +1,32 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// CABundleSourceApplyConfiguration represents a declarative configuration of the CABundleSource type for use
+// with apply.
+type CABundleSourceApplyConfiguration struct {
+	SecretRef    *SecretKeySelectorApplyConfiguration    `json:"secretRef,omitempty"`
+	ConfigMapRef *ConfigMapKeySelectorApplyConfiguration `json:"configMapRef,omitempty"`
+}
+
+// CABundleSourceApplyConfiguration constructs a declarative configuration of the CABundleSource type for use with
+// apply.
+func CABundleSource() *CABundleSourceApplyConfiguration {
+	return &CABundleSourceApplyConfiguration{}
+}
+
+// WithSecretRef sets the SecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretRef field is set to the value of the last call.
+func (b *CABundleSourceApplyConfiguration) WithSecretRef(value *SecretKeySelectorApplyConfiguration) *CABundleSourceApplyConfiguration {
+	b.SecretRef = value
+	return b
+}
+
+// WithConfigMapRef sets the ConfigMapRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapRef field is set to the value of the last call.
+func (b *CABundleSourceApplyConfiguration) WithConfigMapRef(value *ConfigMapKeySelectorApplyConfiguration) *CABundleSourceApplyConfiguration {
+	b.ConfigMapRef = value
+	return b
+}
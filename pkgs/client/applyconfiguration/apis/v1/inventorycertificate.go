@@ -0,0 +1,56 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InventoryCertificateApplyConfiguration represents a declarative configuration of the InventoryCertificate type for use
+// with apply.
+type InventoryCertificateApplyConfiguration struct {
+	ID           *string  `json:"id,omitempty"`
+	SerialNumber *string  `json:"serialNumber,omitempty"`
+	Hostnames    []string `json:"hostnames,omitempty"`
+	ExpiresOn    *v1.Time `json:"expiresOn,omitempty"`
+}
+
+// InventoryCertificateApplyConfiguration constructs a declarative configuration of the InventoryCertificate type for use with
+// apply.
+func InventoryCertificate() *InventoryCertificateApplyConfiguration {
+	return &InventoryCertificateApplyConfiguration{}
+}
+
+// WithID sets the ID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ID field is set to the value of the last call.
+func (b *InventoryCertificateApplyConfiguration) WithID(value string) *InventoryCertificateApplyConfiguration {
+	b.ID = &value
+	return b
+}
+
+// WithSerialNumber sets the SerialNumber field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SerialNumber field is set to the value of the last call.
+func (b *InventoryCertificateApplyConfiguration) WithSerialNumber(value string) *InventoryCertificateApplyConfiguration {
+	b.SerialNumber = &value
+	return b
+}
+
+// WithHostnames adds the given value to the Hostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hostnames field.
+func (b *InventoryCertificateApplyConfiguration) WithHostnames(values ...string) *InventoryCertificateApplyConfiguration {
+	for i := range values {
+		b.Hostnames = append(b.Hostnames, values[i])
+	}
+	return b
+}
+
+// WithExpiresOn sets the ExpiresOn field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpiresOn field is set to the value of the last call.
+func (b *InventoryCertificateApplyConfiguration) WithExpiresOn(value v1.Time) *InventoryCertificateApplyConfiguration {
+	b.ExpiresOn = &value
+	return b
+}
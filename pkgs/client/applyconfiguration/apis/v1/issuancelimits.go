@@ -0,0 +1,41 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// IssuanceLimitsApplyConfiguration represents a declarative configuration of the IssuanceLimits type for use
+// with apply.
+type IssuanceLimitsApplyConfiguration struct {
+	PerHour   *int32 `json:"perHour,omitempty"`
+	PerDay    *int32 `json:"perDay,omitempty"`
+	MaxActive *int32 `json:"maxActive,omitempty"`
+}
+
+// IssuanceLimitsApplyConfiguration constructs a declarative configuration of the IssuanceLimits type for use with
+// apply.
+func IssuanceLimits() *IssuanceLimitsApplyConfiguration {
+	return &IssuanceLimitsApplyConfiguration{}
+}
+
+// WithPerHour sets the PerHour field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PerHour field is set to the value of the last call.
+func (b *IssuanceLimitsApplyConfiguration) WithPerHour(value int32) *IssuanceLimitsApplyConfiguration {
+	b.PerHour = &value
+	return b
+}
+
+// WithPerDay sets the PerDay field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PerDay field is set to the value of the last call.
+func (b *IssuanceLimitsApplyConfiguration) WithPerDay(value int32) *IssuanceLimitsApplyConfiguration {
+	b.PerDay = &value
+	return b
+}
+
+// WithMaxActive sets the MaxActive field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxActive field is set to the value of the last call.
+func (b *IssuanceLimitsApplyConfiguration) WithMaxActive(value int32) *IssuanceLimitsApplyConfiguration {
+	b.MaxActive = &value
+	return b
+}
@@ -0,0 +1,41 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// IssuanceUsageApplyConfiguration represents a declarative configuration of the IssuanceUsage type for use
+// with apply.
+type IssuanceUsageApplyConfiguration struct {
+	IssuedLastHour *int32 `json:"issuedLastHour,omitempty"`
+	IssuedLastDay  *int32 `json:"issuedLastDay,omitempty"`
+	Active         *int32 `json:"active,omitempty"`
+}
+
+// IssuanceUsageApplyConfiguration constructs a declarative configuration of the IssuanceUsage type for use with
+// apply.
+func IssuanceUsage() *IssuanceUsageApplyConfiguration {
+	return &IssuanceUsageApplyConfiguration{}
+}
+
+// WithIssuedLastHour sets the IssuedLastHour field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IssuedLastHour field is set to the value of the last call.
+func (b *IssuanceUsageApplyConfiguration) WithIssuedLastHour(value int32) *IssuanceUsageApplyConfiguration {
+	b.IssuedLastHour = &value
+	return b
+}
+
+// WithIssuedLastDay sets the IssuedLastDay field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IssuedLastDay field is set to the value of the last call.
+func (b *IssuanceUsageApplyConfiguration) WithIssuedLastDay(value int32) *IssuanceUsageApplyConfiguration {
+	b.IssuedLastDay = &value
+	return b
+}
+
+// WithActive sets the Active field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Active field is set to the value of the last call.
+func (b *IssuanceUsageApplyConfiguration) WithActive(value int32) *IssuanceUsageApplyConfiguration {
+	b.Active = &value
+	return b
+}
@@ -0,0 +1,64 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginCertificateConditionApplyConfiguration represents a declarative configuration of the OriginCertificateCondition type for use
+// with apply.
+type OriginCertificateConditionApplyConfiguration struct {
+	Type               *v1.ConditionType   `json:"type,omitempty"`
+	Status             *v1.ConditionStatus `json:"status,omitempty"`
+	LastTransitionTime *metav1.Time        `json:"lastTransitionTime,omitempty"`
+	Reason             *string             `json:"reason,omitempty"`
+	Message            *string             `json:"message,omitempty"`
+}
+
+// OriginCertificateConditionApplyConfiguration constructs a declarative configuration of the OriginCertificateCondition type for use with
+// apply.
+func OriginCertificateCondition() *OriginCertificateConditionApplyConfiguration {
+	return &OriginCertificateConditionApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *OriginCertificateConditionApplyConfiguration) WithType(value v1.ConditionType) *OriginCertificateConditionApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *OriginCertificateConditionApplyConfiguration) WithStatus(value v1.ConditionStatus) *OriginCertificateConditionApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *OriginCertificateConditionApplyConfiguration) WithLastTransitionTime(value metav1.Time) *OriginCertificateConditionApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *OriginCertificateConditionApplyConfiguration) WithReason(value string) *OriginCertificateConditionApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *OriginCertificateConditionApplyConfiguration) WithMessage(value string) *OriginCertificateConditionApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,93 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginCertificateRecordSpecApplyConfiguration represents a declarative configuration of the OriginCertificateRecordSpec type for use
+// with apply.
+type OriginCertificateRecordSpecApplyConfiguration struct {
+	CertificateID *string                            `json:"certificateID,omitempty"`
+	SerialNumber  *string                            `json:"serialNumber,omitempty"`
+	Hostnames     []string                           `json:"hostnames,omitempty"`
+	RequestType   *v1.RequestType                    `json:"requestType,omitempty"`
+	NotBefore     *metav1.Time                       `json:"notBefore,omitempty"`
+	NotAfter      *metav1.Time                       `json:"notAfter,omitempty"`
+	IssuerRef     *IssuerReferenceApplyConfiguration `json:"issuerRef,omitempty"`
+	Source        *RecordSourceApplyConfiguration    `json:"source,omitempty"`
+}
+
+// OriginCertificateRecordSpecApplyConfiguration constructs a declarative configuration of the OriginCertificateRecordSpec type for use with
+// apply.
+func OriginCertificateRecordSpec() *OriginCertificateRecordSpecApplyConfiguration {
+	return &OriginCertificateRecordSpecApplyConfiguration{}
+}
+
+// WithCertificateID sets the CertificateID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CertificateID field is set to the value of the last call.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithCertificateID(value string) *OriginCertificateRecordSpecApplyConfiguration {
+	b.CertificateID = &value
+	return b
+}
+
+// WithSerialNumber sets the SerialNumber field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SerialNumber field is set to the value of the last call.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithSerialNumber(value string) *OriginCertificateRecordSpecApplyConfiguration {
+	b.SerialNumber = &value
+	return b
+}
+
+// WithHostnames adds the given value to the Hostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hostnames field.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithHostnames(values ...string) *OriginCertificateRecordSpecApplyConfiguration {
+	for i := range values {
+		b.Hostnames = append(b.Hostnames, values[i])
+	}
+	return b
+}
+
+// WithRequestType sets the RequestType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestType field is set to the value of the last call.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithRequestType(value v1.RequestType) *OriginCertificateRecordSpecApplyConfiguration {
+	b.RequestType = &value
+	return b
+}
+
+// WithNotBefore sets the NotBefore field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotBefore field is set to the value of the last call.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithNotBefore(value metav1.Time) *OriginCertificateRecordSpecApplyConfiguration {
+	b.NotBefore = &value
+	return b
+}
+
+// WithNotAfter sets the NotAfter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotAfter field is set to the value of the last call.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithNotAfter(value metav1.Time) *OriginCertificateRecordSpecApplyConfiguration {
+	b.NotAfter = &value
+	return b
+}
+
+// WithIssuerRef sets the IssuerRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IssuerRef field is set to the value of the last call.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithIssuerRef(value *IssuerReferenceApplyConfiguration) *OriginCertificateRecordSpecApplyConfiguration {
+	b.IssuerRef = value
+	return b
+}
+
+// WithSource sets the Source field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Source field is set to the value of the last call.
+func (b *OriginCertificateRecordSpecApplyConfiguration) WithSource(value *RecordSourceApplyConfiguration) *OriginCertificateRecordSpecApplyConfiguration {
+	b.Source = value
+	return b
+}
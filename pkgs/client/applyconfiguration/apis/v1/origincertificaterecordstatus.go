@@ -0,0 +1,36 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginCertificateRecordStatusApplyConfiguration represents a declarative configuration of the OriginCertificateRecordStatus type for use
+// with apply.
+type OriginCertificateRecordStatusApplyConfiguration struct {
+	RevocationTime *v1.Time `json:"revocationTime,omitempty"`
+	OrphanedTime   *v1.Time `json:"orphanedTime,omitempty"`
+}
+
+// OriginCertificateRecordStatusApplyConfiguration constructs a declarative configuration of the OriginCertificateRecordStatus type for use with
+// apply.
+func OriginCertificateRecordStatus() *OriginCertificateRecordStatusApplyConfiguration {
+	return &OriginCertificateRecordStatusApplyConfiguration{}
+}
+
+// WithRevocationTime sets the RevocationTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RevocationTime field is set to the value of the last call.
+func (b *OriginCertificateRecordStatusApplyConfiguration) WithRevocationTime(value v1.Time) *OriginCertificateRecordStatusApplyConfiguration {
+	b.RevocationTime = &value
+	return b
+}
+
+// WithOrphanedTime sets the OrphanedTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrphanedTime field is set to the value of the last call.
+func (b *OriginCertificateRecordStatusApplyConfiguration) WithOrphanedTime(value v1.Time) *OriginCertificateRecordStatusApplyConfiguration {
+	b.OrphanedTime = &value
+	return b
+}
@@ -0,0 +1,65 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginCertificateSpecApplyConfiguration represents a declarative configuration of the OriginCertificateSpec type for use
+// with apply.
+type OriginCertificateSpecApplyConfiguration struct {
+	SecretName  *string                            `json:"secretName,omitempty"`
+	DNSNames    []string                           `json:"dnsNames,omitempty"`
+	Duration    *v1.Duration                       `json:"duration,omitempty"`
+	RenewBefore *v1.Duration                       `json:"renewBefore,omitempty"`
+	IssuerRef   *IssuerReferenceApplyConfiguration `json:"issuerRef,omitempty"`
+}
+
+// OriginCertificateSpecApplyConfiguration constructs a declarative configuration of the OriginCertificateSpec type for use with
+// apply.
+func OriginCertificateSpec() *OriginCertificateSpecApplyConfiguration {
+	return &OriginCertificateSpecApplyConfiguration{}
+}
+
+// WithSecretName sets the SecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretName field is set to the value of the last call.
+func (b *OriginCertificateSpecApplyConfiguration) WithSecretName(value string) *OriginCertificateSpecApplyConfiguration {
+	b.SecretName = &value
+	return b
+}
+
+// WithDNSNames adds the given value to the DNSNames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DNSNames field.
+func (b *OriginCertificateSpecApplyConfiguration) WithDNSNames(values ...string) *OriginCertificateSpecApplyConfiguration {
+	for i := range values {
+		b.DNSNames = append(b.DNSNames, values[i])
+	}
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *OriginCertificateSpecApplyConfiguration) WithDuration(value v1.Duration) *OriginCertificateSpecApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithRenewBefore sets the RenewBefore field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RenewBefore field is set to the value of the last call.
+func (b *OriginCertificateSpecApplyConfiguration) WithRenewBefore(value v1.Duration) *OriginCertificateSpecApplyConfiguration {
+	b.RenewBefore = &value
+	return b
+}
+
+// WithIssuerRef sets the IssuerRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IssuerRef field is set to the value of the last call.
+func (b *OriginCertificateSpecApplyConfiguration) WithIssuerRef(value *IssuerReferenceApplyConfiguration) *OriginCertificateSpecApplyConfiguration {
+	b.IssuerRef = value
+	return b
+}
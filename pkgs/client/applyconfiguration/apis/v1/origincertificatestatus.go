@@ -0,0 +1,68 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginCertificateStatusApplyConfiguration represents a declarative configuration of the OriginCertificateStatus type for use
+// with apply.
+type OriginCertificateStatusApplyConfiguration struct {
+	Conditions         []OriginCertificateConditionApplyConfiguration `json:"conditions,omitempty"`
+	NotBefore          *metav1.Time                                   `json:"notBefore,omitempty"`
+	NotAfter           *metav1.Time                                   `json:"notAfter,omitempty"`
+	RenewalTime        *metav1.Time                                   `json:"renewalTime,omitempty"`
+	PendingCertificate *PendingCertificateApplyConfiguration          `json:"pendingCertificate,omitempty"`
+}
+
+// OriginCertificateStatusApplyConfiguration constructs a declarative configuration of the OriginCertificateStatus type for use with
+// apply.
+func OriginCertificateStatus() *OriginCertificateStatusApplyConfiguration {
+	return &OriginCertificateStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *OriginCertificateStatusApplyConfiguration) WithConditions(values ...*OriginCertificateConditionApplyConfiguration) *OriginCertificateStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithNotBefore sets the NotBefore field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotBefore field is set to the value of the last call.
+func (b *OriginCertificateStatusApplyConfiguration) WithNotBefore(value metav1.Time) *OriginCertificateStatusApplyConfiguration {
+	b.NotBefore = &value
+	return b
+}
+
+// WithNotAfter sets the NotAfter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotAfter field is set to the value of the last call.
+func (b *OriginCertificateStatusApplyConfiguration) WithNotAfter(value metav1.Time) *OriginCertificateStatusApplyConfiguration {
+	b.NotAfter = &value
+	return b
+}
+
+// WithRenewalTime sets the RenewalTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RenewalTime field is set to the value of the last call.
+func (b *OriginCertificateStatusApplyConfiguration) WithRenewalTime(value metav1.Time) *OriginCertificateStatusApplyConfiguration {
+	b.RenewalTime = &value
+	return b
+}
+
+// WithPendingCertificate sets the PendingCertificate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingCertificate field is set to the value of the last call.
+func (b *OriginCertificateStatusApplyConfiguration) WithPendingCertificate(value *PendingCertificateApplyConfiguration) *OriginCertificateStatusApplyConfiguration {
+	b.PendingCertificate = value
+	return b
+}
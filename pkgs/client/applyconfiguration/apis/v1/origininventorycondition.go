@@ -0,0 +1,64 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginInventoryConditionApplyConfiguration represents a declarative configuration of the OriginInventoryCondition type for use
+// with apply.
+type OriginInventoryConditionApplyConfiguration struct {
+	Type               *v1.ConditionType   `json:"type,omitempty"`
+	Status             *v1.ConditionStatus `json:"status,omitempty"`
+	LastTransitionTime *metav1.Time        `json:"lastTransitionTime,omitempty"`
+	Reason             *string             `json:"reason,omitempty"`
+	Message            *string             `json:"message,omitempty"`
+}
+
+// OriginInventoryConditionApplyConfiguration constructs a declarative configuration of the OriginInventoryCondition type for use with
+// apply.
+func OriginInventoryCondition() *OriginInventoryConditionApplyConfiguration {
+	return &OriginInventoryConditionApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *OriginInventoryConditionApplyConfiguration) WithType(value v1.ConditionType) *OriginInventoryConditionApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *OriginInventoryConditionApplyConfiguration) WithStatus(value v1.ConditionStatus) *OriginInventoryConditionApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *OriginInventoryConditionApplyConfiguration) WithLastTransitionTime(value metav1.Time) *OriginInventoryConditionApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *OriginInventoryConditionApplyConfiguration) WithReason(value string) *OriginInventoryConditionApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *OriginInventoryConditionApplyConfiguration) WithMessage(value string) *OriginInventoryConditionApplyConfiguration {
+	b.Message = &value
+	return b
+}
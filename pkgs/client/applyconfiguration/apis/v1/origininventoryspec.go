@@ -0,0 +1,74 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginInventorySpecApplyConfiguration represents a declarative configuration of the OriginInventorySpec type for use
+// with apply.
+type OriginInventorySpecApplyConfiguration struct {
+	IssuerRef         *IssuerReferenceApplyConfiguration `json:"issuerRef,omitempty"`
+	ZoneIDs           []string                           `json:"zoneIDs,omitempty"`
+	Interval          *metav1.Duration                   `json:"interval,omitempty"`
+	ExpiryThreshold   *metav1.Duration                   `json:"expiryThreshold,omitempty"`
+	RevokeOrphans     *bool                              `json:"revokeOrphans,omitempty"`
+	OrphanGracePeriod *metav1.Duration                   `json:"orphanGracePeriod,omitempty"`
+}
+
+// OriginInventorySpecApplyConfiguration constructs a declarative configuration of the OriginInventorySpec type for use with
+// apply.
+func OriginInventorySpec() *OriginInventorySpecApplyConfiguration {
+	return &OriginInventorySpecApplyConfiguration{}
+}
+
+// WithIssuerRef sets the IssuerRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IssuerRef field is set to the value of the last call.
+func (b *OriginInventorySpecApplyConfiguration) WithIssuerRef(value *IssuerReferenceApplyConfiguration) *OriginInventorySpecApplyConfiguration {
+	b.IssuerRef = value
+	return b
+}
+
+// WithZoneIDs adds the given value to the ZoneIDs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ZoneIDs field.
+func (b *OriginInventorySpecApplyConfiguration) WithZoneIDs(values ...string) *OriginInventorySpecApplyConfiguration {
+	for i := range values {
+		b.ZoneIDs = append(b.ZoneIDs, values[i])
+	}
+	return b
+}
+
+// WithInterval sets the Interval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Interval field is set to the value of the last call.
+func (b *OriginInventorySpecApplyConfiguration) WithInterval(value metav1.Duration) *OriginInventorySpecApplyConfiguration {
+	b.Interval = &value
+	return b
+}
+
+// WithExpiryThreshold sets the ExpiryThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpiryThreshold field is set to the value of the last call.
+func (b *OriginInventorySpecApplyConfiguration) WithExpiryThreshold(value metav1.Duration) *OriginInventorySpecApplyConfiguration {
+	b.ExpiryThreshold = &value
+	return b
+}
+
+// WithRevokeOrphans sets the RevokeOrphans field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RevokeOrphans field is set to the value of the last call.
+func (b *OriginInventorySpecApplyConfiguration) WithRevokeOrphans(value bool) *OriginInventorySpecApplyConfiguration {
+	b.RevokeOrphans = &value
+	return b
+}
+
+// WithOrphanGracePeriod sets the OrphanGracePeriod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrphanGracePeriod field is set to the value of the last call.
+func (b *OriginInventorySpecApplyConfiguration) WithOrphanGracePeriod(value metav1.Duration) *OriginInventorySpecApplyConfiguration {
+	b.OrphanGracePeriod = &value
+	return b
+}
@@ -0,0 +1,105 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginInventoryStatusApplyConfiguration represents a declarative configuration of the OriginInventoryStatus type for use
+// with apply.
+type OriginInventoryStatusApplyConfiguration struct {
+	Conditions           []OriginInventoryConditionApplyConfiguration `json:"conditions,omitempty"`
+	LastSyncTime         *metav1.Time                                 `json:"lastSyncTime,omitempty"`
+	Certificates         *int                                         `json:"certificates,omitempty"`
+	Orphaned             *int                                         `json:"orphaned,omitempty"`
+	Expiring             *int                                         `json:"expiring,omitempty"`
+	Revoked              *int                                         `json:"revoked,omitempty"`
+	Orphans              []InventoryCertificateApplyConfiguration     `json:"orphans,omitempty"`
+	ExpiringCertificates []InventoryCertificateApplyConfiguration     `json:"expiringCertificates,omitempty"`
+}
+
+// OriginInventoryStatusApplyConfiguration constructs a declarative configuration of the OriginInventoryStatus type for use with
+// apply.
+func OriginInventoryStatus() *OriginInventoryStatusApplyConfiguration {
+	return &OriginInventoryStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *OriginInventoryStatusApplyConfiguration) WithConditions(values ...*OriginInventoryConditionApplyConfiguration) *OriginInventoryStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithLastSyncTime sets the LastSyncTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSyncTime field is set to the value of the last call.
+func (b *OriginInventoryStatusApplyConfiguration) WithLastSyncTime(value metav1.Time) *OriginInventoryStatusApplyConfiguration {
+	b.LastSyncTime = &value
+	return b
+}
+
+// WithCertificates sets the Certificates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Certificates field is set to the value of the last call.
+func (b *OriginInventoryStatusApplyConfiguration) WithCertificates(value int) *OriginInventoryStatusApplyConfiguration {
+	b.Certificates = &value
+	return b
+}
+
+// WithOrphaned sets the Orphaned field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Orphaned field is set to the value of the last call.
+func (b *OriginInventoryStatusApplyConfiguration) WithOrphaned(value int) *OriginInventoryStatusApplyConfiguration {
+	b.Orphaned = &value
+	return b
+}
+
+// WithExpiring sets the Expiring field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expiring field is set to the value of the last call.
+func (b *OriginInventoryStatusApplyConfiguration) WithExpiring(value int) *OriginInventoryStatusApplyConfiguration {
+	b.Expiring = &value
+	return b
+}
+
+// WithRevoked sets the Revoked field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revoked field is set to the value of the last call.
+func (b *OriginInventoryStatusApplyConfiguration) WithRevoked(value int) *OriginInventoryStatusApplyConfiguration {
+	b.Revoked = &value
+	return b
+}
+
+// WithOrphans adds the given value to the Orphans field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Orphans field.
+func (b *OriginInventoryStatusApplyConfiguration) WithOrphans(values ...*InventoryCertificateApplyConfiguration) *OriginInventoryStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOrphans")
+		}
+		b.Orphans = append(b.Orphans, *values[i])
+	}
+	return b
+}
+
+// WithExpiringCertificates adds the given value to the ExpiringCertificates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExpiringCertificates field.
+func (b *OriginInventoryStatusApplyConfiguration) WithExpiringCertificates(values ...*InventoryCertificateApplyConfiguration) *OriginInventoryStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExpiringCertificates")
+		}
+		b.ExpiringCertificates = append(b.ExpiringCertificates, *values[i])
+	}
+	return b
+}
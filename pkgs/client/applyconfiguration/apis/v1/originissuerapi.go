@@ -0,0 +1,54 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginIssuerAPIApplyConfiguration represents a declarative configuration of the OriginIssuerAPI type for use
+// with apply.
+type OriginIssuerAPIApplyConfiguration struct {
+	Endpoint *string                           `json:"endpoint,omitempty"`
+	Timeout  *v1.Duration                      `json:"timeout,omitempty"`
+	Proxy    *string                           `json:"proxy,omitempty"`
+	CABundle *CABundleSourceApplyConfiguration `json:"caBundle,omitempty"`
+}
+
+// OriginIssuerAPIApplyConfiguration constructs a declarative configuration of the OriginIssuerAPI type for use with
+// apply.
+func OriginIssuerAPI() *OriginIssuerAPIApplyConfiguration {
+	return &OriginIssuerAPIApplyConfiguration{}
+}
+
+// WithEndpoint sets the Endpoint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Endpoint field is set to the value of the last call.
+func (b *OriginIssuerAPIApplyConfiguration) WithEndpoint(value string) *OriginIssuerAPIApplyConfiguration {
+	b.Endpoint = &value
+	return b
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *OriginIssuerAPIApplyConfiguration) WithTimeout(value v1.Duration) *OriginIssuerAPIApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithProxy sets the Proxy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Proxy field is set to the value of the last call.
+func (b *OriginIssuerAPIApplyConfiguration) WithProxy(value string) *OriginIssuerAPIApplyConfiguration {
+	b.Proxy = &value
+	return b
+}
+
+// WithCABundle sets the CABundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CABundle field is set to the value of the last call.
+func (b *OriginIssuerAPIApplyConfiguration) WithCABundle(value *CABundleSourceApplyConfiguration) *OriginIssuerAPIApplyConfiguration {
+	b.CABundle = value
+	return b
+}
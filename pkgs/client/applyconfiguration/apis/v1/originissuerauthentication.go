@@ -0,0 +1,64 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OriginIssuerAuthenticationApplyConfiguration represents a declarative configuration of the OriginIssuerAuthentication type for use
+// with apply.
+type OriginIssuerAuthenticationApplyConfiguration struct {
+	ServiceKeyRef  *SecretKeySelectorApplyConfiguration       `json:"serviceKeyRef,omitempty"`
+	TokenRef       *SecretKeySelectorApplyConfiguration       `json:"tokenRef,omitempty"`
+	ServiceKeyFile *string                                    `json:"serviceKeyFile,omitempty"`
+	TokenFile      *string                                    `json:"tokenFile,omitempty"`
+	Credentials    []OriginIssuerCredentialApplyConfiguration `json:"credentials,omitempty"`
+}
+
+// OriginIssuerAuthenticationApplyConfiguration constructs a declarative configuration of the OriginIssuerAuthentication type for use with
+// apply.
+func OriginIssuerAuthentication() *OriginIssuerAuthenticationApplyConfiguration {
+	return &OriginIssuerAuthenticationApplyConfiguration{}
+}
+
+// WithServiceKeyRef sets the ServiceKeyRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceKeyRef field is set to the value of the last call.
+func (b *OriginIssuerAuthenticationApplyConfiguration) WithServiceKeyRef(value *SecretKeySelectorApplyConfiguration) *OriginIssuerAuthenticationApplyConfiguration {
+	b.ServiceKeyRef = value
+	return b
+}
+
+// WithTokenRef sets the TokenRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TokenRef field is set to the value of the last call.
+func (b *OriginIssuerAuthenticationApplyConfiguration) WithTokenRef(value *SecretKeySelectorApplyConfiguration) *OriginIssuerAuthenticationApplyConfiguration {
+	b.TokenRef = value
+	return b
+}
+
+// WithServiceKeyFile sets the ServiceKeyFile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceKeyFile field is set to the value of the last call.
+func (b *OriginIssuerAuthenticationApplyConfiguration) WithServiceKeyFile(value string) *OriginIssuerAuthenticationApplyConfiguration {
+	b.ServiceKeyFile = &value
+	return b
+}
+
+// WithTokenFile sets the TokenFile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TokenFile field is set to the value of the last call.
+func (b *OriginIssuerAuthenticationApplyConfiguration) WithTokenFile(value string) *OriginIssuerAuthenticationApplyConfiguration {
+	b.TokenFile = &value
+	return b
+}
+
+// WithCredentials adds the given value to the Credentials field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Credentials field.
+func (b *OriginIssuerAuthenticationApplyConfiguration) WithCredentials(values ...*OriginIssuerCredentialApplyConfiguration) *OriginIssuerAuthenticationApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCredentials")
+		}
+		b.Credentials = append(b.Credentials, *values[i])
+	}
+	return b
+}
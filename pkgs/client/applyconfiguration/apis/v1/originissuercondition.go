@@ -0,0 +1,64 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginIssuerConditionApplyConfiguration represents a declarative configuration of the OriginIssuerCondition type for use
+// with apply.
+type OriginIssuerConditionApplyConfiguration struct {
+	Type               *v1.ConditionType   `json:"type,omitempty"`
+	Status             *v1.ConditionStatus `json:"status,omitempty"`
+	LastTransitionTime *metav1.Time        `json:"lastTransitionTime,omitempty"`
+	Reason             *string             `json:"reason,omitempty"`
+	Message            *string             `json:"message,omitempty"`
+}
+
+// OriginIssuerConditionApplyConfiguration constructs a declarative configuration of the OriginIssuerCondition type for use with
+// apply.
+func OriginIssuerCondition() *OriginIssuerConditionApplyConfiguration {
+	return &OriginIssuerConditionApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *OriginIssuerConditionApplyConfiguration) WithType(value v1.ConditionType) *OriginIssuerConditionApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *OriginIssuerConditionApplyConfiguration) WithStatus(value v1.ConditionStatus) *OriginIssuerConditionApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *OriginIssuerConditionApplyConfiguration) WithLastTransitionTime(value metav1.Time) *OriginIssuerConditionApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *OriginIssuerConditionApplyConfiguration) WithReason(value string) *OriginIssuerConditionApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *OriginIssuerConditionApplyConfiguration) WithMessage(value string) *OriginIssuerConditionApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,59 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OriginIssuerCredentialApplyConfiguration represents a declarative configuration of the OriginIssuerCredential type for use
+// with apply.
+type OriginIssuerCredentialApplyConfiguration struct {
+	Name           *string                              `json:"name,omitempty"`
+	ServiceKeyRef  *SecretKeySelectorApplyConfiguration `json:"serviceKeyRef,omitempty"`
+	TokenRef       *SecretKeySelectorApplyConfiguration `json:"tokenRef,omitempty"`
+	ServiceKeyFile *string                              `json:"serviceKeyFile,omitempty"`
+	TokenFile      *string                              `json:"tokenFile,omitempty"`
+}
+
+// OriginIssuerCredentialApplyConfiguration constructs a declarative configuration of the OriginIssuerCredential type for use with
+// apply.
+func OriginIssuerCredential() *OriginIssuerCredentialApplyConfiguration {
+	return &OriginIssuerCredentialApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *OriginIssuerCredentialApplyConfiguration) WithName(value string) *OriginIssuerCredentialApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithServiceKeyRef sets the ServiceKeyRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceKeyRef field is set to the value of the last call.
+func (b *OriginIssuerCredentialApplyConfiguration) WithServiceKeyRef(value *SecretKeySelectorApplyConfiguration) *OriginIssuerCredentialApplyConfiguration {
+	b.ServiceKeyRef = value
+	return b
+}
+
+// WithTokenRef sets the TokenRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TokenRef field is set to the value of the last call.
+func (b *OriginIssuerCredentialApplyConfiguration) WithTokenRef(value *SecretKeySelectorApplyConfiguration) *OriginIssuerCredentialApplyConfiguration {
+	b.TokenRef = value
+	return b
+}
+
+// WithServiceKeyFile sets the ServiceKeyFile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceKeyFile field is set to the value of the last call.
+func (b *OriginIssuerCredentialApplyConfiguration) WithServiceKeyFile(value string) *OriginIssuerCredentialApplyConfiguration {
+	b.ServiceKeyFile = &value
+	return b
+}
+
+// WithTokenFile sets the TokenFile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TokenFile field is set to the value of the last call.
+func (b *OriginIssuerCredentialApplyConfiguration) WithTokenFile(value string) *OriginIssuerCredentialApplyConfiguration {
+	b.TokenFile = &value
+	return b
+}
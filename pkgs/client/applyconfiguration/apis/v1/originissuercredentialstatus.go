@@ -0,0 +1,73 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginIssuerCredentialStatusApplyConfiguration represents a declarative configuration of the OriginIssuerCredentialStatus type for use
+// with apply.
+type OriginIssuerCredentialStatusApplyConfiguration struct {
+	Name               *string             `json:"name,omitempty"`
+	Status             *v1.ConditionStatus `json:"status,omitempty"`
+	LastTransitionTime *metav1.Time        `json:"lastTransitionTime,omitempty"`
+	Reason             *string             `json:"reason,omitempty"`
+	Message            *string             `json:"message,omitempty"`
+	ObservedVersion    *string             `json:"observedVersion,omitempty"`
+}
+
+// OriginIssuerCredentialStatusApplyConfiguration constructs a declarative configuration of the OriginIssuerCredentialStatus type for use with
+// apply.
+func OriginIssuerCredentialStatus() *OriginIssuerCredentialStatusApplyConfiguration {
+	return &OriginIssuerCredentialStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *OriginIssuerCredentialStatusApplyConfiguration) WithName(value string) *OriginIssuerCredentialStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *OriginIssuerCredentialStatusApplyConfiguration) WithStatus(value v1.ConditionStatus) *OriginIssuerCredentialStatusApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *OriginIssuerCredentialStatusApplyConfiguration) WithLastTransitionTime(value metav1.Time) *OriginIssuerCredentialStatusApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *OriginIssuerCredentialStatusApplyConfiguration) WithReason(value string) *OriginIssuerCredentialStatusApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *OriginIssuerCredentialStatusApplyConfiguration) WithMessage(value string) *OriginIssuerCredentialStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithObservedVersion sets the ObservedVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedVersion field is set to the value of the last call.
+func (b *OriginIssuerCredentialStatusApplyConfiguration) WithObservedVersion(value string) *OriginIssuerCredentialStatusApplyConfiguration {
+	b.ObservedVersion = &value
+	return b
+}
@@ -0,0 +1,49 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginIssuerPolicyApplyConfiguration represents a declarative configuration of the OriginIssuerPolicy type for use
+// with apply.
+type OriginIssuerPolicyApplyConfiguration struct {
+	AllowedHostnames  []string     `json:"allowedHostnames,omitempty"`
+	MaxDuration       *v1.Duration `json:"maxDuration,omitempty"`
+	AllowedNamespaces []string     `json:"allowedNamespaces,omitempty"`
+}
+
+// OriginIssuerPolicyApplyConfiguration constructs a declarative configuration of the OriginIssuerPolicy type for use with
+// apply.
+func OriginIssuerPolicy() *OriginIssuerPolicyApplyConfiguration {
+	return &OriginIssuerPolicyApplyConfiguration{}
+}
+
+// WithAllowedHostnames adds the given value to the AllowedHostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AllowedHostnames field.
+func (b *OriginIssuerPolicyApplyConfiguration) WithAllowedHostnames(values ...string) *OriginIssuerPolicyApplyConfiguration {
+	for i := range values {
+		b.AllowedHostnames = append(b.AllowedHostnames, values[i])
+	}
+	return b
+}
+
+// WithMaxDuration sets the MaxDuration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxDuration field is set to the value of the last call.
+func (b *OriginIssuerPolicyApplyConfiguration) WithMaxDuration(value v1.Duration) *OriginIssuerPolicyApplyConfiguration {
+	b.MaxDuration = &value
+	return b
+}
+
+// WithAllowedNamespaces adds the given value to the AllowedNamespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AllowedNamespaces field.
+func (b *OriginIssuerPolicyApplyConfiguration) WithAllowedNamespaces(values ...string) *OriginIssuerPolicyApplyConfiguration {
+	for i := range values {
+		b.AllowedNamespaces = append(b.AllowedNamespaces, values[i])
+	}
+	return b
+}
@@ -0,0 +1,32 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OriginIssuerQuotaApplyConfiguration represents a declarative configuration of the OriginIssuerQuota type for use
+// with apply.
+type OriginIssuerQuotaApplyConfiguration struct {
+	Issuer    *IssuanceLimitsApplyConfiguration `json:"issuer,omitempty"`
+	Namespace *IssuanceLimitsApplyConfiguration `json:"namespace,omitempty"`
+}
+
+// OriginIssuerQuotaApplyConfiguration constructs a declarative configuration of the OriginIssuerQuota type for use with
+// apply.
+func OriginIssuerQuota() *OriginIssuerQuotaApplyConfiguration {
+	return &OriginIssuerQuotaApplyConfiguration{}
+}
+
+// WithIssuer sets the Issuer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Issuer field is set to the value of the last call.
+func (b *OriginIssuerQuotaApplyConfiguration) WithIssuer(value *IssuanceLimitsApplyConfiguration) *OriginIssuerQuotaApplyConfiguration {
+	b.Issuer = value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *OriginIssuerQuotaApplyConfiguration) WithNamespace(value *IssuanceLimitsApplyConfiguration) *OriginIssuerQuotaApplyConfiguration {
+	b.Namespace = value
+	return b
+}
@@ -0,0 +1,50 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OriginIssuerQuotaStatusApplyConfiguration represents a declarative configuration of the OriginIssuerQuotaStatus type for use
+// with apply.
+type OriginIssuerQuotaStatusApplyConfiguration struct {
+	Issuer         *IssuanceUsageApplyConfiguration           `json:"issuer,omitempty"`
+	Namespaces     []NamespaceIssuanceUsageApplyConfiguration `json:"namespaces,omitempty"`
+	LastUpdateTime *metav1.Time                               `json:"lastUpdateTime,omitempty"`
+}
+
+// OriginIssuerQuotaStatusApplyConfiguration constructs a declarative configuration of the OriginIssuerQuotaStatus type for use with
+// apply.
+func OriginIssuerQuotaStatus() *OriginIssuerQuotaStatusApplyConfiguration {
+	return &OriginIssuerQuotaStatusApplyConfiguration{}
+}
+
+// WithIssuer sets the Issuer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Issuer field is set to the value of the last call.
+func (b *OriginIssuerQuotaStatusApplyConfiguration) WithIssuer(value *IssuanceUsageApplyConfiguration) *OriginIssuerQuotaStatusApplyConfiguration {
+	b.Issuer = value
+	return b
+}
+
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *OriginIssuerQuotaStatusApplyConfiguration) WithNamespaces(values ...*NamespaceIssuanceUsageApplyConfiguration) *OriginIssuerQuotaStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNamespaces")
+		}
+		b.Namespaces = append(b.Namespaces, *values[i])
+	}
+	return b
+}
+
+// WithLastUpdateTime sets the LastUpdateTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpdateTime field is set to the value of the last call.
+func (b *OriginIssuerQuotaStatusApplyConfiguration) WithLastUpdateTime(value metav1.Time) *OriginIssuerQuotaStatusApplyConfiguration {
+	b.LastUpdateTime = &value
+	return b
+}
@@ -0,0 +1,72 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+)
+
+// OriginIssuerSpecApplyConfiguration represents a declarative configuration of the OriginIssuerSpec type for use
+// with apply.
+type OriginIssuerSpecApplyConfiguration struct {
+	RequestType *v1.RequestType                               `json:"requestType,omitempty"`
+	Auth        *OriginIssuerAuthenticationApplyConfiguration `json:"auth,omitempty"`
+	API         *OriginIssuerAPIApplyConfiguration            `json:"api,omitempty"`
+	SigningMode *v1.SigningMode                               `json:"signingMode,omitempty"`
+	Policy      *OriginIssuerPolicyApplyConfiguration         `json:"policy,omitempty"`
+	Quota       *OriginIssuerQuotaApplyConfiguration          `json:"quota,omitempty"`
+}
+
+// OriginIssuerSpecApplyConfiguration constructs a declarative configuration of the OriginIssuerSpec type for use with
+// apply.
+func OriginIssuerSpec() *OriginIssuerSpecApplyConfiguration {
+	return &OriginIssuerSpecApplyConfiguration{}
+}
+
+// WithRequestType sets the RequestType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestType field is set to the value of the last call.
+func (b *OriginIssuerSpecApplyConfiguration) WithRequestType(value v1.RequestType) *OriginIssuerSpecApplyConfiguration {
+	b.RequestType = &value
+	return b
+}
+
+// WithAuth sets the Auth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Auth field is set to the value of the last call.
+func (b *OriginIssuerSpecApplyConfiguration) WithAuth(value *OriginIssuerAuthenticationApplyConfiguration) *OriginIssuerSpecApplyConfiguration {
+	b.Auth = value
+	return b
+}
+
+// WithAPI sets the API field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the API field is set to the value of the last call.
+func (b *OriginIssuerSpecApplyConfiguration) WithAPI(value *OriginIssuerAPIApplyConfiguration) *OriginIssuerSpecApplyConfiguration {
+	b.API = value
+	return b
+}
+
+// WithSigningMode sets the SigningMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SigningMode field is set to the value of the last call.
+func (b *OriginIssuerSpecApplyConfiguration) WithSigningMode(value v1.SigningMode) *OriginIssuerSpecApplyConfiguration {
+	b.SigningMode = &value
+	return b
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *OriginIssuerSpecApplyConfiguration) WithPolicy(value *OriginIssuerPolicyApplyConfiguration) *OriginIssuerSpecApplyConfiguration {
+	b.Policy = value
+	return b
+}
+
+// WithQuota sets the Quota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Quota field is set to the value of the last call.
+func (b *OriginIssuerSpecApplyConfiguration) WithQuota(value *OriginIssuerQuotaApplyConfiguration) *OriginIssuerSpecApplyConfiguration {
+	b.Quota = value
+	return b
+}
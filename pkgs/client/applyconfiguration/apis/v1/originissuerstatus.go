@@ -0,0 +1,51 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// OriginIssuerStatusApplyConfiguration represents a declarative configuration of the OriginIssuerStatus type for use
+// with apply.
+type OriginIssuerStatusApplyConfiguration struct {
+	Conditions  []OriginIssuerConditionApplyConfiguration        `json:"conditions,omitempty"`
+	Credentials []OriginIssuerCredentialStatusApplyConfiguration `json:"credentials,omitempty"`
+	Quota       *OriginIssuerQuotaStatusApplyConfiguration       `json:"quota,omitempty"`
+}
+
+// OriginIssuerStatusApplyConfiguration constructs a declarative configuration of the OriginIssuerStatus type for use with
+// apply.
+func OriginIssuerStatus() *OriginIssuerStatusApplyConfiguration {
+	return &OriginIssuerStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *OriginIssuerStatusApplyConfiguration) WithConditions(values ...*OriginIssuerConditionApplyConfiguration) *OriginIssuerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithCredentials adds the given value to the Credentials field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Credentials field.
+func (b *OriginIssuerStatusApplyConfiguration) WithCredentials(values ...*OriginIssuerCredentialStatusApplyConfiguration) *OriginIssuerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCredentials")
+		}
+		b.Credentials = append(b.Credentials, *values[i])
+	}
+	return b
+}
+
+// WithQuota sets the Quota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Quota field is set to the value of the last call.
+func (b *OriginIssuerStatusApplyConfiguration) WithQuota(value *OriginIssuerQuotaStatusApplyConfiguration) *OriginIssuerStatusApplyConfiguration {
+	b.Quota = value
+	return b
+}
@@ -0,0 +1,32 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PendingCertificateApplyConfiguration represents a declarative configuration of the PendingCertificate type for use
+// with apply.
+type PendingCertificateApplyConfiguration struct {
+	ID           *string `json:"id,omitempty"`
+	SerialNumber *string `json:"serialNumber,omitempty"`
+}
+
+// PendingCertificateApplyConfiguration constructs a declarative configuration of the PendingCertificate type for use with
+// apply.
+func PendingCertificate() *PendingCertificateApplyConfiguration {
+	return &PendingCertificateApplyConfiguration{}
+}
+
+// WithID sets the ID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ID field is set to the value of the last call.
+func (b *PendingCertificateApplyConfiguration) WithID(value string) *PendingCertificateApplyConfiguration {
+	b.ID = &value
+	return b
+}
+
+// WithSerialNumber sets the SerialNumber field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SerialNumber field is set to the value of the last call.
+func (b *PendingCertificateApplyConfiguration) WithSerialNumber(value string) *PendingCertificateApplyConfiguration {
+	b.SerialNumber = &value
+	return b
+}
@@ -0,0 +1,72 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// RecordSourceApplyConfiguration represents a declarative configuration of the RecordSource type for use
+// with apply.
+type RecordSourceApplyConfiguration struct {
+	APIGroup    *string    `json:"apiGroup,omitempty"`
+	Kind        *string    `json:"kind,omitempty"`
+	Namespace   *string    `json:"namespace,omitempty"`
+	Name        *string    `json:"name,omitempty"`
+	UID         *types.UID `json:"uid,omitempty"`
+	Certificate *string    `json:"certificate,omitempty"`
+}
+
+// RecordSourceApplyConfiguration constructs a declarative configuration of the RecordSource type for use with
+// apply.
+func RecordSource() *RecordSourceApplyConfiguration {
+	return &RecordSourceApplyConfiguration{}
+}
+
+// WithAPIGroup sets the APIGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIGroup field is set to the value of the last call.
+func (b *RecordSourceApplyConfiguration) WithAPIGroup(value string) *RecordSourceApplyConfiguration {
+	b.APIGroup = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *RecordSourceApplyConfiguration) WithKind(value string) *RecordSourceApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *RecordSourceApplyConfiguration) WithNamespace(value string) *RecordSourceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *RecordSourceApplyConfiguration) WithName(value string) *RecordSourceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *RecordSourceApplyConfiguration) WithUID(value types.UID) *RecordSourceApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithCertificate sets the Certificate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Certificate field is set to the value of the last call.
+func (b *RecordSourceApplyConfiguration) WithCertificate(value string) *RecordSourceApplyConfiguration {
+	b.Certificate = &value
+	return b
+}
@@ -0,0 +1,92 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	internal "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/internal"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	testing "k8s.io/client-go/testing"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=cert-manager.k8s.cloudflare.com, Version=v1
+	case v1.SchemeGroupVersion.WithKind("CABundleSource"):
+		return &apisv1.CABundleSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterOriginIssuer"):
+		return &apisv1.ClusterOriginIssuerApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConfigMapKeySelector"):
+		return &apisv1.ConfigMapKeySelectorApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("InventoryCertificate"):
+		return &apisv1.InventoryCertificateApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("IssuanceLimits"):
+		return &apisv1.IssuanceLimitsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("IssuanceUsage"):
+		return &apisv1.IssuanceUsageApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("IssuerReference"):
+		return &apisv1.IssuerReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NamespaceIssuanceUsage"):
+		return &apisv1.NamespaceIssuanceUsageApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginCertificate"):
+		return &apisv1.OriginCertificateApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginCertificateCondition"):
+		return &apisv1.OriginCertificateConditionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginCertificateRecord"):
+		return &apisv1.OriginCertificateRecordApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginCertificateRecordSpec"):
+		return &apisv1.OriginCertificateRecordSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginCertificateRecordStatus"):
+		return &apisv1.OriginCertificateRecordStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginCertificateSpec"):
+		return &apisv1.OriginCertificateSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginCertificateStatus"):
+		return &apisv1.OriginCertificateStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginInventory"):
+		return &apisv1.OriginInventoryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginInventoryCondition"):
+		return &apisv1.OriginInventoryConditionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginInventorySpec"):
+		return &apisv1.OriginInventorySpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginInventoryStatus"):
+		return &apisv1.OriginInventoryStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuer"):
+		return &apisv1.OriginIssuerApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerAPI"):
+		return &apisv1.OriginIssuerAPIApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerAuthentication"):
+		return &apisv1.OriginIssuerAuthenticationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerCondition"):
+		return &apisv1.OriginIssuerConditionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerCredential"):
+		return &apisv1.OriginIssuerCredentialApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerCredentialStatus"):
+		return &apisv1.OriginIssuerCredentialStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerPolicy"):
+		return &apisv1.OriginIssuerPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerQuota"):
+		return &apisv1.OriginIssuerQuotaApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerQuotaStatus"):
+		return &apisv1.OriginIssuerQuotaStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerSpec"):
+		return &apisv1.OriginIssuerSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OriginIssuerStatus"):
+		return &apisv1.OriginIssuerStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PendingCertificate"):
+		return &apisv1.PendingCertificateApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RecordSource"):
+		return &apisv1.RecordSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SecretKeySelector"):
+		return &apisv1.SecretKeySelectorApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) *testing.TypeConverter {
+	return &testing.TypeConverter{Scheme: scheme, TypeResolver: internal.Parser()}
+}
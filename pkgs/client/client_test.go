@@ -0,0 +1,65 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	applyv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/fake"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/client/informers/externalversions"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestClientset(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientset := fake.NewSimpleClientset()
+
+	_, err := clientset.CloudflareV1().OriginIssuers("default").Create(ctx, &v1.OriginIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+		Spec:       v1.OriginIssuerSpec{RequestType: v1.RequestTypeOriginECC},
+	}, metav1.CreateOptions{})
+	assert.NilError(t, err)
+
+	_, err = clientset.CloudflareV1().ClusterOriginIssuers().Create(ctx, &v1.ClusterOriginIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "foobar"},
+		Spec:       v1.OriginIssuerSpec{RequestType: v1.RequestTypeOriginRSA},
+	}, metav1.CreateOptions{})
+	assert.NilError(t, err)
+
+	factory := externalversions.NewSharedInformerFactory(clientset, time.Hour)
+	issuers := factory.Cloudflare().V1().OriginIssuers().Lister()
+	clusterIssuers := factory.Cloudflare().V1().ClusterOriginIssuers().Lister()
+
+	factory.Start(ctx.Done())
+	for typ, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		assert.Assert(t, synced, "informer for %v did not sync", typ)
+	}
+
+	iss, err := issuers.OriginIssuers("default").Get("foobar")
+	assert.NilError(t, err)
+	assert.Equal(t, iss.Spec.RequestType, v1.RequestTypeOriginECC)
+
+	all, err := clusterIssuers.List(labels.Everything())
+	assert.NilError(t, err)
+	assert.Equal(t, len(all), 1)
+	assert.Equal(t, all[0].Spec.RequestType, v1.RequestTypeOriginRSA)
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	iss := applyv1.OriginIssuer("foobar", "default").
+		WithSpec(applyv1.OriginIssuerSpec().
+			WithRequestType(v1.RequestTypeOriginECC).
+			WithAuth(applyv1.OriginIssuerAuthentication().
+				WithTokenRef(applyv1.SecretKeySelector().WithName("issuer-api-token").WithKey("token"))))
+
+	got, err := json.Marshal(iss)
+	assert.NilError(t, err)
+	assert.Equal(t, string(got), `{"kind":"OriginIssuer","apiVersion":"cert-manager.k8s.cloudflare.com/v1","metadata":{"name":"foobar","namespace":"default"},"spec":{"requestType":"OriginECC","auth":{"tokenRef":{"name":"issuer-api-token","key":"token"}}}}`)
+}
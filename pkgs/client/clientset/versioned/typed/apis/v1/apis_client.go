@@ -0,0 +1,111 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"net/http"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type CloudflareV1Interface interface {
+	RESTClient() rest.Interface
+	ClusterOriginIssuersGetter
+	OriginCertificatesGetter
+	OriginCertificateRecordsGetter
+	OriginInventoriesGetter
+	OriginIssuersGetter
+}
+
+// CloudflareV1Client is used to interact with features provided by the cert-manager.k8s.cloudflare.com group.
+type CloudflareV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *CloudflareV1Client) ClusterOriginIssuers() ClusterOriginIssuerInterface {
+	return newClusterOriginIssuers(c)
+}
+
+func (c *CloudflareV1Client) OriginCertificates(namespace string) OriginCertificateInterface {
+	return newOriginCertificates(c, namespace)
+}
+
+func (c *CloudflareV1Client) OriginCertificateRecords() OriginCertificateRecordInterface {
+	return newOriginCertificateRecords(c)
+}
+
+func (c *CloudflareV1Client) OriginInventories(namespace string) OriginInventoryInterface {
+	return newOriginInventories(c, namespace)
+}
+
+func (c *CloudflareV1Client) OriginIssuers(namespace string) OriginIssuerInterface {
+	return newOriginIssuers(c, namespace)
+}
+
+// NewForConfig creates a new CloudflareV1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*CloudflareV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new CloudflareV1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*CloudflareV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudflareV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new CloudflareV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *CloudflareV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new CloudflareV1Client for the given RESTClient.
+func New(c rest.Interface) *CloudflareV1Client {
+	return &CloudflareV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *CloudflareV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
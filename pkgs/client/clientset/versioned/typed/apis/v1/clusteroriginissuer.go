@@ -0,0 +1,57 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	scheme "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterOriginIssuersGetter has a method to return a ClusterOriginIssuerInterface.
+// A group's client should implement this interface.
+type ClusterOriginIssuersGetter interface {
+	ClusterOriginIssuers() ClusterOriginIssuerInterface
+}
+
+// ClusterOriginIssuerInterface has methods to work with ClusterOriginIssuer resources.
+type ClusterOriginIssuerInterface interface {
+	Create(ctx context.Context, clusterOriginIssuer *v1.ClusterOriginIssuer, opts metav1.CreateOptions) (*v1.ClusterOriginIssuer, error)
+	Update(ctx context.Context, clusterOriginIssuer *v1.ClusterOriginIssuer, opts metav1.UpdateOptions) (*v1.ClusterOriginIssuer, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterOriginIssuer *v1.ClusterOriginIssuer, opts metav1.UpdateOptions) (*v1.ClusterOriginIssuer, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ClusterOriginIssuer, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ClusterOriginIssuerList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterOriginIssuer, err error)
+	Apply(ctx context.Context, clusterOriginIssuer *apisv1.ClusterOriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ClusterOriginIssuer, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, clusterOriginIssuer *apisv1.ClusterOriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ClusterOriginIssuer, err error)
+	ClusterOriginIssuerExpansion
+}
+
+// clusterOriginIssuers implements ClusterOriginIssuerInterface
+type clusterOriginIssuers struct {
+	*gentype.ClientWithListAndApply[*v1.ClusterOriginIssuer, *v1.ClusterOriginIssuerList, *apisv1.ClusterOriginIssuerApplyConfiguration]
+}
+
+// newClusterOriginIssuers returns a ClusterOriginIssuers
+func newClusterOriginIssuers(c *CloudflareV1Client) *clusterOriginIssuers {
+	return &clusterOriginIssuers{
+		gentype.NewClientWithListAndApply[*v1.ClusterOriginIssuer, *v1.ClusterOriginIssuerList, *apisv1.ClusterOriginIssuerApplyConfiguration](
+			"clusteroriginissuers",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *v1.ClusterOriginIssuer { return &v1.ClusterOriginIssuer{} },
+			func() *v1.ClusterOriginIssuerList { return &v1.ClusterOriginIssuerList{} }),
+	}
+}
@@ -0,0 +1,40 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/typed/apis/v1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeCloudflareV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeCloudflareV1) ClusterOriginIssuers() v1.ClusterOriginIssuerInterface {
+	return &FakeClusterOriginIssuers{c}
+}
+
+func (c *FakeCloudflareV1) OriginCertificates(namespace string) v1.OriginCertificateInterface {
+	return &FakeOriginCertificates{c, namespace}
+}
+
+func (c *FakeCloudflareV1) OriginCertificateRecords() v1.OriginCertificateRecordInterface {
+	return &FakeOriginCertificateRecords{c}
+}
+
+func (c *FakeCloudflareV1) OriginInventories(namespace string) v1.OriginInventoryInterface {
+	return &FakeOriginInventories{c, namespace}
+}
+
+func (c *FakeCloudflareV1) OriginIssuers(namespace string) v1.OriginIssuerInterface {
+	return &FakeOriginIssuers{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeCloudflareV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
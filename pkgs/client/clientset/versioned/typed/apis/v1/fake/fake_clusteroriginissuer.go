@@ -0,0 +1,170 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterOriginIssuers implements ClusterOriginIssuerInterface
+type FakeClusterOriginIssuers struct {
+	Fake *FakeCloudflareV1
+}
+
+var clusteroriginissuersResource = v1.SchemeGroupVersion.WithResource("clusteroriginissuers")
+
+var clusteroriginissuersKind = v1.SchemeGroupVersion.WithKind("ClusterOriginIssuer")
+
+// Get takes name of the clusterOriginIssuer, and returns the corresponding clusterOriginIssuer object, and an error if there is any.
+func (c *FakeClusterOriginIssuers) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ClusterOriginIssuer, err error) {
+	emptyResult := &v1.ClusterOriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetActionWithOptions(clusteroriginissuersResource, name, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ClusterOriginIssuer), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterOriginIssuers that match those selectors.
+func (c *FakeClusterOriginIssuers) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ClusterOriginIssuerList, err error) {
+	emptyResult := &v1.ClusterOriginIssuerList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListActionWithOptions(clusteroriginissuersResource, clusteroriginissuersKind, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.ClusterOriginIssuerList{ListMeta: obj.(*v1.ClusterOriginIssuerList).ListMeta}
+	for _, item := range obj.(*v1.ClusterOriginIssuerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterOriginIssuers.
+func (c *FakeClusterOriginIssuers) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchActionWithOptions(clusteroriginissuersResource, opts))
+}
+
+// Create takes the representation of a clusterOriginIssuer and creates it.  Returns the server's representation of the clusterOriginIssuer, and an error, if there is any.
+func (c *FakeClusterOriginIssuers) Create(ctx context.Context, clusterOriginIssuer *v1.ClusterOriginIssuer, opts metav1.CreateOptions) (result *v1.ClusterOriginIssuer, err error) {
+	emptyResult := &v1.ClusterOriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateActionWithOptions(clusteroriginissuersResource, clusterOriginIssuer, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ClusterOriginIssuer), err
+}
+
+// Update takes the representation of a clusterOriginIssuer and updates it. Returns the server's representation of the clusterOriginIssuer, and an error, if there is any.
+func (c *FakeClusterOriginIssuers) Update(ctx context.Context, clusterOriginIssuer *v1.ClusterOriginIssuer, opts metav1.UpdateOptions) (result *v1.ClusterOriginIssuer, err error) {
+	emptyResult := &v1.ClusterOriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateActionWithOptions(clusteroriginissuersResource, clusterOriginIssuer, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ClusterOriginIssuer), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterOriginIssuers) UpdateStatus(ctx context.Context, clusterOriginIssuer *v1.ClusterOriginIssuer, opts metav1.UpdateOptions) (result *v1.ClusterOriginIssuer, err error) {
+	emptyResult := &v1.ClusterOriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceActionWithOptions(clusteroriginissuersResource, "status", clusterOriginIssuer, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ClusterOriginIssuer), err
+}
+
+// Delete takes name of the clusterOriginIssuer and deletes it. Returns an error if one occurs.
+func (c *FakeClusterOriginIssuers) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusteroriginissuersResource, name, opts), &v1.ClusterOriginIssuer{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterOriginIssuers) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionActionWithOptions(clusteroriginissuersResource, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.ClusterOriginIssuerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterOriginIssuer.
+func (c *FakeClusterOriginIssuers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterOriginIssuer, err error) {
+	emptyResult := &v1.ClusterOriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(clusteroriginissuersResource, name, pt, data, opts, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ClusterOriginIssuer), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterOriginIssuer.
+func (c *FakeClusterOriginIssuers) Apply(ctx context.Context, clusterOriginIssuer *apisv1.ClusterOriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ClusterOriginIssuer, err error) {
+	if clusterOriginIssuer == nil {
+		return nil, fmt.Errorf("clusterOriginIssuer provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterOriginIssuer)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterOriginIssuer.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterOriginIssuer.Name must be provided to Apply")
+	}
+	emptyResult := &v1.ClusterOriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(clusteroriginissuersResource, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ClusterOriginIssuer), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeClusterOriginIssuers) ApplyStatus(ctx context.Context, clusterOriginIssuer *apisv1.ClusterOriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.ClusterOriginIssuer, err error) {
+	if clusterOriginIssuer == nil {
+		return nil, fmt.Errorf("clusterOriginIssuer provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterOriginIssuer)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterOriginIssuer.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterOriginIssuer.Name must be provided to Apply")
+	}
+	emptyResult := &v1.ClusterOriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(clusteroriginissuersResource, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ClusterOriginIssuer), err
+}
@@ -0,0 +1,181 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOriginCertificates implements OriginCertificateInterface
+type FakeOriginCertificates struct {
+	Fake *FakeCloudflareV1
+	ns   string
+}
+
+var origincertificatesResource = v1.SchemeGroupVersion.WithResource("origincertificates")
+
+var origincertificatesKind = v1.SchemeGroupVersion.WithKind("OriginCertificate")
+
+// Get takes name of the originCertificate, and returns the corresponding originCertificate object, and an error if there is any.
+func (c *FakeOriginCertificates) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.OriginCertificate, err error) {
+	emptyResult := &v1.OriginCertificate{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(origincertificatesResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificate), err
+}
+
+// List takes label and field selectors, and returns the list of OriginCertificates that match those selectors.
+func (c *FakeOriginCertificates) List(ctx context.Context, opts metav1.ListOptions) (result *v1.OriginCertificateList, err error) {
+	emptyResult := &v1.OriginCertificateList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(origincertificatesResource, origincertificatesKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.OriginCertificateList{ListMeta: obj.(*v1.OriginCertificateList).ListMeta}
+	for _, item := range obj.(*v1.OriginCertificateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested originCertificates.
+func (c *FakeOriginCertificates) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(origincertificatesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a originCertificate and creates it.  Returns the server's representation of the originCertificate, and an error, if there is any.
+func (c *FakeOriginCertificates) Create(ctx context.Context, originCertificate *v1.OriginCertificate, opts metav1.CreateOptions) (result *v1.OriginCertificate, err error) {
+	emptyResult := &v1.OriginCertificate{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(origincertificatesResource, c.ns, originCertificate, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificate), err
+}
+
+// Update takes the representation of a originCertificate and updates it. Returns the server's representation of the originCertificate, and an error, if there is any.
+func (c *FakeOriginCertificates) Update(ctx context.Context, originCertificate *v1.OriginCertificate, opts metav1.UpdateOptions) (result *v1.OriginCertificate, err error) {
+	emptyResult := &v1.OriginCertificate{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(origincertificatesResource, c.ns, originCertificate, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificate), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOriginCertificates) UpdateStatus(ctx context.Context, originCertificate *v1.OriginCertificate, opts metav1.UpdateOptions) (result *v1.OriginCertificate, err error) {
+	emptyResult := &v1.OriginCertificate{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(origincertificatesResource, "status", c.ns, originCertificate, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificate), err
+}
+
+// Delete takes name of the originCertificate and deletes it. Returns an error if one occurs.
+func (c *FakeOriginCertificates) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(origincertificatesResource, c.ns, name, opts), &v1.OriginCertificate{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOriginCertificates) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(origincertificatesResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.OriginCertificateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched originCertificate.
+func (c *FakeOriginCertificates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginCertificate, err error) {
+	emptyResult := &v1.OriginCertificate{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(origincertificatesResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificate), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied originCertificate.
+func (c *FakeOriginCertificates) Apply(ctx context.Context, originCertificate *apisv1.OriginCertificateApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificate, err error) {
+	if originCertificate == nil {
+		return nil, fmt.Errorf("originCertificate provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originCertificate)
+	if err != nil {
+		return nil, err
+	}
+	name := originCertificate.Name
+	if name == nil {
+		return nil, fmt.Errorf("originCertificate.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginCertificate{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(origincertificatesResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificate), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeOriginCertificates) ApplyStatus(ctx context.Context, originCertificate *apisv1.OriginCertificateApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificate, err error) {
+	if originCertificate == nil {
+		return nil, fmt.Errorf("originCertificate provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originCertificate)
+	if err != nil {
+		return nil, err
+	}
+	name := originCertificate.Name
+	if name == nil {
+		return nil, fmt.Errorf("originCertificate.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginCertificate{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(origincertificatesResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificate), err
+}
@@ -0,0 +1,170 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOriginCertificateRecords implements OriginCertificateRecordInterface
+type FakeOriginCertificateRecords struct {
+	Fake *FakeCloudflareV1
+}
+
+var origincertificaterecordsResource = v1.SchemeGroupVersion.WithResource("origincertificaterecords")
+
+var origincertificaterecordsKind = v1.SchemeGroupVersion.WithKind("OriginCertificateRecord")
+
+// Get takes name of the originCertificateRecord, and returns the corresponding originCertificateRecord object, and an error if there is any.
+func (c *FakeOriginCertificateRecords) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.OriginCertificateRecord, err error) {
+	emptyResult := &v1.OriginCertificateRecord{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetActionWithOptions(origincertificaterecordsResource, name, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificateRecord), err
+}
+
+// List takes label and field selectors, and returns the list of OriginCertificateRecords that match those selectors.
+func (c *FakeOriginCertificateRecords) List(ctx context.Context, opts metav1.ListOptions) (result *v1.OriginCertificateRecordList, err error) {
+	emptyResult := &v1.OriginCertificateRecordList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListActionWithOptions(origincertificaterecordsResource, origincertificaterecordsKind, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.OriginCertificateRecordList{ListMeta: obj.(*v1.OriginCertificateRecordList).ListMeta}
+	for _, item := range obj.(*v1.OriginCertificateRecordList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested originCertificateRecords.
+func (c *FakeOriginCertificateRecords) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchActionWithOptions(origincertificaterecordsResource, opts))
+}
+
+// Create takes the representation of a originCertificateRecord and creates it.  Returns the server's representation of the originCertificateRecord, and an error, if there is any.
+func (c *FakeOriginCertificateRecords) Create(ctx context.Context, originCertificateRecord *v1.OriginCertificateRecord, opts metav1.CreateOptions) (result *v1.OriginCertificateRecord, err error) {
+	emptyResult := &v1.OriginCertificateRecord{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateActionWithOptions(origincertificaterecordsResource, originCertificateRecord, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificateRecord), err
+}
+
+// Update takes the representation of a originCertificateRecord and updates it. Returns the server's representation of the originCertificateRecord, and an error, if there is any.
+func (c *FakeOriginCertificateRecords) Update(ctx context.Context, originCertificateRecord *v1.OriginCertificateRecord, opts metav1.UpdateOptions) (result *v1.OriginCertificateRecord, err error) {
+	emptyResult := &v1.OriginCertificateRecord{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateActionWithOptions(origincertificaterecordsResource, originCertificateRecord, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificateRecord), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOriginCertificateRecords) UpdateStatus(ctx context.Context, originCertificateRecord *v1.OriginCertificateRecord, opts metav1.UpdateOptions) (result *v1.OriginCertificateRecord, err error) {
+	emptyResult := &v1.OriginCertificateRecord{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceActionWithOptions(origincertificaterecordsResource, "status", originCertificateRecord, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificateRecord), err
+}
+
+// Delete takes name of the originCertificateRecord and deletes it. Returns an error if one occurs.
+func (c *FakeOriginCertificateRecords) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(origincertificaterecordsResource, name, opts), &v1.OriginCertificateRecord{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOriginCertificateRecords) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionActionWithOptions(origincertificaterecordsResource, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.OriginCertificateRecordList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched originCertificateRecord.
+func (c *FakeOriginCertificateRecords) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginCertificateRecord, err error) {
+	emptyResult := &v1.OriginCertificateRecord{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(origincertificaterecordsResource, name, pt, data, opts, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificateRecord), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied originCertificateRecord.
+func (c *FakeOriginCertificateRecords) Apply(ctx context.Context, originCertificateRecord *apisv1.OriginCertificateRecordApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificateRecord, err error) {
+	if originCertificateRecord == nil {
+		return nil, fmt.Errorf("originCertificateRecord provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originCertificateRecord)
+	if err != nil {
+		return nil, err
+	}
+	name := originCertificateRecord.Name
+	if name == nil {
+		return nil, fmt.Errorf("originCertificateRecord.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginCertificateRecord{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(origincertificaterecordsResource, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificateRecord), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeOriginCertificateRecords) ApplyStatus(ctx context.Context, originCertificateRecord *apisv1.OriginCertificateRecordApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificateRecord, err error) {
+	if originCertificateRecord == nil {
+		return nil, fmt.Errorf("originCertificateRecord provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originCertificateRecord)
+	if err != nil {
+		return nil, err
+	}
+	name := originCertificateRecord.Name
+	if name == nil {
+		return nil, fmt.Errorf("originCertificateRecord.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginCertificateRecord{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(origincertificaterecordsResource, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginCertificateRecord), err
+}
@@ -0,0 +1,181 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOriginInventories implements OriginInventoryInterface
+type FakeOriginInventories struct {
+	Fake *FakeCloudflareV1
+	ns   string
+}
+
+var origininventoriesResource = v1.SchemeGroupVersion.WithResource("origininventories")
+
+var origininventoriesKind = v1.SchemeGroupVersion.WithKind("OriginInventory")
+
+// Get takes name of the originInventory, and returns the corresponding originInventory object, and an error if there is any.
+func (c *FakeOriginInventories) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.OriginInventory, err error) {
+	emptyResult := &v1.OriginInventory{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(origininventoriesResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginInventory), err
+}
+
+// List takes label and field selectors, and returns the list of OriginInventories that match those selectors.
+func (c *FakeOriginInventories) List(ctx context.Context, opts metav1.ListOptions) (result *v1.OriginInventoryList, err error) {
+	emptyResult := &v1.OriginInventoryList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(origininventoriesResource, origininventoriesKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.OriginInventoryList{ListMeta: obj.(*v1.OriginInventoryList).ListMeta}
+	for _, item := range obj.(*v1.OriginInventoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested originInventories.
+func (c *FakeOriginInventories) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(origininventoriesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a originInventory and creates it.  Returns the server's representation of the originInventory, and an error, if there is any.
+func (c *FakeOriginInventories) Create(ctx context.Context, originInventory *v1.OriginInventory, opts metav1.CreateOptions) (result *v1.OriginInventory, err error) {
+	emptyResult := &v1.OriginInventory{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(origininventoriesResource, c.ns, originInventory, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginInventory), err
+}
+
+// Update takes the representation of a originInventory and updates it. Returns the server's representation of the originInventory, and an error, if there is any.
+func (c *FakeOriginInventories) Update(ctx context.Context, originInventory *v1.OriginInventory, opts metav1.UpdateOptions) (result *v1.OriginInventory, err error) {
+	emptyResult := &v1.OriginInventory{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(origininventoriesResource, c.ns, originInventory, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginInventory), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOriginInventories) UpdateStatus(ctx context.Context, originInventory *v1.OriginInventory, opts metav1.UpdateOptions) (result *v1.OriginInventory, err error) {
+	emptyResult := &v1.OriginInventory{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(origininventoriesResource, "status", c.ns, originInventory, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginInventory), err
+}
+
+// Delete takes name of the originInventory and deletes it. Returns an error if one occurs.
+func (c *FakeOriginInventories) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(origininventoriesResource, c.ns, name, opts), &v1.OriginInventory{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOriginInventories) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(origininventoriesResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.OriginInventoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched originInventory.
+func (c *FakeOriginInventories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginInventory, err error) {
+	emptyResult := &v1.OriginInventory{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(origininventoriesResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginInventory), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied originInventory.
+func (c *FakeOriginInventories) Apply(ctx context.Context, originInventory *apisv1.OriginInventoryApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginInventory, err error) {
+	if originInventory == nil {
+		return nil, fmt.Errorf("originInventory provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originInventory)
+	if err != nil {
+		return nil, err
+	}
+	name := originInventory.Name
+	if name == nil {
+		return nil, fmt.Errorf("originInventory.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginInventory{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(origininventoriesResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginInventory), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeOriginInventories) ApplyStatus(ctx context.Context, originInventory *apisv1.OriginInventoryApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginInventory, err error) {
+	if originInventory == nil {
+		return nil, fmt.Errorf("originInventory provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originInventory)
+	if err != nil {
+		return nil, err
+	}
+	name := originInventory.Name
+	if name == nil {
+		return nil, fmt.Errorf("originInventory.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginInventory{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(origininventoriesResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginInventory), err
+}
@@ -0,0 +1,181 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOriginIssuers implements OriginIssuerInterface
+type FakeOriginIssuers struct {
+	Fake *FakeCloudflareV1
+	ns   string
+}
+
+var originissuersResource = v1.SchemeGroupVersion.WithResource("originissuers")
+
+var originissuersKind = v1.SchemeGroupVersion.WithKind("OriginIssuer")
+
+// Get takes name of the originIssuer, and returns the corresponding originIssuer object, and an error if there is any.
+func (c *FakeOriginIssuers) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.OriginIssuer, err error) {
+	emptyResult := &v1.OriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(originissuersResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginIssuer), err
+}
+
+// List takes label and field selectors, and returns the list of OriginIssuers that match those selectors.
+func (c *FakeOriginIssuers) List(ctx context.Context, opts metav1.ListOptions) (result *v1.OriginIssuerList, err error) {
+	emptyResult := &v1.OriginIssuerList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(originissuersResource, originissuersKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.OriginIssuerList{ListMeta: obj.(*v1.OriginIssuerList).ListMeta}
+	for _, item := range obj.(*v1.OriginIssuerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested originIssuers.
+func (c *FakeOriginIssuers) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(originissuersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a originIssuer and creates it.  Returns the server's representation of the originIssuer, and an error, if there is any.
+func (c *FakeOriginIssuers) Create(ctx context.Context, originIssuer *v1.OriginIssuer, opts metav1.CreateOptions) (result *v1.OriginIssuer, err error) {
+	emptyResult := &v1.OriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(originissuersResource, c.ns, originIssuer, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginIssuer), err
+}
+
+// Update takes the representation of a originIssuer and updates it. Returns the server's representation of the originIssuer, and an error, if there is any.
+func (c *FakeOriginIssuers) Update(ctx context.Context, originIssuer *v1.OriginIssuer, opts metav1.UpdateOptions) (result *v1.OriginIssuer, err error) {
+	emptyResult := &v1.OriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(originissuersResource, c.ns, originIssuer, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginIssuer), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOriginIssuers) UpdateStatus(ctx context.Context, originIssuer *v1.OriginIssuer, opts metav1.UpdateOptions) (result *v1.OriginIssuer, err error) {
+	emptyResult := &v1.OriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(originissuersResource, "status", c.ns, originIssuer, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginIssuer), err
+}
+
+// Delete takes name of the originIssuer and deletes it. Returns an error if one occurs.
+func (c *FakeOriginIssuers) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(originissuersResource, c.ns, name, opts), &v1.OriginIssuer{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOriginIssuers) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(originissuersResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.OriginIssuerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched originIssuer.
+func (c *FakeOriginIssuers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginIssuer, err error) {
+	emptyResult := &v1.OriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(originissuersResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginIssuer), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied originIssuer.
+func (c *FakeOriginIssuers) Apply(ctx context.Context, originIssuer *apisv1.OriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginIssuer, err error) {
+	if originIssuer == nil {
+		return nil, fmt.Errorf("originIssuer provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originIssuer)
+	if err != nil {
+		return nil, err
+	}
+	name := originIssuer.Name
+	if name == nil {
+		return nil, fmt.Errorf("originIssuer.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(originissuersResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginIssuer), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeOriginIssuers) ApplyStatus(ctx context.Context, originIssuer *apisv1.OriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginIssuer, err error) {
+	if originIssuer == nil {
+		return nil, fmt.Errorf("originIssuer provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(originIssuer)
+	if err != nil {
+		return nil, err
+	}
+	name := originIssuer.Name
+	if name == nil {
+		return nil, fmt.Errorf("originIssuer.Name must be provided to Apply")
+	}
+	emptyResult := &v1.OriginIssuer{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(originissuersResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.OriginIssuer), err
+}
@@ -0,0 +1,13 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+type ClusterOriginIssuerExpansion interface{}
+
+type OriginCertificateExpansion interface{}
+
+type OriginCertificateRecordExpansion interface{}
+
+type OriginInventoryExpansion interface{}
+
+type OriginIssuerExpansion interface{}
@@ -0,0 +1,57 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	scheme "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// OriginCertificatesGetter has a method to return a OriginCertificateInterface.
+// A group's client should implement this interface.
+type OriginCertificatesGetter interface {
+	OriginCertificates(namespace string) OriginCertificateInterface
+}
+
+// OriginCertificateInterface has methods to work with OriginCertificate resources.
+type OriginCertificateInterface interface {
+	Create(ctx context.Context, originCertificate *v1.OriginCertificate, opts metav1.CreateOptions) (*v1.OriginCertificate, error)
+	Update(ctx context.Context, originCertificate *v1.OriginCertificate, opts metav1.UpdateOptions) (*v1.OriginCertificate, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, originCertificate *v1.OriginCertificate, opts metav1.UpdateOptions) (*v1.OriginCertificate, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.OriginCertificate, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.OriginCertificateList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginCertificate, err error)
+	Apply(ctx context.Context, originCertificate *apisv1.OriginCertificateApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificate, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, originCertificate *apisv1.OriginCertificateApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificate, err error)
+	OriginCertificateExpansion
+}
+
+// originCertificates implements OriginCertificateInterface
+type originCertificates struct {
+	*gentype.ClientWithListAndApply[*v1.OriginCertificate, *v1.OriginCertificateList, *apisv1.OriginCertificateApplyConfiguration]
+}
+
+// newOriginCertificates returns a OriginCertificates
+func newOriginCertificates(c *CloudflareV1Client, namespace string) *originCertificates {
+	return &originCertificates{
+		gentype.NewClientWithListAndApply[*v1.OriginCertificate, *v1.OriginCertificateList, *apisv1.OriginCertificateApplyConfiguration](
+			"origincertificates",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *v1.OriginCertificate { return &v1.OriginCertificate{} },
+			func() *v1.OriginCertificateList { return &v1.OriginCertificateList{} }),
+	}
+}
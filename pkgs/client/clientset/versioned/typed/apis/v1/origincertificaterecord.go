@@ -0,0 +1,57 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	scheme "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// OriginCertificateRecordsGetter has a method to return a OriginCertificateRecordInterface.
+// A group's client should implement this interface.
+type OriginCertificateRecordsGetter interface {
+	OriginCertificateRecords() OriginCertificateRecordInterface
+}
+
+// OriginCertificateRecordInterface has methods to work with OriginCertificateRecord resources.
+type OriginCertificateRecordInterface interface {
+	Create(ctx context.Context, originCertificateRecord *v1.OriginCertificateRecord, opts metav1.CreateOptions) (*v1.OriginCertificateRecord, error)
+	Update(ctx context.Context, originCertificateRecord *v1.OriginCertificateRecord, opts metav1.UpdateOptions) (*v1.OriginCertificateRecord, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, originCertificateRecord *v1.OriginCertificateRecord, opts metav1.UpdateOptions) (*v1.OriginCertificateRecord, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.OriginCertificateRecord, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.OriginCertificateRecordList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginCertificateRecord, err error)
+	Apply(ctx context.Context, originCertificateRecord *apisv1.OriginCertificateRecordApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificateRecord, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, originCertificateRecord *apisv1.OriginCertificateRecordApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginCertificateRecord, err error)
+	OriginCertificateRecordExpansion
+}
+
+// originCertificateRecords implements OriginCertificateRecordInterface
+type originCertificateRecords struct {
+	*gentype.ClientWithListAndApply[*v1.OriginCertificateRecord, *v1.OriginCertificateRecordList, *apisv1.OriginCertificateRecordApplyConfiguration]
+}
+
+// newOriginCertificateRecords returns a OriginCertificateRecords
+func newOriginCertificateRecords(c *CloudflareV1Client) *originCertificateRecords {
+	return &originCertificateRecords{
+		gentype.NewClientWithListAndApply[*v1.OriginCertificateRecord, *v1.OriginCertificateRecordList, *apisv1.OriginCertificateRecordApplyConfiguration](
+			"origincertificaterecords",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *v1.OriginCertificateRecord { return &v1.OriginCertificateRecord{} },
+			func() *v1.OriginCertificateRecordList { return &v1.OriginCertificateRecordList{} }),
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	scheme "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// OriginInventoriesGetter has a method to return a OriginInventoryInterface.
+// A group's client should implement this interface.
+type OriginInventoriesGetter interface {
+	OriginInventories(namespace string) OriginInventoryInterface
+}
+
+// OriginInventoryInterface has methods to work with OriginInventory resources.
+type OriginInventoryInterface interface {
+	Create(ctx context.Context, originInventory *v1.OriginInventory, opts metav1.CreateOptions) (*v1.OriginInventory, error)
+	Update(ctx context.Context, originInventory *v1.OriginInventory, opts metav1.UpdateOptions) (*v1.OriginInventory, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, originInventory *v1.OriginInventory, opts metav1.UpdateOptions) (*v1.OriginInventory, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.OriginInventory, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.OriginInventoryList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginInventory, err error)
+	Apply(ctx context.Context, originInventory *apisv1.OriginInventoryApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginInventory, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, originInventory *apisv1.OriginInventoryApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginInventory, err error)
+	OriginInventoryExpansion
+}
+
+// originInventories implements OriginInventoryInterface
+type originInventories struct {
+	*gentype.ClientWithListAndApply[*v1.OriginInventory, *v1.OriginInventoryList, *apisv1.OriginInventoryApplyConfiguration]
+}
+
+// newOriginInventories returns a OriginInventories
+func newOriginInventories(c *CloudflareV1Client, namespace string) *originInventories {
+	return &originInventories{
+		gentype.NewClientWithListAndApply[*v1.OriginInventory, *v1.OriginInventoryList, *apisv1.OriginInventoryApplyConfiguration](
+			"origininventories",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *v1.OriginInventory { return &v1.OriginInventory{} },
+			func() *v1.OriginInventoryList { return &v1.OriginInventoryList{} }),
+	}
+}
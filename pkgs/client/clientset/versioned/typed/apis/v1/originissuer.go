@@ -0,0 +1,57 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration/apis/v1"
+	scheme "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// OriginIssuersGetter has a method to return a OriginIssuerInterface.
+// A group's client should implement this interface.
+type OriginIssuersGetter interface {
+	OriginIssuers(namespace string) OriginIssuerInterface
+}
+
+// OriginIssuerInterface has methods to work with OriginIssuer resources.
+type OriginIssuerInterface interface {
+	Create(ctx context.Context, originIssuer *v1.OriginIssuer, opts metav1.CreateOptions) (*v1.OriginIssuer, error)
+	Update(ctx context.Context, originIssuer *v1.OriginIssuer, opts metav1.UpdateOptions) (*v1.OriginIssuer, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, originIssuer *v1.OriginIssuer, opts metav1.UpdateOptions) (*v1.OriginIssuer, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.OriginIssuer, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.OriginIssuerList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OriginIssuer, err error)
+	Apply(ctx context.Context, originIssuer *apisv1.OriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginIssuer, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, originIssuer *apisv1.OriginIssuerApplyConfiguration, opts metav1.ApplyOptions) (result *v1.OriginIssuer, err error)
+	OriginIssuerExpansion
+}
+
+// originIssuers implements OriginIssuerInterface
+type originIssuers struct {
+	*gentype.ClientWithListAndApply[*v1.OriginIssuer, *v1.OriginIssuerList, *apisv1.OriginIssuerApplyConfiguration]
+}
+
+// newOriginIssuers returns a OriginIssuers
+func newOriginIssuers(c *CloudflareV1Client, namespace string) *originIssuers {
+	return &originIssuers{
+		gentype.NewClientWithListAndApply[*v1.OriginIssuer, *v1.OriginIssuerList, *apisv1.OriginIssuerApplyConfiguration](
+			"originissuers",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *v1.OriginIssuer { return &v1.OriginIssuer{} },
+			func() *v1.OriginIssuerList { return &v1.OriginIssuerList{} }),
+	}
+}
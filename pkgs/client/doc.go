@@ -0,0 +1,9 @@
+// Package client contains a typed clientset, shared informers, listers and apply
+// configurations for the OriginIssuer API. Its subpackages are generated from
+// the types in pkgs/apis/v1; do not edit them by hand.
+package client
+
+//go:generate go run k8s.io/code-generator/cmd/applyconfiguration-gen --go-header-file /dev/null --output-dir ./applyconfiguration --output-pkg github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1
+//go:generate go run k8s.io/code-generator/cmd/client-gen --go-header-file /dev/null --clientset-name versioned --input-base github.com/cloudflare/origin-ca-issuer/pkgs --input apis/v1 --output-dir ./clientset --output-pkg github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset --apply-configuration-package github.com/cloudflare/origin-ca-issuer/pkgs/client/applyconfiguration
+//go:generate go run k8s.io/code-generator/cmd/lister-gen --go-header-file /dev/null --output-dir ./listers --output-pkg github.com/cloudflare/origin-ca-issuer/pkgs/client/listers github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1
+//go:generate go run k8s.io/code-generator/cmd/informer-gen --go-header-file /dev/null --versioned-clientset-package github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned --listers-package github.com/cloudflare/origin-ca-issuer/pkgs/client/listers --output-dir ./informers --output-pkg github.com/cloudflare/origin-ca-issuer/pkgs/client/informers github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1
@@ -0,0 +1,57 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	internalinterfaces "github.com/cloudflare/origin-ca-issuer/pkgs/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// ClusterOriginIssuers returns a ClusterOriginIssuerInformer.
+	ClusterOriginIssuers() ClusterOriginIssuerInformer
+	// OriginCertificates returns a OriginCertificateInformer.
+	OriginCertificates() OriginCertificateInformer
+	// OriginCertificateRecords returns a OriginCertificateRecordInformer.
+	OriginCertificateRecords() OriginCertificateRecordInformer
+	// OriginInventories returns a OriginInventoryInformer.
+	OriginInventories() OriginInventoryInformer
+	// OriginIssuers returns a OriginIssuerInformer.
+	OriginIssuers() OriginIssuerInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// ClusterOriginIssuers returns a ClusterOriginIssuerInformer.
+func (v *version) ClusterOriginIssuers() ClusterOriginIssuerInformer {
+	return &clusterOriginIssuerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// OriginCertificates returns a OriginCertificateInformer.
+func (v *version) OriginCertificates() OriginCertificateInformer {
+	return &originCertificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// OriginCertificateRecords returns a OriginCertificateRecordInformer.
+func (v *version) OriginCertificateRecords() OriginCertificateRecordInformer {
+	return &originCertificateRecordInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// OriginInventories returns a OriginInventoryInformer.
+func (v *version) OriginInventories() OriginInventoryInformer {
+	return &originInventoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// OriginIssuers returns a OriginIssuerInformer.
+func (v *version) OriginIssuers() OriginIssuerInformer {
+	return &originIssuerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
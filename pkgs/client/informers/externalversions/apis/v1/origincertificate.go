@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	versioned "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned"
+	internalinterfaces "github.com/cloudflare/origin-ca-issuer/pkgs/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/listers/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// OriginCertificateInformer provides access to a shared informer and lister for
+// OriginCertificates.
+type OriginCertificateInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.OriginCertificateLister
+}
+
+type originCertificateInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewOriginCertificateInformer constructs a new informer for OriginCertificate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewOriginCertificateInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredOriginCertificateInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredOriginCertificateInformer constructs a new informer for OriginCertificate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredOriginCertificateInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CloudflareV1().OriginCertificates(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CloudflareV1().OriginCertificates(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apisv1.OriginCertificate{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *originCertificateInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredOriginCertificateInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *originCertificateInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apisv1.OriginCertificate{}, f.defaultInformer)
+}
+
+func (f *originCertificateInformer) Lister() v1.OriginCertificateLister {
+	return v1.NewOriginCertificateLister(f.Informer().GetIndexer())
+}
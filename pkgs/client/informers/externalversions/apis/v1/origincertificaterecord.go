@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	versioned "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned"
+	internalinterfaces "github.com/cloudflare/origin-ca-issuer/pkgs/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/listers/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// OriginCertificateRecordInformer provides access to a shared informer and lister for
+// OriginCertificateRecords.
+type OriginCertificateRecordInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.OriginCertificateRecordLister
+}
+
+type originCertificateRecordInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewOriginCertificateRecordInformer constructs a new informer for OriginCertificateRecord type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewOriginCertificateRecordInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredOriginCertificateRecordInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredOriginCertificateRecordInformer constructs a new informer for OriginCertificateRecord type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredOriginCertificateRecordInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CloudflareV1().OriginCertificateRecords().List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CloudflareV1().OriginCertificateRecords().Watch(context.TODO(), options)
+			},
+		},
+		&apisv1.OriginCertificateRecord{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *originCertificateRecordInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredOriginCertificateRecordInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *originCertificateRecordInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apisv1.OriginCertificateRecord{}, f.defaultInformer)
+}
+
+func (f *originCertificateRecordInformer) Lister() v1.OriginCertificateRecordLister {
+	return v1.NewOriginCertificateRecordLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	apisv1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	versioned "github.com/cloudflare/origin-ca-issuer/pkgs/client/clientset/versioned"
+	internalinterfaces "github.com/cloudflare/origin-ca-issuer/pkgs/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/client/listers/apis/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// OriginInventoryInformer provides access to a shared informer and lister for
+// OriginInventories.
+type OriginInventoryInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.OriginInventoryLister
+}
+
+type originInventoryInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewOriginInventoryInformer constructs a new informer for OriginInventory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewOriginInventoryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredOriginInventoryInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredOriginInventoryInformer constructs a new informer for OriginInventory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredOriginInventoryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CloudflareV1().OriginInventories(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CloudflareV1().OriginInventories(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apisv1.OriginInventory{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *originInventoryInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredOriginInventoryInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *originInventoryInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apisv1.OriginInventory{}, f.defaultInformer)
+}
+
+func (f *originInventoryInformer) Lister() v1.OriginInventoryLister {
+	return v1.NewOriginInventoryLister(f.Informer().GetIndexer())
+}
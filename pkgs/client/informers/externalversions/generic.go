@@ -0,0 +1,54 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	"fmt"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=cert-manager.k8s.cloudflare.com, Version=v1
+	case v1.SchemeGroupVersion.WithResource("clusteroriginissuers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloudflare().V1().ClusterOriginIssuers().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("origincertificates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloudflare().V1().OriginCertificates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("origincertificaterecords"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloudflare().V1().OriginCertificateRecords().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("origininventories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloudflare().V1().OriginInventories().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("originissuers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloudflare().V1().OriginIssuers().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
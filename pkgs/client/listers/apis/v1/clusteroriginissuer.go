@@ -0,0 +1,32 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterOriginIssuerLister helps list ClusterOriginIssuers.
+// All objects returned here must be treated as read-only.
+type ClusterOriginIssuerLister interface {
+	// List lists all ClusterOriginIssuers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ClusterOriginIssuer, err error)
+	// Get retrieves the ClusterOriginIssuer from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ClusterOriginIssuer, error)
+	ClusterOriginIssuerListerExpansion
+}
+
+// clusterOriginIssuerLister implements the ClusterOriginIssuerLister interface.
+type clusterOriginIssuerLister struct {
+	listers.ResourceIndexer[*v1.ClusterOriginIssuer]
+}
+
+// NewClusterOriginIssuerLister returns a new ClusterOriginIssuerLister.
+func NewClusterOriginIssuerLister(indexer cache.Indexer) ClusterOriginIssuerLister {
+	return &clusterOriginIssuerLister{listers.New[*v1.ClusterOriginIssuer](indexer, v1.Resource("clusteroriginissuer"))}
+}
@@ -0,0 +1,35 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// ClusterOriginIssuerListerExpansion allows custom methods to be added to
+// ClusterOriginIssuerLister.
+type ClusterOriginIssuerListerExpansion interface{}
+
+// OriginCertificateListerExpansion allows custom methods to be added to
+// OriginCertificateLister.
+type OriginCertificateListerExpansion interface{}
+
+// OriginCertificateNamespaceListerExpansion allows custom methods to be added to
+// OriginCertificateNamespaceLister.
+type OriginCertificateNamespaceListerExpansion interface{}
+
+// OriginCertificateRecordListerExpansion allows custom methods to be added to
+// OriginCertificateRecordLister.
+type OriginCertificateRecordListerExpansion interface{}
+
+// OriginInventoryListerExpansion allows custom methods to be added to
+// OriginInventoryLister.
+type OriginInventoryListerExpansion interface{}
+
+// OriginInventoryNamespaceListerExpansion allows custom methods to be added to
+// OriginInventoryNamespaceLister.
+type OriginInventoryNamespaceListerExpansion interface{}
+
+// OriginIssuerListerExpansion allows custom methods to be added to
+// OriginIssuerLister.
+type OriginIssuerListerExpansion interface{}
+
+// OriginIssuerNamespaceListerExpansion allows custom methods to be added to
+// OriginIssuerNamespaceLister.
+type OriginIssuerNamespaceListerExpansion interface{}
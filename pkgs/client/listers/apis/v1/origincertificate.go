@@ -0,0 +1,54 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// OriginCertificateLister helps list OriginCertificates.
+// All objects returned here must be treated as read-only.
+type OriginCertificateLister interface {
+	// List lists all OriginCertificates in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OriginCertificate, err error)
+	// OriginCertificates returns an object that can list and get OriginCertificates.
+	OriginCertificates(namespace string) OriginCertificateNamespaceLister
+	OriginCertificateListerExpansion
+}
+
+// originCertificateLister implements the OriginCertificateLister interface.
+type originCertificateLister struct {
+	listers.ResourceIndexer[*v1.OriginCertificate]
+}
+
+// NewOriginCertificateLister returns a new OriginCertificateLister.
+func NewOriginCertificateLister(indexer cache.Indexer) OriginCertificateLister {
+	return &originCertificateLister{listers.New[*v1.OriginCertificate](indexer, v1.Resource("origincertificate"))}
+}
+
+// OriginCertificates returns an object that can list and get OriginCertificates.
+func (s *originCertificateLister) OriginCertificates(namespace string) OriginCertificateNamespaceLister {
+	return originCertificateNamespaceLister{listers.NewNamespaced[*v1.OriginCertificate](s.ResourceIndexer, namespace)}
+}
+
+// OriginCertificateNamespaceLister helps list and get OriginCertificates.
+// All objects returned here must be treated as read-only.
+type OriginCertificateNamespaceLister interface {
+	// List lists all OriginCertificates in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OriginCertificate, err error)
+	// Get retrieves the OriginCertificate from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.OriginCertificate, error)
+	OriginCertificateNamespaceListerExpansion
+}
+
+// originCertificateNamespaceLister implements the OriginCertificateNamespaceLister
+// interface.
+type originCertificateNamespaceLister struct {
+	listers.ResourceIndexer[*v1.OriginCertificate]
+}
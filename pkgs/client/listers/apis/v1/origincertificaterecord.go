@@ -0,0 +1,32 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// OriginCertificateRecordLister helps list OriginCertificateRecords.
+// All objects returned here must be treated as read-only.
+type OriginCertificateRecordLister interface {
+	// List lists all OriginCertificateRecords in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OriginCertificateRecord, err error)
+	// Get retrieves the OriginCertificateRecord from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.OriginCertificateRecord, error)
+	OriginCertificateRecordListerExpansion
+}
+
+// originCertificateRecordLister implements the OriginCertificateRecordLister interface.
+type originCertificateRecordLister struct {
+	listers.ResourceIndexer[*v1.OriginCertificateRecord]
+}
+
+// NewOriginCertificateRecordLister returns a new OriginCertificateRecordLister.
+func NewOriginCertificateRecordLister(indexer cache.Indexer) OriginCertificateRecordLister {
+	return &originCertificateRecordLister{listers.New[*v1.OriginCertificateRecord](indexer, v1.Resource("origincertificaterecord"))}
+}
@@ -0,0 +1,54 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// OriginInventoryLister helps list OriginInventories.
+// All objects returned here must be treated as read-only.
+type OriginInventoryLister interface {
+	// List lists all OriginInventories in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OriginInventory, err error)
+	// OriginInventories returns an object that can list and get OriginInventories.
+	OriginInventories(namespace string) OriginInventoryNamespaceLister
+	OriginInventoryListerExpansion
+}
+
+// originInventoryLister implements the OriginInventoryLister interface.
+type originInventoryLister struct {
+	listers.ResourceIndexer[*v1.OriginInventory]
+}
+
+// NewOriginInventoryLister returns a new OriginInventoryLister.
+func NewOriginInventoryLister(indexer cache.Indexer) OriginInventoryLister {
+	return &originInventoryLister{listers.New[*v1.OriginInventory](indexer, v1.Resource("origininventory"))}
+}
+
+// OriginInventories returns an object that can list and get OriginInventories.
+func (s *originInventoryLister) OriginInventories(namespace string) OriginInventoryNamespaceLister {
+	return originInventoryNamespaceLister{listers.NewNamespaced[*v1.OriginInventory](s.ResourceIndexer, namespace)}
+}
+
+// OriginInventoryNamespaceLister helps list and get OriginInventories.
+// All objects returned here must be treated as read-only.
+type OriginInventoryNamespaceLister interface {
+	// List lists all OriginInventories in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OriginInventory, err error)
+	// Get retrieves the OriginInventory from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.OriginInventory, error)
+	OriginInventoryNamespaceListerExpansion
+}
+
+// originInventoryNamespaceLister implements the OriginInventoryNamespaceLister
+// interface.
+type originInventoryNamespaceLister struct {
+	listers.ResourceIndexer[*v1.OriginInventory]
+}
@@ -0,0 +1,54 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// OriginIssuerLister helps list OriginIssuers.
+// All objects returned here must be treated as read-only.
+type OriginIssuerLister interface {
+	// List lists all OriginIssuers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OriginIssuer, err error)
+	// OriginIssuers returns an object that can list and get OriginIssuers.
+	OriginIssuers(namespace string) OriginIssuerNamespaceLister
+	OriginIssuerListerExpansion
+}
+
+// originIssuerLister implements the OriginIssuerLister interface.
+type originIssuerLister struct {
+	listers.ResourceIndexer[*v1.OriginIssuer]
+}
+
+// NewOriginIssuerLister returns a new OriginIssuerLister.
+func NewOriginIssuerLister(indexer cache.Indexer) OriginIssuerLister {
+	return &originIssuerLister{listers.New[*v1.OriginIssuer](indexer, v1.Resource("originissuer"))}
+}
+
+// OriginIssuers returns an object that can list and get OriginIssuers.
+func (s *originIssuerLister) OriginIssuers(namespace string) OriginIssuerNamespaceLister {
+	return originIssuerNamespaceLister{listers.NewNamespaced[*v1.OriginIssuer](s.ResourceIndexer, namespace)}
+}
+
+// OriginIssuerNamespaceLister helps list and get OriginIssuers.
+// All objects returned here must be treated as read-only.
+type OriginIssuerNamespaceLister interface {
+	// List lists all OriginIssuers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.OriginIssuer, err error)
+	// Get retrieves the OriginIssuer from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.OriginIssuer, error)
+	OriginIssuerNamespaceListerExpansion
+}
+
+// originIssuerNamespaceLister implements the OriginIssuerNamespaceLister
+// interface.
+type originIssuerNamespaceLister struct {
+	listers.ResourceIndexer[*v1.OriginIssuer]
+}
@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// APIPolicy restricts the settings of the Cloudflare API that issuers may use, so that tenants
+// creating OriginIssuers cannot make the controller send requests, and their credentials, to
+// arbitrary hosts.
+type APIPolicy struct {
+	// AllowInsecureEndpoints allows issuers to reach the Cloudflare API over plain HTTP,
+	// sending their credentials in cleartext.
+	AllowInsecureEndpoints bool
+
+	// AllowedEndpoints lists the base URLs of the Cloudflare API that OriginIssuers may set
+	// as their endpoint, matched by scheme and host. ClusterOriginIssuers may set any endpoint.
+	AllowedEndpoints []string
+
+	// AllowedProxies lists the URLs of the proxies that OriginIssuers may reach the Cloudflare
+	// API through, matched by scheme and host. ClusterOriginIssuers may set any proxy.
+	AllowedProxies []string
+}
+
+// check returns a *statusError if the API settings api of an issuer of kind are not allowed by p.
+func (p APIPolicy) check(kind string, api *v1.OriginIssuerAPI) error {
+	if api == nil {
+		return nil
+	}
+
+	notAllowed := func(format string, args ...any) error {
+		message := fmt.Sprintf(format, args...)
+		return &statusError{reason: "APINotAllowed", message: message, err: errors.New(message)}
+	}
+
+	if api.Endpoint != "" {
+		if !p.AllowInsecureEndpoints && urlScheme(api.Endpoint) != "https" {
+			return notAllowed("spec.api.endpoint %q must use https, unless the controller is started with --allow-insecure-api-endpoints", api.Endpoint)
+		}
+
+		if kind == "OriginIssuer" && !urlAllowed(api.Endpoint, p.AllowedEndpoints) {
+			return notAllowed("spec.api.endpoint %q is not allowed for OriginIssuers, unless listed in the controller's --allowed-api-endpoints", api.Endpoint)
+		}
+	}
+
+	if api.Proxy != "" && kind == "OriginIssuer" && !urlAllowed(api.Proxy, p.AllowedProxies) {
+		return notAllowed("spec.api.proxy %q is not allowed for OriginIssuers, unless listed in the controller's --allowed-api-proxies", api.Proxy)
+	}
+
+	return nil
+}
+
+// urlScheme returns the scheme of the URL s, or an empty string if s is not a URL.
+func urlScheme(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+
+	return u.Scheme
+}
+
+// urlAllowed reports whether the URL s has the scheme and host of one of the allowed URLs.
+func urlAllowed(s string, allowed []string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	for _, a := range allowed {
+		au, err := url.Parse(a)
+		if err == nil && au.Scheme == u.Scheme && strings.EqualFold(au.Host, u.Host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configureAPI applies the API settings of an issuer to b, reading the CA bundle, if any.
+func configureAPI(ctx context.Context, reader client.Reader, b *cfapi.Builder, api *v1.OriginIssuerAPI, namespace issuerNamespace) error {
+	if api == nil {
+		return nil
+	}
+
+	if api.Endpoint != "" {
+		b.WithEndpoint(api.Endpoint)
+	}
+
+	if api.Timeout != nil {
+		b.WithTimeout(api.Timeout.Duration)
+	}
+
+	if api.Proxy != "" {
+		b.WithProxy(api.Proxy)
+	}
+
+	if api.CABundle != nil {
+		bundle, err := caBundle(ctx, reader, api.CABundle, namespace)
+		if err != nil {
+			return err
+		}
+
+		b.WithCABundle(bundle)
+	}
+
+	return nil
+}
+
+// caBundleError wraps an error reading the CA bundle of an issuer with its status reason and message.
+func caBundleError(err error) error {
+	reason := "Error"
+	if apierrors.IsNotFound(err) {
+		reason = "NotFound"
+	}
+
+	return &statusError{reason: reason, message: fmt.Sprintf("Failed to retrieve CA bundle: %v", err), err: err}
+}
+
+// caBundle reads the CA bundle referenced by src.
+func caBundle(ctx context.Context, reader client.Reader, src *v1.CABundleSource, namespace issuerNamespace) ([]byte, error) {
+	switch {
+	case src.SecretRef != nil:
+		name, err := namespace.secretName(src.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+
+		var secret core.Secret
+		if err := reader.Get(ctx, name, &secret); err != nil {
+			return nil, err
+		}
+
+		bundle, ok := secret.Data[src.SecretRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s does not contain key %q", secret.Name, src.SecretRef.Key)
+		}
+
+		return bundle, nil
+	case src.ConfigMapRef != nil:
+		var cm core.ConfigMap
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace.name, Name: src.ConfigMapRef.Name}, &cm); err != nil {
+			return nil, err
+		}
+
+		bundle, ok := cm.Data[src.ConfigMapRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("configmap %s does not contain key %q", cm.Name, src.ConfigMapRef.Key)
+		}
+
+		return []byte(bundle), nil
+	default:
+		return nil, errors.New("CA bundle does not reference a secret or configmap")
+	}
+}
+
+// caBundleMetadata reads the metadata of the object referenced by src.
+func caBundleMetadata(ctx context.Context, reader client.Reader, src *v1.CABundleSource, namespace issuerNamespace) (*metav1.PartialObjectMetadata, error) {
+	switch {
+	case src.SecretRef != nil:
+		name, err := namespace.secretName(src.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+
+		return objectMetadata(ctx, reader, "Secret", name)
+	case src.ConfigMapRef != nil:
+		return objectMetadata(ctx, reader, "ConfigMap", types.NamespacedName{Namespace: namespace.name, Name: src.ConfigMapRef.Name})
+	default:
+		return nil, errors.New("CA bundle does not reference a secret or configmap")
+	}
+}
+
+// objectMetadata reads only the metadata of the named core object of the given kind.
+// When reader is backed by the manager's cache, this is served by a metadata-only
+// informer rather than the apiserver, without caching the object's data.
+func objectMetadata(ctx context.Context, reader client.Reader, kind string, name types.NamespacedName) (*metav1.PartialObjectMetadata, error) {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(core.SchemeGroupVersion.WithKind(kind))
+
+	if err := reader.Get(ctx, name, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// validateAPI ensures the API settings of an issuer are well-formed.
+func validateAPI(api *v1.OriginIssuerAPI) error {
+	if api == nil {
+		return nil
+	}
+
+	if api.Endpoint != "" {
+		if err := validateURL(api.Endpoint); err != nil {
+			return fmt.Errorf("spec.api.endpoint has invalid value %q: %w", api.Endpoint, err)
+		}
+	}
+
+	if api.Proxy != "" {
+		if err := validateURL(api.Proxy); err != nil {
+			return fmt.Errorf("spec.api.proxy has invalid value %q: %w", api.Proxy, err)
+		}
+	}
+
+	if api.Timeout != nil && api.Timeout.Duration <= 0 {
+		return fmt.Errorf("spec.api.timeout has invalid value %s: must be positive", api.Timeout.Duration)
+	}
+
+	if src := api.CABundle; src != nil {
+		switch {
+		case src.SecretRef == nil && src.ConfigMapRef == nil:
+			return errors.New("spec.api.caBundle must set one of secretRef or configMapRef")
+		case src.SecretRef != nil && src.ConfigMapRef != nil:
+			return errors.New("spec.api.caBundle must not set both secretRef and configMapRef")
+		}
+	}
+
+	return nil
+}
+
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("scheme must be http or https")
+	}
+
+	if u.Host == "" {
+		return errors.New("host must be set")
+	}
+
+	return nil
+}
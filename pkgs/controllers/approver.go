@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	certmanager "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// approverReason is the reason of the Approved and Denied conditions set by the approver, identifying
+// it among approvers.
+const approverReason = "cert-manager.k8s.cloudflare.com"
+
+// CertificateRequestApprover implements a controller that approves CertificateRequests referencing
+// this controller's issuers when they satisfy the policy of the issuer, and denies them otherwise.
+// As cert-manager's approver must not be bound to approve requests for Origin issuers alongside
+// it, requests for issuers without a policy are denied rather than left pending.
+type CertificateRequestApprover struct {
+	client.Client
+	DisableClusterIssuers bool
+	Log                   logr.Logger
+}
+
+// +kubebuilder:rbac:groups=cert-manager.io,resources=signers,verbs=approve,resourceNames=originissuers.cert-manager.k8s.cloudflare.com/*;clusteroriginissuers.cert-manager.k8s.cloudflare.com/*
+
+// Reconcile approves or denies CertificateRequest according to the policy of the referenced issuer.
+func (r *CertificateRequestApprover) Reconcile(ctx context.Context, cr *certmanager.CertificateRequest) (reconcile.Result, error) {
+	log := r.Log.WithValues("namespace", cr.Namespace, "certificaterequest", cr.Name)
+
+	if cr.Spec.IssuerRef.Group != v1.GroupVersion.Group {
+		log.V(4).Info("resource does not specify an issuerRef group name that we are responsible for", "group", cr.Spec.IssuerRef.Group)
+
+		return reconcile.Result{}, nil
+	}
+
+	if r.DisableClusterIssuers && cr.Spec.IssuerRef.Kind == "ClusterOriginIssuer" {
+		log.V(4).Info("resource references a ClusterOriginIssuer, but cluster issuers are disabled")
+
+		return reconcile.Result{}, nil
+	}
+
+	if cmutil.CertificateRequestIsApproved(cr) || cmutil.CertificateRequestIsDenied(cr) {
+		log.V(4).Info("CertificateRequest has already been approved or denied. Ignoring.")
+
+		return reconcile.Result{}, nil
+	}
+
+	var policy *v1.OriginIssuerPolicy
+	switch kind := cr.Spec.IssuerRef.Kind; kind {
+	case "OriginIssuer":
+		iss := v1.OriginIssuer{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.IssuerRef.Name}, &iss); err != nil {
+			log.Error(err, "failed to retrieve OriginIssuer resource", "namespace", cr.Namespace, "name", cr.Spec.IssuerRef.Name)
+
+			return reconcile.Result{}, err
+		}
+
+		policy = iss.Spec.Policy
+	case "ClusterOriginIssuer":
+		iss := v1.ClusterOriginIssuer{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: cr.Spec.IssuerRef.Name}, &iss); err != nil {
+			log.Error(err, "failed to retrieve ClusterOriginIssuer resource", "name", cr.Spec.IssuerRef.Name)
+
+			return reconcile.Result{}, err
+		}
+
+		policy = iss.Spec.Policy
+	default:
+		log.V(4).Info("resource references an unknown issuer kind", "kind", kind)
+
+		return reconcile.Result{}, nil
+	}
+
+	if policy == nil {
+		log.Info("denying certificate request for an issuer without a policy")
+		cmutil.SetCertificateRequestCondition(cr, certmanager.CertificateRequestConditionDenied, cmmeta.ConditionTrue, approverReason, fmt.Sprintf("%s does not have a policy allowing requests", cr.Spec.IssuerRef.Kind))
+
+		return reconcile.Result{}, r.Client.Status().Update(ctx, cr)
+	}
+
+	if err := evaluatePolicy(policy, cr); err != nil {
+		log.Info("denying certificate request", "reason", err.Error())
+		cmutil.SetCertificateRequestCondition(cr, certmanager.CertificateRequestConditionDenied, cmmeta.ConditionTrue, approverReason, fmt.Sprintf("Denied by the issuer policy: %v", err))
+	} else {
+		log.V(4).Info("approving certificate request")
+		cmutil.SetCertificateRequestCondition(cr, certmanager.CertificateRequestConditionApproved, cmmeta.ConditionTrue, approverReason, "Approved by the issuer policy")
+	}
+
+	return reconcile.Result{}, r.Client.Status().Update(ctx, cr)
+}
+
+// evaluatePolicy returns an error describing the first rule of policy the CertificateRequest violates.
+func evaluatePolicy(policy *v1.OriginIssuerPolicy, cr *certmanager.CertificateRequest) error {
+	if len(policy.AllowedNamespaces) > 0 && !slices.Contains(policy.AllowedNamespaces, cr.Namespace) {
+		return fmt.Errorf("namespace %q is not allowed", cr.Namespace)
+	}
+
+	if err := validateCertificateRequest(cr); err != nil {
+		return err
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return fmt.Errorf("failed to decode CSR: %w", err)
+	}
+
+	if len(policy.AllowedHostnames) > 0 {
+		for _, hostname := range csr.DNSNames {
+			if !slices.ContainsFunc(policy.AllowedHostnames, func(pattern string) bool {
+				return matchHostname(pattern, hostname)
+			}) {
+				return fmt.Errorf("hostname %q is not allowed", hostname)
+			}
+		}
+	}
+
+	if policy.MaxDuration != nil {
+		var duration time.Duration
+		if cr.Spec.Duration != nil {
+			duration = cr.Spec.Duration.Duration
+		}
+
+		validity := provisioners.Validity(duration)
+		if time.Duration(validity)*24*time.Hour > policy.MaxDuration.Duration {
+			return fmt.Errorf("validity of %d days exceeds the maximum duration %s", validity, policy.MaxDuration.Duration)
+		}
+	}
+
+	return nil
+}
+
+// matchHostname reports whether hostname matches pattern. Each label of pattern is matched against
+// the label of hostname at the same position, in the syntax of Go's path.Match, so wildcards never
+// match across labels: "*.example.com" matches "www.example.com", but neither "example.com" nor
+// "a.b.example.com".
+func matchHostname(pattern, hostname string) bool {
+	patterns, labels := strings.Split(strings.ToLower(pattern), "."), strings.Split(strings.ToLower(hostname), ".")
+	if len(patterns) != len(labels) {
+		return false
+	}
+
+	for i, label := range labels {
+		if ok, _ := path.Match(patterns[i], label); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validatePolicy ensures the hostname patterns and maximum duration of policy are valid.
+func validatePolicy(policy *v1.OriginIssuerPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	for i, pattern := range policy.AllowedHostnames {
+		for _, label := range strings.Split(pattern, ".") {
+			if _, err := path.Match(label, ""); err != nil {
+				return fmt.Errorf("spec.policy.allowedHostnames[%d] has invalid value %q: %w", i, pattern, err)
+			}
+		}
+	}
+
+	if policy.MaxDuration != nil && policy.MaxDuration.Duration <= 0 {
+		return fmt.Errorf("spec.policy.maxDuration has invalid value %s: must be positive", policy.MaxDuration.Duration)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmgen "github.com/cert-manager/cert-manager/test/unit/gen"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCertificateRequestApprover(t *testing.T) {
+	if err := cmapi.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &v1.OriginIssuerPolicy{
+		AllowedHostnames:  []string{"example.com", "*.example.com"},
+		MaxDuration:       &metav1.Duration{Duration: 90 * 24 * time.Hour},
+		AllowedNamespaces: []string{"default"},
+	}
+
+	request := func(kind, namespace string, hostnames []string, duration time.Duration, mods ...cmgen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		return cmgen.CertificateRequest("foobar", append([]cmgen.CertificateRequestModifier{
+			cmgen.SetCertificateRequestNamespace(namespace),
+			cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: duration}),
+			cmgen.SetCertificateRequestCSR(csrMust(t, &x509.CertificateRequest{DNSNames: hostnames})),
+			cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+				Name:  "foobar",
+				Kind:  kind,
+				Group: "cert-manager.k8s.cloudflare.com",
+			}),
+		}, mods...)...)
+	}
+
+	tests := []struct {
+		name     string
+		policy   *v1.OriginIssuerPolicy
+		cr       *cmapi.CertificateRequest
+		approved bool
+		denied   string
+	}{
+		{
+			name:     "satisfies policy",
+			policy:   policy,
+			cr:       request("ClusterOriginIssuer", "default", []string{"example.com", "www.example.com"}, 90*24*time.Hour),
+			approved: true,
+		},
+		{
+			name:     "wildcard hostname",
+			policy:   policy,
+			cr:       request("ClusterOriginIssuer", "default", []string{"*.example.com", "WWW.Example.com"}, 30*24*time.Hour),
+			approved: true,
+		},
+		{
+			name:   "wildcard matches a single label",
+			policy: policy,
+			cr:     request("ClusterOriginIssuer", "default", []string{"www.example.com", "a.b.example.com"}, 90*24*time.Hour),
+			denied: `Denied by the issuer policy: hostname "a.b.example.com" is not allowed`,
+		},
+		{
+			name:   "hostname not allowed",
+			policy: policy,
+			cr:     request("ClusterOriginIssuer", "default", []string{"example.com", "example.net"}, 90*24*time.Hour),
+			denied: `Denied by the issuer policy: hostname "example.net" is not allowed`,
+		},
+		{
+			name:   "validity too long",
+			policy: policy,
+			cr:     request("ClusterOriginIssuer", "default", []string{"example.com"}, 300*24*time.Hour),
+			denied: "Denied by the issuer policy: validity of 365 days exceeds the maximum duration 2160h0m0s",
+		},
+		{
+			name:   "namespace not allowed",
+			policy: policy,
+			cr:     request("ClusterOriginIssuer", "other", []string{"example.com"}, 90*24*time.Hour),
+			denied: `Denied by the issuer policy: namespace "other" is not allowed`,
+		},
+		{
+			name:   "unsupported request",
+			policy: policy,
+			cr: request("ClusterOriginIssuer", "default", []string{"example.com"}, 90*24*time.Hour,
+				cmgen.SetCertificateRequestIsCA(true),
+			),
+			denied: "Denied by the issuer policy: signing of CA certificates is not supported",
+		},
+		{
+			name:   "no policy",
+			cr:     request("ClusterOriginIssuer", "default", []string{"example.net"}, 90*24*time.Hour),
+			denied: "ClusterOriginIssuer does not have a policy allowing requests",
+		},
+		{
+			name:     "origin issuer",
+			policy:   policy,
+			cr:       request("OriginIssuer", "default", []string{"example.com"}, 90*24*time.Hour),
+			approved: true,
+		},
+		{
+			name:   "origin issuer hostname not allowed",
+			policy: policy,
+			cr:     request("OriginIssuer", "default", []string{"example.net"}, 90*24*time.Hour),
+			denied: `Denied by the issuer policy: hostname "example.net" is not allowed`,
+		},
+		{
+			name:   "origin issuer without policy",
+			cr:     request("OriginIssuer", "default", []string{"example.com"}, 90*24*time.Hour),
+			denied: "OriginIssuer does not have a policy allowing requests",
+		},
+		{
+			name:   "already denied",
+			policy: policy,
+			cr: request("OriginIssuer", "default", []string{"example.com"}, 90*24*time.Hour,
+				cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionDenied,
+					Status: cmmeta.ConditionTrue,
+					Reason: "policy.cert-manager.io",
+				}),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(
+					&v1.OriginIssuer{
+						ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: tt.cr.Namespace},
+						Spec:       v1.OriginIssuerSpec{RequestType: v1.RequestTypeOriginECC, Policy: tt.policy},
+					},
+					&v1.ClusterOriginIssuer{
+						ObjectMeta: metav1.ObjectMeta{Name: "foobar"},
+						Spec:       v1.OriginIssuerSpec{RequestType: v1.RequestTypeOriginECC, Policy: tt.policy},
+					},
+					tt.cr,
+				).
+				WithStatusSubresource(&cmapi.CertificateRequest{}).
+				Build()
+
+			controller := &CertificateRequestApprover{
+				Client: client,
+				Log:    logf.Log,
+			}
+
+			_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: tt.cr.Namespace, Name: "foobar"},
+			})
+			assert.NilError(t, err)
+
+			cr := &cmapi.CertificateRequest{}
+			assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: tt.cr.Namespace, Name: "foobar"}, cr))
+
+			assert.Equal(t, cmutil.CertificateRequestIsApproved(cr), tt.approved)
+
+			if tt.denied == "" {
+				assert.Equal(t, cmutil.CertificateRequestIsDenied(cr), cmutil.CertificateRequestIsDenied(tt.cr))
+				return
+			}
+
+			cond := cmutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied)
+			assert.Assert(t, cond != nil)
+			assert.Equal(t, cond.Reason, "cert-manager.k8s.cloudflare.com")
+			assert.Equal(t, cond.Message, tt.denied)
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *v1.OriginIssuerPolicy
+		error  string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:   "valid",
+			policy: &v1.OriginIssuerPolicy{AllowedHostnames: []string{"*.example.com"}, MaxDuration: &metav1.Duration{Duration: time.Hour}},
+		},
+		{
+			name:   "invalid pattern",
+			policy: &v1.OriginIssuerPolicy{AllowedHostnames: []string{"example.com", "[example.com"}},
+			error:  `spec.policy.allowedHostnames[1] has invalid value "[example.com": syntax error in pattern`,
+		},
+		{
+			name:   "non-positive duration",
+			policy: &v1.OriginIssuerPolicy{MaxDuration: &metav1.Duration{}},
+			error:  "spec.policy.maxDuration has invalid value 0s: must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOriginIssuer(v1.OriginIssuerSpec{
+				RequestType: v1.RequestTypeOriginECC,
+				Policy:      tt.policy,
+			})
+
+			if tt.error == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.error)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+)
+
+// apiUnavailableReason is the reason of the Ready condition of issuers whose credentials
+// all have an open circuit breaker.
+const apiUnavailableReason = "APIUnavailable"
+
+// apiUnavailableMessage explains why an issuer is not ready while the Cloudflare API is unavailable.
+const apiUnavailableMessage = "Cloudflare API is unavailable, signing resumes once a probe of the API succeeds"
+
+// defaultAPIBreakerPrefix starts the keys of the circuit breakers of credentials used with the
+// controller's settings of the Cloudflare API.
+const defaultAPIBreakerPrefix = "default|"
+
+// breakerKey identifies the circuit breaker of cred, used with the API settings of an issuer
+// with spec. Issuers sharing a credential, endpoint and proxy share its breaker.
+func breakerKey(spec v1.OriginIssuerSpec, namespace issuerNamespace, cred v1.OriginIssuerCredential) string {
+	api := defaultAPIBreakerPrefix
+	if spec.API != nil && *spec.API != (v1.OriginIssuerAPI{}) {
+		api = "api:" + spec.API.Endpoint + "|" + spec.API.Proxy + "|"
+	}
+
+	if ref := secretRef(cred); ref != nil {
+		if name, err := namespace.secretName(ref); err == nil {
+			return api + "secret:" + name.String() + "/" + ref.Key
+		}
+	}
+
+	return api + "file:" + credentialFile(cred)
+}
+
+// DefaultAPIBreaker reports whether the circuit breaker identified by key is of a credential used
+// with the controller's settings of the Cloudflare API. Issuers overriding the endpoint, timeout,
+// proxy or CA bundle of the API may not reach it for reasons of their own, which must not make the
+// controller report the API as unavailable.
+func DefaultAPIBreaker(key string) bool {
+	return strings.HasPrefix(key, defaultAPIBreakerPrefix)
+}
+
+// apiUnavailable reports whether the circuit breakers of all credentials of an issuer with spec
+// are open, and how long until the first of them half-opens.
+func apiUnavailable(breakers *provisioners.Breakers, spec v1.OriginIssuerSpec, namespace issuerNamespace) (time.Duration, bool) {
+	creds := IssuerCredentials(spec.Auth)
+	if len(creds) == 0 {
+		return 0, false
+	}
+
+	var retryAfter time.Duration
+	for i, cred := range creds {
+		remaining, open := breakers.Open(breakerKey(spec, namespace, cred))
+		if !open {
+			return 0, false
+		}
+
+		if i == 0 || remaining < retryAfter {
+			retryAfter = remaining
+		}
+	}
+
+	return retryAfter, true
+}
+
+// circuitOpen reports whether err was returned instead of calling the Cloudflare API because a
+// circuit breaker is open, and how long until it half-opens.
+func circuitOpen(err error) (time.Duration, bool) {
+	var openErr *provisioners.CircuitOpenError
+	if !errors.As(err, &openErr) {
+		return 0, false
+	}
+
+	return openErr.RetryAfter, true
+}
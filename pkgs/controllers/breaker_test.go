@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmgen "github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakeClock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	if err := cmapi.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+	certificate := golden.Get(t, "certificate.golden")
+
+	down := true
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if down {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`<html><body>502 Bad Gateway</body></html>`))
+			return
+		}
+
+		result, _ := json.Marshal(map[string]any{
+			"certificate": string(certificate),
+			"expires_on":  "2014-01-01T05:20:00Z",
+		})
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "result": json.RawMessage(result)})
+	}))
+	defer ts.Close()
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			cmgen.CertificateRequest("foobar",
+				cmgen.SetCertificateRequestNamespace("default"),
+				cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+				cmgen.SetCertificateRequestCSR(golden.Get(t, "csr.golden")),
+				cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "foobar",
+					Kind:  "OriginIssuer",
+					Group: "cert-manager.k8s.cloudflare.com",
+				}),
+			),
+			&v1.OriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginECC,
+					Auth: v1.OriginIssuerAuthentication{
+						TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+					},
+				},
+				Status: v1.OriginIssuerStatus{
+					Conditions: []v1.OriginIssuerCondition{{Type: v1.ConditionReady, Status: v1.ConditionTrue}},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("valid-token")},
+			},
+		).
+		WithStatusSubresource(&cmapi.CertificateRequest{}, &v1.OriginIssuer{}).
+		Build()
+
+	breakers := provisioners.NewBreakers(2, time.Minute, clock)
+
+	controller := &CertificateRequestController{
+		Client:   client,
+		Reader:   client,
+		Log:      logf.Log,
+		Builder:  cfapi.NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL),
+		Clock:    clock,
+		Recorder: record.NewFakeRecorder(10),
+		SigningSettings: SigningSettings{
+			Breakers: breakers,
+		},
+	}
+
+	issuers := &OriginIssuerController{
+		Client: client,
+		Reader: client,
+		Clock:  clock,
+		Log:    logf.Log,
+		SigningSettings: SigningSettings{
+			Breakers: breakers,
+		},
+	}
+
+	crName := types.NamespacedName{Namespace: "default", Name: "foobar"}
+	issuerName := types.NamespacedName{Namespace: "default", Name: "foobar"}
+
+	readyCondition := func() cmapi.CertificateRequestCondition {
+		t.Helper()
+
+		cr := &cmapi.CertificateRequest{}
+		assert.NilError(t, client.Get(context.Background(), crName, cr))
+
+		cond := cmutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady)
+		assert.Assert(t, cond != nil)
+
+		return *cond
+	}
+
+	issuerReady := func() v1.OriginIssuerCondition {
+		t.Helper()
+
+		iss := &v1.OriginIssuer{}
+		assert.NilError(t, client.Get(context.Background(), issuerName, iss))
+
+		for _, cond := range iss.Status.Conditions {
+			if cond.Type == v1.ConditionReady {
+				return cond
+			}
+		}
+
+		t.Fatal("issuer does not have a Ready condition")
+		return v1.OriginIssuerCondition{}
+	}
+
+	// Failures below the threshold are retried with the usual backoff.
+	_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{NamespacedName: crName})
+	assert.ErrorContains(t, err, "Cloudflare API responded with status 502")
+	assert.Equal(t, issuerReady().Status, v1.ConditionTrue)
+
+	// The failure reaching the threshold opens the breaker, marking the issuer as not ready.
+	res, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{NamespacedName: crName})
+	assert.NilError(t, err)
+	assert.Equal(t, res.RequeueAfter, time.Minute)
+	assert.Equal(t, requests, 2)
+
+	cond := readyCondition()
+	assert.Equal(t, cond.Status, cmmeta.ConditionFalse)
+	assert.Equal(t, cond.Reason, cmapi.CertificateRequestReasonPending)
+	assert.Assert(t, strings.HasPrefix(cond.Message, "Cloudflare API is unavailable: unable to sign request: Cloudflare API responded with status 502"), cond.Message)
+
+	assert.Equal(t, issuerReady().Status, v1.ConditionFalse)
+	assert.Equal(t, issuerReady().Reason, "APIUnavailable")
+
+	// Requests are requeued until the breaker half-opens, without calling the API.
+	clock.Step(20 * time.Second)
+
+	res, err = reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{NamespacedName: crName})
+	assert.NilError(t, err)
+	assert.Equal(t, res.RequeueAfter, 40*time.Second)
+	assert.Equal(t, readyCondition().Message, "OriginIssuer default/foobar is not Ready: "+apiUnavailableMessage)
+	assert.Equal(t, requests, 2)
+
+	res, err = reconcile.AsReconciler(client, issuers).Reconcile(context.Background(), reconcile.Request{NamespacedName: issuerName})
+	assert.NilError(t, err)
+	assert.Equal(t, res.RequeueAfter, 40*time.Second)
+	assert.Equal(t, issuerReady().Reason, "APIUnavailable")
+
+	// The issuer is ready again once the breaker half-opens, and the next request probes the API.
+	clock.Step(40 * time.Second)
+	down = false
+
+	_, err = reconcile.AsReconciler(client, issuers).Reconcile(context.Background(), reconcile.Request{NamespacedName: issuerName})
+	assert.NilError(t, err)
+	assert.Equal(t, issuerReady().Status, v1.ConditionTrue)
+	assert.Equal(t, requests, 2)
+
+	_, err = reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{NamespacedName: crName})
+	assert.NilError(t, err)
+	assert.Equal(t, readyCondition().Reason, cmapi.CertificateRequestReasonIssued)
+	assert.Equal(t, requests, 3)
+}
+
+func TestDefaultAPIBreakerReadiness(t *testing.T) {
+	clock := fakeClock.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	breakers := provisioners.NewBreakers(1, time.Minute, clock)
+
+	unavailable := &url.Error{Op: "Post", URL: "https://api.cloudflare.com", Err: errors.New("connection refused")}
+	fail := func() error { return unavailable }
+	succeed := func() error { return nil }
+
+	key := func(name string, api *v1.OriginIssuerAPI) string {
+		spec := v1.OriginIssuerSpec{
+			API:  api,
+			Auth: v1.OriginIssuerAuthentication{TokenRef: &v1.SecretKeySelector{Name: name, Key: "token"}},
+		}
+
+		return breakerKey(spec, issuerNamespace{name: "default"}, IssuerCredentials(spec.Auth)[0])
+	}
+
+	healthy := key("healthy", nil)
+	custom := key("custom", &v1.OriginIssuerAPI{Endpoint: "https://unreachable.example"})
+	stale := key("stale", nil)
+	outage := key("outage", &v1.OriginIssuerAPI{})
+
+	assert.Assert(t, DefaultAPIBreaker(healthy))
+	assert.Assert(t, DefaultAPIBreaker(outage))
+	assert.Assert(t, !DefaultAPIBreaker(custom))
+	assert.Assert(t, !DefaultAPIBreaker(key("proxied", &v1.OriginIssuerAPI{Proxy: "http://proxy.example:3128"})))
+
+	// An issuer with an unreachable endpoint of its own does not make the API unavailable.
+	_ = breakers.Do(custom, fail)
+	assert.NilError(t, breakers.Unavailable(DefaultAPIBreaker))
+
+	_ = breakers.Do(healthy, succeed)
+	assert.NilError(t, breakers.Unavailable(DefaultAPIBreaker))
+
+	// A breaker left open by an issuer that is no longer used does not hide a later outage.
+	_ = breakers.Do(stale, fail)
+	clock.Step(90 * time.Second)
+	_ = breakers.Do(outage, fail)
+
+	var openErr *provisioners.CircuitOpenError
+	assert.Assert(t, errors.As(breakers.Unavailable(DefaultAPIBreaker), &openErr))
+	assert.Equal(t, openErr.RetryAfter, time.Minute)
+}
@@ -1,21 +1,23 @@
 package controllers
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	certmanager "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
 	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
 	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
 	"github.com/go-logr/logr"
 	core "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -23,17 +25,43 @@ import (
 
 const originDBWriteErrorCode = 1100
 
+// issuerBusyRequeueDelay is how long a CertificateRequest waits before being retried
+// when its issuer has reached MaxConcurrentSignsPerIssuer.
+const issuerBusyRequeueDelay = 5 * time.Second
+
 // CertificateRequestController implements a controller that reconciles CertificateRequests
 // that references this controller.
 type CertificateRequestController struct {
 	client.Client
 	Reader                   client.Reader
 	ClusterResourceNamespace string
+	DisableClusterIssuers    bool
 	Log                      logr.Logger
 	Builder                  *cfapi.Builder
+	Recorder                 record.EventRecorder
+
+	SigningSettings
+
+	// ClusterSecretNamespaces lists the namespaces, besides ClusterResourceNamespace,
+	// that ClusterOriginIssuers may select secrets from.
+	ClusterSecretNamespaces []string
 
 	Clock                  clock.Clock
 	CheckApprovedCondition bool
+
+	// MaxRetryDuration bounds how long, measured from the creation of a
+	// CertificateRequest, transient errors are retried before the request
+	// is marked as Failed. A zero value retries indefinitely.
+	MaxRetryDuration time.Duration
+
+	// MaxConcurrentSignsPerIssuer bounds the number of certificates signed
+	// concurrently for a single issuer, so a slow or rate-limited issuer
+	// does not occupy every worker. A zero value is unbounded.
+	MaxConcurrentSignsPerIssuer int
+
+	signing issuerLimiter
+	quotas  Quotas
+	clients clientCache
 }
 
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=get;list;watch;update
@@ -50,6 +78,12 @@ func (r *CertificateRequestController) Reconcile(ctx context.Context, cr *certma
 		return reconcile.Result{}, nil
 	}
 
+	if r.DisableClusterIssuers && cr.Spec.IssuerRef.Kind == "ClusterOriginIssuer" {
+		log.V(4).Info("resource references a ClusterOriginIssuer, but cluster issuers are disabled")
+
+		return reconcile.Result{}, nil
+	}
+
 	// Ignore CertificateRequest if it is already Ready
 	if cmutil.CertificateRequestHasCondition(cr, certmanager.CertificateRequestCondition{
 		Type:   certmanager.CertificateRequestConditionReady,
@@ -105,170 +139,218 @@ func (r *CertificateRequestController) Reconcile(ctx context.Context, cr *certma
 		return reconcile.Result{}, nil
 	}
 
-	if cr.Spec.IsCA {
-		log.Info("Origin Issuer does not support signing of CA certificates")
+	if err := validateCertificateRequest(cr); err != nil {
+		log.Error(err, "certificate request cannot be signed by the Origin CA")
+		r.Recorder.Event(cr, core.EventTypeWarning, "UnsupportedRequest", err.Error())
 
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.setFailed(ctx, cr, fmt.Sprintf("Origin CA Issuer cannot sign certificate request: %v", err))
 	}
 
-	var (
-		secretNamespace string
-		issuerspec      v1.OriginIssuerSpec
-	)
-
 	switch cr.Spec.IssuerRef.Kind {
-	case "OriginIssuer":
-		iss := v1.OriginIssuer{}
-		issNamespaceName := types.NamespacedName{
-			Namespace: cr.Namespace,
-			Name:      cr.Spec.IssuerRef.Name,
-		}
+	case "OriginIssuer", "ClusterOriginIssuer":
+	default:
+		err := fmt.Errorf("unknown issuer kind: %s", cr.Spec.IssuerRef.Kind)
+		log.Error(err, "certificate request references unknown issuer kind", "namespace", cr.Namespace, "name", cr.Name)
+		_ = r.setFailed(ctx, cr, fmt.Sprintf("Unknown issuer kind: %s", cr.Spec.IssuerRef.Kind))
 
-		if err := r.Client.Get(ctx, issNamespaceName, &iss); err != nil {
-			log.Error(err, "failed to retrieve OriginIssuer resource", "namespace", issNamespaceName.Namespace, "name", issNamespaceName.Name)
-			_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, fmt.Sprintf("Failed to retrieve OriginIssuer resource %s: %v", issNamespaceName, err))
+		return reconcile.Result{}, err
+	}
 
-			return reconcile.Result{}, err
-		}
+	signer := r.signer()
 
-		if !IssuerStatusHasCondition(iss.Status, v1.OriginIssuerCondition{Type: v1.ConditionReady, Status: v1.ConditionTrue}) {
-			err := fmt.Errorf("resource %s is not ready", issNamespaceName)
-			log.Error(err, "issuer failed readiness checks", "namespace", issNamespaceName.Namespace, "name", issNamespaceName.Name)
-			_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, fmt.Sprintf("OriginIssuer %s is not Ready", issNamespaceName))
+	iss, err := signer.getIssuer(ctx, log, cr.Spec.IssuerRef.Kind, cr.Spec.IssuerRef.Name, cr.Namespace)
+	if err != nil {
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, reason, message)
 
-			return reconcile.Result{}, err
+		if retryAfter, open := circuitOpen(err); open {
+			return reconcile.Result{RequeueAfter: retryAfter}, nil
 		}
 
-		secretNamespace = iss.Namespace
-		issuerspec = iss.Spec
-	case "ClusterOriginIssuer":
-		iss := v1.ClusterOriginIssuer{}
-		issNamespaceName := types.NamespacedName{
-			Name: cr.Spec.IssuerRef.Name,
-		}
+		return r.retryOrFail(ctx, log, cr, err)
+	}
 
-		if err := r.Client.Get(ctx, issNamespaceName, &iss); err != nil {
-			log.Error(err, "failed to retrieve OriginIssuer resource", "namespace", issNamespaceName.Namespace, "name", issNamespaceName.Name)
-			_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, fmt.Sprintf("Failed to retrieve OriginIssuer resource %s: %v", issNamespaceName, err))
+	if !signsLocally(iss.spec, r.LocalSigning, r.AllowLocalSigningMode) && len(IssuerCredentials(iss.spec.Auth)) == 0 {
+		// This issuer should not be ready!
+		err := fmt.Errorf("issuer %s does not have an authentication method configured", cr.Spec.IssuerRef.Name)
+		log.Error(err, "failed to retrieve issuer auth secret")
+		return r.retryOrFail(ctx, log, cr, err)
+	}
 
-			return reconcile.Result{}, err
-		}
+	if !r.signing.tryAcquire(iss.key(), r.MaxConcurrentSignsPerIssuer) {
+		log.V(4).Info("issuer has reached its signing concurrency limit, requeue-ing", "limit", r.MaxConcurrentSignsPerIssuer)
 
-		if !IssuerStatusHasCondition(iss.Status, v1.OriginIssuerCondition{Type: v1.ConditionReady, Status: v1.ConditionTrue}) {
-			err := fmt.Errorf("resource %s is not ready", issNamespaceName)
-			log.Error(err, "issuer failed readiness checks", "namespace", issNamespaceName.Namespace, "name", issNamespaceName.Name)
-			_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, fmt.Sprintf("OriginIssuer %s is not Ready", issNamespaceName))
+		return reconcile.Result{RequeueAfter: issuerBusyRequeueDelay}, nil
+	}
+	defer r.signing.release(iss.key(), r.MaxConcurrentSignsPerIssuer)
 
-			return reconcile.Result{}, err
-		}
+	var duration time.Duration
+	if cr.Spec.Duration != nil {
+		duration = cr.Spec.Duration.Duration
+	}
 
-		secretNamespace = r.ClusterResourceNamespace
-		issuerspec = iss.Spec
-	default:
-		err := fmt.Errorf("unknown issuer kind: %s", cr.Spec.IssuerRef.Kind)
-		log.Error(err, "certificate request references unknown issuer kind", "namespace", cr.Namespace, "name", cr.Name)
-		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonFailed, fmt.Sprintf("Unknown issuer kind: %s", cr.Spec.IssuerRef.Kind))
+	if iss.spec.Quota != nil && !r.RecordCertificates {
+		err := errors.New("issuer quota cannot be enforced without certificate records")
+		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, quotaUnavailableMessage)
 
-		return reconcile.Result{}, err
+		return r.retryOrFail(ctx, log, cr, err)
 	}
 
-	var c *cfapi.Client
-	switch {
-	case issuerspec.Auth.ServiceKeyRef != nil:
-		var secret core.Secret
+	reservation, exceeded, err := signer.reserveQuota(ctx, iss, cr.Namespace, certificateRequestSource(cr), duration)
+	if err != nil {
+		log.Error(err, "failed to count certificates issued by issuer")
+		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, fmt.Sprintf("Failed to count certificates issued by issuer: %v", err))
 
-		secretNamespaceName := types.NamespacedName{
-			Namespace: secretNamespace,
-			Name:      issuerspec.Auth.ServiceKeyRef.Name,
-		}
+		return r.retryOrFail(ctx, log, cr, err)
+	}
 
-		if err := r.Reader.Get(ctx, secretNamespaceName, &secret); err != nil {
-			log.Error(err, "failed to retieve OriginIssuer auth secret", "namespace", secretNamespaceName.Namespace, "name", secretNamespaceName.Name)
-			if apierrors.IsNotFound(err) {
-				_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			} else {
-				_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			}
+	if exceeded != nil {
+		log.Info("issuer quota exceeded, requeue-ing", "reason", exceeded.message, "retryAfter", exceeded.retryAfter)
+		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, fmt.Sprintf("Issuer quota exceeded: %s", exceeded.message))
 
-			return reconcile.Result{}, err
-		}
+		return reconcile.Result{RequeueAfter: exceeded.retryAfter}, nil
+	}
+
+	resp, err := signer.issue(ctx, log, cr, iss, cr.Spec.Request, duration)
+	if err != nil {
+		reservation.release()
+	} else {
+		reservation.confirm(resp)
+	}
 
-		serviceKey, ok := secret.Data[issuerspec.Auth.ServiceKeyRef.Key]
-		if !ok {
-			err := fmt.Errorf("secret %s does not contain key %q", secret.Name, issuerspec.Auth.ServiceKeyRef.Key)
-			log.Error(err, "failed to retrieve OriginIssuer auth secret")
-			_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+	var openErr *provisioners.CircuitOpenError
+	if errors.As(err, &openErr) {
+		log.Info("Cloudflare API is unavailable, requeue-ing", "reason", openErr.Err.Error(), "retryAfter", openErr.RetryAfter)
+		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonPending, openErr.Error())
 
-			return reconcile.Result{}, err
-		}
+		return reconcile.Result{RequeueAfter: openErr.RetryAfter}, nil
+	}
 
-		c = r.Builder.Clone().WithServiceKey(serviceKey).Build()
-	case issuerspec.Auth.TokenRef != nil:
-		var secret core.Secret
+	var serr *statusError
+	if errors.As(err, &serr) {
+		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, serr.reason, serr.message)
 
-		secretNamespaceName := types.NamespacedName{
-			Namespace: secretNamespace,
-			Name:      issuerspec.Auth.TokenRef.Name,
-		}
+		return r.retryOrFail(ctx, log, cr, err)
+	}
 
-		if err := r.Reader.Get(ctx, secretNamespaceName, &secret); err != nil {
-			log.Error(err, "failed to retieve OriginIssuer auth secret", "namespace", secretNamespaceName.Namespace, "name", secretNamespaceName.Name)
-			if apierrors.IsNotFound(err) {
-				_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			} else {
-				_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			}
+	if err != nil {
+		return r.signError(ctx, log, cr, err)
+	}
 
-			return reconcile.Result{}, err
-		}
+	if r.RecordCertificates {
+		r.record(ctx, log, cr, iss, resp)
+	}
 
-		token, ok := secret.Data[issuerspec.Auth.TokenRef.Key]
-		if !ok {
-			err := fmt.Errorf("secret %s does not contain key %q", secret.Name, issuerspec.Auth.TokenRef.Key)
-			log.Error(err, "failed to retrieve OriginIssuer auth secret")
-			_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+	cr.Status.Certificate = []byte(resp.Certificate)
+	_ = r.setStatus(ctx, cr, cmmeta.ConditionTrue, certmanager.CertificateRequestReasonIssued, "Certificate issued")
 
-			return reconcile.Result{}, err
+	return reconcile.Result{}, nil
+}
+
+// signError handles an error returned by the Cloudflare API when signing a certificate. Errors writing
+// to the Origin CA database are transient and retried, others mark the CertificateRequest as Failed.
+func (r *CertificateRequestController) signError(ctx context.Context, log logr.Logger, cr *certmanager.CertificateRequest, err error) (reconcile.Result, error) {
+	var apiError *cfapi.APIError
+	if errors.As(err, &apiError) {
+		if apiError.Code == originDBWriteErrorCode {
+			log.Error(err, "requeue-ing after API error")
+			return r.retryOrFail(ctx, log, cr, err)
 		}
+	}
 
-		c = r.Builder.Clone().WithToken(token).Build()
-	default:
-		// This issuer should not be ready!
-		err := fmt.Errorf("issuer %s does not have an authentication method configured", cr.Spec.IssuerRef.Name)
-		log.Error(err, "failed to retrieve issuer auth secret")
-		return reconcile.Result{}, err
+	if cfapi.IsUnavailableError(err) {
+		log.Error(err, "requeue-ing after Cloudflare API is unavailable")
+		return r.retryOrFail(ctx, log, cr, err)
 	}
 
-	p, err := provisioners.New(c, issuerspec.RequestType, log)
+	log.Error(err, "failed to sign certificate request")
+	_ = r.setFailed(ctx, cr, fmt.Sprintf("Failed to sign certificate request: %v", err))
+
+	return reconcile.Result{}, err
+}
+
+// record creates the OriginCertificateRecord of the certificate issued for the CertificateRequest. As the
+// certificate is already issued, failures are only logged and recorded as events.
+func (r *CertificateRequestController) record(ctx context.Context, log logr.Logger, cr *certmanager.CertificateRequest, iss *issuer, resp *cfapi.SignResponse) {
+	err := createCertificateRecord(ctx, r.Client, iss, resp, certificateRequestSource(cr))
 	if err != nil {
-		log.Error(err, "failed to create provisioner")
+		log.Error(err, "failed to record issued certificate")
+		r.Recorder.Event(cr, core.EventTypeWarning, "RecordFailed", fmt.Sprintf("Failed to record issued certificate: %v", err))
+	}
+}
 
-		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, "Error", "Failed initialize provisioner")
+// certificateRequestSource returns the source recorded for certificates issued for the CertificateRequest.
+func certificateRequestSource(cr *certmanager.CertificateRequest) v1.RecordSource {
+	return v1.RecordSource{
+		APIGroup:    certmanager.SchemeGroupVersion.Group,
+		Kind:        "CertificateRequest",
+		Namespace:   cr.Namespace,
+		Name:        cr.Name,
+		UID:         cr.UID,
+		Certificate: cr.Annotations[certmanager.CertificateNameKey],
+	}
+}
 
-		return reconcile.Result{}, err
+// signer returns the signer used to sign CertificateRequests.
+func (r *CertificateRequestController) signer() *issuerSigner {
+	return &issuerSigner{
+		client:      r.Client,
+		reader:      r.Reader,
+		builder:     r.Builder,
+		credentials: r.Credentials,
+		clock:       r.Clock,
+		recorder:    r.Recorder,
+		clients:     &r.clients,
+		breakers:    r.Breakers,
+		quotas:      cmp.Or(r.Quotas, &r.quotas),
+		apiPolicy:   r.APIPolicy,
+
+		local:                 r.LocalSigner,
+		localSigning:          r.LocalSigning,
+		allowLocalSigningMode: r.AllowLocalSigningMode,
+
+		clusterResourceNamespace: r.ClusterResourceNamespace,
+		clusterSecretNamespaces:  r.ClusterSecretNamespaces,
 	}
+}
 
-	pem, err := p.Sign(ctx, cr)
+// validateCertificateRequest ensures the CertificateRequest only requests a certificate
+// that the Cloudflare Origin CA is able to issue: a non-CA, server authentication
+// certificate for DNS names.
+func validateCertificateRequest(cr *certmanager.CertificateRequest) error {
+	if cr.Spec.IsCA {
+		return errors.New("signing of CA certificates is not supported")
+	}
 
-	var apiError *cfapi.APIError
-	if errors.As(err, &apiError) {
-		if apiError.Code == originDBWriteErrorCode {
-			log.Error(err, "requeue-ing after API error")
-			return reconcile.Result{}, err
+	for _, usage := range cr.Spec.Usages {
+		if usage == certmanager.UsageClientAuth {
+			return fmt.Errorf("key usage %q is not supported", usage)
 		}
 	}
 
-	if err != nil {
-		log.Error(err, "failed to sign certificate request")
-		_ = r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonFailed, fmt.Sprintf("Failed to sign certificate request: %v", err))
+	return validateCSR(cr.Spec.Request)
+}
 
-		return reconcile.Result{}, err
+// validateCSR ensures the PEM-encoded certificate signing request only requests subject
+// alternative names the Cloudflare Origin CA is able to issue.
+func validateCSR(request []byte) error {
+	csr, err := pki.DecodeX509CertificateRequestBytes(request)
+	if err != nil {
+		return fmt.Errorf("failed to decode CSR: %w", err)
 	}
 
-	cr.Status.Certificate = pem
-	_ = r.setStatus(ctx, cr, cmmeta.ConditionTrue, certmanager.CertificateRequestReasonIssued, "Certificate issued")
+	switch {
+	case len(csr.IPAddresses) > 0:
+		return errors.New("IP address subject alternative names are not supported")
+	case len(csr.URIs) > 0:
+		return errors.New("URI subject alternative names are not supported")
+	case len(csr.EmailAddresses) > 0:
+		return errors.New("email address subject alternative names are not supported")
+	case len(csr.DNSNames) == 0:
+		return errors.New("at least one DNS subject alternative name is required")
+	}
 
-	return reconcile.Result{}, nil
+	return nil
 }
 
 // setStatus is a helper function to set the CertifcateRequest status condition with reason and message, and update the API.
@@ -277,3 +359,32 @@ func (r *CertificateRequestController) setStatus(ctx context.Context, cr *certma
 
 	return r.Client.Status().Update(ctx, cr)
 }
+
+// setFailed is a helper function to mark the CertificateRequest as Failed, setting FailureTime
+// if not already set, and update the API. Failed CertificateRequests are not reconciled again.
+func (r *CertificateRequestController) setFailed(ctx context.Context, cr *certmanager.CertificateRequest, message string) error {
+	if cr.Status.FailureTime == nil {
+		nowTime := metav1.NewTime(r.Clock.Now())
+		cr.Status.FailureTime = &nowTime
+	}
+
+	return r.setStatus(ctx, cr, cmmeta.ConditionFalse, certmanager.CertificateRequestReasonFailed, message)
+}
+
+// retryOrFail is a helper function for transient errors. It returns the error so the
+// CertificateRequest is retried with backoff, unless the request is older than MaxRetryDuration,
+// in which case the request is marked as Failed so cert-manager can act on it.
+func (r *CertificateRequestController) retryOrFail(ctx context.Context, log logr.Logger, cr *certmanager.CertificateRequest, err error) (reconcile.Result, error) {
+	if r.MaxRetryDuration <= 0 || cr.CreationTimestamp.IsZero() {
+		return reconcile.Result{}, err
+	}
+
+	deadline := cr.CreationTimestamp.Add(r.MaxRetryDuration)
+	if r.Clock.Now().Before(deadline) {
+		return reconcile.Result{}, err
+	}
+
+	log.Error(err, "retry budget exhausted, marking CertificateRequest as failed", "deadline", deadline)
+
+	return reconcile.Result{}, r.setFailed(ctx, cr, fmt.Sprintf("Failed to sign certificate request after retrying for %s: %v", r.MaxRetryDuration, err))
+}
@@ -2,15 +2,23 @@ package controllers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
 	"testing"
 	"time"
 
 	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	cmgen "github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
 	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
 	"gopkg.in/dnaeon/go-vcr.v4/pkg/cassette"
 	"gopkg.in/dnaeon/go-vcr.v4/pkg/recorder"
 	"gotest.tools/v3/assert"
@@ -20,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	fakeClock "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -47,6 +56,9 @@ func TestCertificateRequestReconcile(t *testing.T) {
 		expected      cmapi.CertificateRequestStatus
 		error         string
 		namespaceName types.NamespacedName
+
+		maxRetryDuration      time.Duration
+		disableClusterIssuers bool
 	}{
 		{
 			name: "working OriginIssuer with serviceKeyRef",
@@ -441,6 +453,213 @@ func TestCertificateRequestReconcile(t *testing.T) {
 			},
 			error: "unable to sign request: Cloudflare API Error code=1100 message=Failed to write certificate to Database ray_id=0123456789abcdef-ABC",
 		},
+		{
+			name: "fails after exhausting retry budget",
+			objects: []runtime.Object{
+				cmgen.CertificateRequest("foobar",
+					cmgen.SetCertificateRequestNamespace("default"),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+					cmgen.SetCertificateRequestCSR(golden.Get(t, "csr.golden")),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "foobar",
+						Kind:  "OriginIssuer",
+						Group: "cert-manager.k8s.cloudflare.com",
+					}),
+					func(cr *cmapi.CertificateRequest) {
+						cr.CreationTimestamp = metav1.NewTime(clock.Now().Add(-2 * time.Hour))
+					},
+				),
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foobar",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginECC,
+					},
+				},
+			},
+			maxRetryDuration: time.Hour,
+			expected: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Failed",
+						Message:            "Failed to sign certificate request after retrying for 1h0m0s: resource default/foobar is not ready",
+					},
+				},
+				FailureTime: &now,
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foobar",
+			},
+		},
+		{
+			name: "retries within retry budget",
+			objects: []runtime.Object{
+				cmgen.CertificateRequest("foobar",
+					cmgen.SetCertificateRequestNamespace("default"),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+					cmgen.SetCertificateRequestCSR(golden.Get(t, "csr.golden")),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "foobar",
+						Kind:  "OriginIssuer",
+						Group: "cert-manager.k8s.cloudflare.com",
+					}),
+					func(cr *cmapi.CertificateRequest) {
+						cr.CreationTimestamp = metav1.NewTime(clock.Now().Add(-30 * time.Minute))
+					},
+				),
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foobar",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginECC,
+					},
+				},
+			},
+			maxRetryDuration: time.Hour,
+			expected: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Pending",
+						Message:            "OriginIssuer default/foobar is not Ready",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foobar",
+			},
+			error: "resource default/foobar is not ready",
+		},
+		{
+			name: "fails CA CertificateRequests",
+			objects: []runtime.Object{
+				cmgen.CertificateRequest("foobar",
+					cmgen.SetCertificateRequestNamespace("default"),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+					cmgen.SetCertificateRequestCSR(golden.Get(t, "csr.golden")),
+					cmgen.SetCertificateRequestIsCA(true),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "foobar",
+						Kind:  "OriginIssuer",
+						Group: "cert-manager.k8s.cloudflare.com",
+					}),
+				),
+			},
+			expected: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Failed",
+						Message:            "Origin CA Issuer cannot sign certificate request: signing of CA certificates is not supported",
+					},
+				},
+				FailureTime: &now,
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foobar",
+			},
+		},
+		{
+			name: "fails CertificateRequests with client auth usage",
+			objects: []runtime.Object{
+				cmgen.CertificateRequest("foobar",
+					cmgen.SetCertificateRequestNamespace("default"),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+					cmgen.SetCertificateRequestCSR(golden.Get(t, "csr.golden")),
+					cmgen.SetCertificateRequestKeyUsages(cmapi.UsageServerAuth, cmapi.UsageClientAuth),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "foobar",
+						Kind:  "OriginIssuer",
+						Group: "cert-manager.k8s.cloudflare.com",
+					}),
+				),
+			},
+			expected: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Failed",
+						Message:            "Origin CA Issuer cannot sign certificate request: key usage \"client auth\" is not supported",
+					},
+				},
+				FailureTime: &now,
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foobar",
+			},
+		},
+		{
+			name: "fails CertificateRequests with IP address SANs",
+			objects: []runtime.Object{
+				cmgen.CertificateRequest("foobar",
+					cmgen.SetCertificateRequestNamespace("default"),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+					cmgen.SetCertificateRequestCSR(csrMust(t, &x509.CertificateRequest{
+						DNSNames:    []string{"example.net"},
+						IPAddresses: []net.IP{net.ParseIP("192.0.2.1")},
+					})),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "foobar",
+						Kind:  "OriginIssuer",
+						Group: "cert-manager.k8s.cloudflare.com",
+					}),
+				),
+			},
+			expected: cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Failed",
+						Message:            "Origin CA Issuer cannot sign certificate request: IP address subject alternative names are not supported",
+					},
+				},
+				FailureTime: &now,
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foobar",
+			},
+		},
+		{
+			name: "ignores ClusterOriginIssuer when cluster issuers are disabled",
+			objects: []runtime.Object{
+				cmgen.CertificateRequest("foobar",
+					cmgen.SetCertificateRequestNamespace("default"),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+					cmgen.SetCertificateRequestCSR(golden.Get(t, "csr.golden")),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "foobar",
+						Kind:  "ClusterOriginIssuer",
+						Group: "cert-manager.k8s.cloudflare.com",
+					}),
+				),
+			},
+			disableClusterIssuers: true,
+			expected:              cmapi.CertificateRequestStatus{},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foobar",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -460,8 +679,12 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				Client:                   client,
 				Reader:                   client,
 				ClusterResourceNamespace: "super-secret",
+				DisableClusterIssuers:    tt.disableClusterIssuers,
 				Log:                      logf.Log,
 				Builder:                  cfapi.NewBuilder().WithClient(tt.recorder.GetDefaultClient()),
+				Clock:                    clock,
+				MaxRetryDuration:         tt.maxRetryDuration,
+				Recorder:                 record.NewFakeRecorder(10),
 			}
 
 			_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
@@ -498,3 +721,247 @@ func RecorderMust(t *testing.T, name string) *recorder.Recorder {
 
 	return recorder
 }
+
+func csrMust(t *testing.T, template *x509.CertificateRequest) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func TestCertificateRequestRecord(t *testing.T) {
+	if err := cmapi.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+
+	ts, _ := originCAServerMust(t, clock)
+	defer ts.Close()
+
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{
+			name:    "records issued certificates",
+			enabled: true,
+		},
+		{
+			name: "records disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(
+					&v1.OriginIssuer{
+						ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+						Spec: v1.OriginIssuerSpec{
+							RequestType: v1.RequestTypeOriginECC,
+							Auth: v1.OriginIssuerAuthentication{
+								TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+							},
+						},
+						Status: v1.OriginIssuerStatus{
+							Conditions: []v1.OriginIssuerCondition{{Type: v1.ConditionReady, Status: v1.ConditionTrue}},
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+						Data:       map[string][]byte{"token": []byte("valid-token")},
+					},
+					cmgen.CertificateRequest("foobar",
+						cmgen.SetCertificateRequestNamespace("default"),
+						cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+						cmgen.SetCertificateRequestCSR(csrMust(t, &x509.CertificateRequest{DNSNames: []string{"example.com"}})),
+						cmgen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateNameKey: "web"}),
+						cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+							Name:  "foobar",
+							Kind:  "OriginIssuer",
+							Group: "cert-manager.k8s.cloudflare.com",
+						}),
+					),
+				).
+				WithStatusSubresource(&cmapi.CertificateRequest{}).
+				Build()
+
+			controller := &CertificateRequestController{
+				Client:   client,
+				Reader:   client,
+				Log:      logf.Log,
+				Builder:  cfapi.NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL),
+				Clock:    clock,
+				Recorder: record.NewFakeRecorder(10),
+				SigningSettings: SigningSettings{
+					RecordCertificates: tt.enabled,
+				},
+			}
+
+			_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "foobar"},
+			})
+			assert.NilError(t, err)
+
+			cr := &cmapi.CertificateRequest{}
+			assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "foobar"}, cr))
+			assert.Assert(t, len(cr.Status.Certificate) > 0)
+
+			records := &v1.OriginCertificateRecordList{}
+			assert.NilError(t, client.List(context.Background(), records))
+
+			if !tt.enabled {
+				assert.Equal(t, len(records.Items), 0)
+				return
+			}
+
+			assert.Equal(t, len(records.Items), 1)
+
+			cert, err := pki.DecodeX509CertificateBytes(cr.Status.Certificate)
+			assert.NilError(t, err)
+
+			got := records.Items[0]
+			assert.Equal(t, got.Name, serialNumber(cert))
+			assert.Equal(t, got.Labels[v1.RecordNamespaceLabel], "default")
+			assert.DeepEqual(t, got.Spec, v1.OriginCertificateRecordSpec{
+				CertificateID: "1",
+				SerialNumber:  serialNumber(cert),
+				Hostnames:     []string{"example.com"},
+				RequestType:   v1.RequestTypeOriginECC,
+				NotBefore:     metav1.NewTime(cert.NotBefore),
+				NotAfter:      metav1.NewTime(cert.NotAfter),
+				IssuerRef:     v1.IssuerReference{Name: "foobar", Kind: "OriginIssuer"},
+				Source: v1.RecordSource{
+					APIGroup:    "cert-manager.io",
+					Kind:        "CertificateRequest",
+					Namespace:   "default",
+					Name:        "foobar",
+					UID:         cr.UID,
+					Certificate: "web",
+				},
+			})
+		})
+	}
+}
+
+func TestCertificateRequestLocalSigning(t *testing.T) {
+	if err := cmapi.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+
+	tests := []struct {
+		name           string
+		mode           v1.SigningMode
+		localSigning   bool
+		allowLocalMode bool
+	}{
+		{
+			name:           "issuer signing mode",
+			mode:           v1.SigningModeLocal,
+			allowLocalMode: true,
+		},
+		{
+			name:         "controller local signing",
+			mode:         v1.SigningModeCloudflare,
+			localSigning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(
+					&v1.OriginIssuer{
+						ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+						Spec: v1.OriginIssuerSpec{
+							RequestType: v1.RequestTypeOriginECC,
+							SigningMode: tt.mode,
+						},
+						Status: v1.OriginIssuerStatus{
+							Conditions: []v1.OriginIssuerCondition{{Type: v1.ConditionReady, Status: v1.ConditionTrue}},
+						},
+					},
+					cmgen.CertificateRequest("foobar",
+						cmgen.SetCertificateRequestNamespace("default"),
+						cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+						cmgen.SetCertificateRequestCSR(csrMust(t, &x509.CertificateRequest{DNSNames: []string{"example.com"}})),
+						cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+							Name:  "foobar",
+							Kind:  "OriginIssuer",
+							Group: "cert-manager.k8s.cloudflare.com",
+						}),
+					),
+				).
+				WithStatusSubresource(&cmapi.CertificateRequest{}).
+				Build()
+
+			ca := &LocalCA{
+				Client: client,
+				Reader: client,
+				Clock:  clock,
+				Secret: types.NamespacedName{Namespace: "origin-ca-issuer", Name: "local-ca"},
+			}
+
+			controller := &CertificateRequestController{
+				Client:   client,
+				Reader:   client,
+				Log:      logf.Log,
+				Clock:    clock,
+				Recorder: record.NewFakeRecorder(10),
+				SigningSettings: SigningSettings{
+					LocalSigner:           provisioners.NewLocalSigner(ca, clock),
+					LocalSigning:          tt.localSigning,
+					AllowLocalSigningMode: tt.allowLocalMode,
+				},
+			}
+
+			_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "foobar"},
+			})
+			assert.NilError(t, err)
+
+			cr := &cmapi.CertificateRequest{}
+			assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "foobar"}, cr))
+			assert.Assert(t, cmutil.CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+				Type:   cmapi.CertificateRequestConditionReady,
+				Status: cmmeta.ConditionTrue,
+			}))
+
+			secret := &corev1.Secret{}
+			assert.NilError(t, client.Get(context.Background(), ca.Secret, secret))
+			caCert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+			assert.NilError(t, err)
+
+			cert, err := pki.DecodeX509CertificateBytes(cr.Status.Certificate)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, cert.DNSNames, []string{"example.com"})
+			assert.NilError(t, cert.CheckSignatureFrom(caCert))
+			assert.DeepEqual(t, cert.Issuer.Organization, []string{"origin-ca-issuer"})
+			assert.Equal(t, cert.Issuer.CommonName, "origin-ca-issuer local staging CA")
+		})
+	}
+}
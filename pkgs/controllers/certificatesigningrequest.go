@@ -0,0 +1,375 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"github.com/go-logr/logr"
+	authorization "k8s.io/api/authorization/v1"
+	certificates "k8s.io/api/certificates/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultSignerDomain is the domain of the signer names handled by the
+// CertificateSigningRequestController when none is configured.
+const DefaultSignerDomain = "cert-manager.k8s.cloudflare.com"
+
+// CertificateSigningRequestController implements a controller that signs Kubernetes
+// CertificateSigningRequests whose signer name references an OriginIssuer or
+// ClusterOriginIssuer.
+//
+// Signer names follow the cert-manager convention: "originissuers.<domain>/<namespace>.<name>"
+// references an OriginIssuer, and "clusteroriginissuers.<domain>/<name>" a ClusterOriginIssuer.
+type CertificateSigningRequestController struct {
+	client.Client
+	Reader                   client.Reader
+	ClusterResourceNamespace string
+	DisableClusterIssuers    bool
+	Log                      logr.Logger
+	Builder                  *cfapi.Builder
+	Recorder                 record.EventRecorder
+	Clock                    clock.Clock
+
+	SigningSettings
+
+	// ClusterSecretNamespaces lists the namespaces, besides ClusterResourceNamespace,
+	// that ClusterOriginIssuers may select secrets from.
+	ClusterSecretNamespaces []string
+
+	// SignerDomain is the domain of the signer names handled by the controller.
+	// Defaults to DefaultSignerDomain.
+	SignerDomain string
+
+	quotas  Quotas
+	clients clientCache
+}
+
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests/status,verbs=update;patch
+// The signers are named for DefaultSignerDomain in the generated role. The Helm chart and the check
+// subcommand name them for the signer domain the controller is configured with.
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=signers,verbs=sign,resourceNames=originissuers.cert-manager.k8s.cloudflare.com/*;clusteroriginissuers.cert-manager.k8s.cloudflare.com/*
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Reconcile reconciles CertificateSigningRequests by signing approved requests with the issuer
+// referenced by their signer name, and writing the certificate to their status.
+func (r *CertificateSigningRequestController) Reconcile(ctx context.Context, csr *certificates.CertificateSigningRequest) (reconcile.Result, error) {
+	log := r.Log.WithValues("certificatesigningrequest", csr.Name, "signer", csr.Spec.SignerName)
+
+	kind, namespace, name, ok := r.parseSignerName(csr.Spec.SignerName)
+	if !ok {
+		log.V(4).Info("resource does not specify a signer name that we are responsible for")
+
+		return reconcile.Result{}, nil
+	}
+
+	if r.DisableClusterIssuers && kind == "ClusterOriginIssuer" {
+		log.V(4).Info("resource references a ClusterOriginIssuer, but cluster issuers are disabled")
+
+		return reconcile.Result{}, nil
+	}
+
+	if len(csr.Status.Certificate) > 0 {
+		log.V(4).Info("existing certificate data found in status, skipping already completed certificate signing request")
+
+		return reconcile.Result{}, nil
+	}
+
+	if csrHasCondition(csr, certificates.CertificateFailed) {
+		log.V(4).Info("CertificateSigningRequest is Failed. Ignoring.")
+		return reconcile.Result{}, nil
+	}
+
+	if csrHasCondition(csr, certificates.CertificateDenied) {
+		log.V(4).Info("CertificateSigningRequest has been denied. Ignoring.")
+		return reconcile.Result{}, nil
+	}
+
+	if !csrHasCondition(csr, certificates.CertificateApproved) {
+		log.V(4).Info("certificate signing request has not been approved")
+		return reconcile.Result{}, nil
+	}
+
+	if err := validateCertificateSigningRequest(csr); err != nil {
+		log.Error(err, "certificate signing request cannot be signed by the Origin CA")
+		r.Recorder.Event(csr, core.EventTypeWarning, "UnsupportedRequest", err.Error())
+
+		return reconcile.Result{}, r.setFailed(ctx, csr, "UnsupportedRequest", fmt.Sprintf("Origin CA Issuer cannot sign certificate signing request: %v", err))
+	}
+
+	if kind == "OriginIssuer" {
+		allowed, err := r.canReference(ctx, csr)
+		if err != nil {
+			log.Error(err, "failed to check whether the requester may reference the issuer")
+			return reconcile.Result{}, err
+		}
+
+		if !allowed {
+			message := fmt.Sprintf("Requester may not reference OriginIssuer %s/%s", namespace, name)
+			r.Recorder.Event(csr, core.EventTypeWarning, "RequestForbidden", message)
+
+			return reconcile.Result{}, r.setFailed(ctx, csr, "RequestForbidden", message)
+		}
+	}
+
+	signer := r.signer()
+
+	iss, err := signer.getIssuer(ctx, log, kind, name, namespace)
+	if err != nil {
+		_, message := statusReason(err)
+		r.Recorder.Event(csr, core.EventTypeWarning, "IssuerNotReady", message)
+
+		if retryAfter, open := circuitOpen(err); open {
+			return reconcile.Result{RequeueAfter: retryAfter}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	var duration time.Duration
+	if csr.Spec.ExpirationSeconds != nil {
+		duration = time.Duration(*csr.Spec.ExpirationSeconds) * time.Second
+	}
+
+	if iss.spec.Quota != nil && !r.RecordCertificates {
+		err := errors.New("issuer quota cannot be enforced without certificate records")
+		log.Error(err, "failed to check issuer quota")
+		r.Recorder.Event(csr, core.EventTypeWarning, "QuotaUnavailable", quotaUnavailableMessage)
+
+		return reconcile.Result{}, err
+	}
+
+	// Certificates issued for CertificateSigningRequests count against the namespace of their issuer.
+	reservation, exceeded, err := signer.reserveQuota(ctx, iss, iss.name.Namespace, certificateSigningRequestSource(csr), duration)
+	if err != nil {
+		log.Error(err, "failed to count certificates issued by issuer")
+		r.Recorder.Event(csr, core.EventTypeWarning, "QuotaError", fmt.Sprintf("Failed to count certificates issued by issuer: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	if exceeded != nil {
+		log.Info("issuer quota exceeded, requeue-ing", "reason", exceeded.message, "retryAfter", exceeded.retryAfter)
+		r.Recorder.Event(csr, core.EventTypeWarning, "QuotaExceeded", fmt.Sprintf("Issuer quota exceeded: %s", exceeded.message))
+
+		return reconcile.Result{RequeueAfter: exceeded.retryAfter}, nil
+	}
+
+	resp, err := signer.issue(ctx, log, csr, iss, csr.Spec.Request, duration)
+	if err != nil {
+		reservation.release()
+	} else {
+		reservation.confirm(resp)
+	}
+
+	var openErr *provisioners.CircuitOpenError
+	if errors.As(err, &openErr) {
+		log.Info("Cloudflare API is unavailable, requeue-ing", "reason", openErr.Err.Error(), "retryAfter", openErr.RetryAfter)
+
+		return reconcile.Result{RequeueAfter: openErr.RetryAfter}, nil
+	}
+
+	var serr *statusError
+	if errors.As(err, &serr) {
+		r.Recorder.Event(csr, core.EventTypeWarning, serr.reason, serr.message)
+
+		return reconcile.Result{}, err
+	}
+
+	if err != nil {
+		var apiError *cfapi.APIError
+		if errors.As(err, &apiError) && apiError.Code == originDBWriteErrorCode {
+			log.Error(err, "requeue-ing after API error")
+			return reconcile.Result{}, err
+		}
+
+		if cfapi.IsUnavailableError(err) {
+			log.Error(err, "requeue-ing after Cloudflare API is unavailable")
+			return reconcile.Result{}, err
+		}
+
+		log.Error(err, "failed to sign certificate signing request")
+		r.Recorder.Event(csr, core.EventTypeWarning, "SigningError", fmt.Sprintf("Failed to sign certificate signing request: %v", err))
+		_ = r.setFailed(ctx, csr, "SigningError", fmt.Sprintf("Failed to sign certificate signing request: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	if r.RecordCertificates {
+		r.record(ctx, log, csr, iss, resp)
+	}
+
+	csr.Status.Certificate = []byte(resp.Certificate)
+	if err := r.Client.Status().Update(ctx, csr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.Recorder.Event(csr, core.EventTypeNormal, "Issued", "Certificate issued")
+
+	return reconcile.Result{}, nil
+}
+
+// record creates the OriginCertificateRecord of the certificate issued for the CertificateSigningRequest.
+// As the certificate is already issued, failures are only logged and recorded as events.
+func (r *CertificateSigningRequestController) record(ctx context.Context, log logr.Logger, csr *certificates.CertificateSigningRequest, iss *issuer, resp *cfapi.SignResponse) {
+	err := createCertificateRecord(ctx, r.Client, iss, resp, certificateSigningRequestSource(csr))
+	if err != nil {
+		log.Error(err, "failed to record issued certificate")
+		r.Recorder.Event(csr, core.EventTypeWarning, "RecordFailed", fmt.Sprintf("Failed to record issued certificate: %v", err))
+	}
+}
+
+// certificateSigningRequestSource returns the source recorded for certificates issued for the
+// CertificateSigningRequest.
+func certificateSigningRequestSource(csr *certificates.CertificateSigningRequest) v1.RecordSource {
+	return v1.RecordSource{
+		APIGroup: certificates.GroupName,
+		Kind:     "CertificateSigningRequest",
+		Name:     csr.Name,
+		UID:      csr.UID,
+	}
+}
+
+// parseSignerName returns the kind, namespace and name of the issuer referenced by signerName,
+// or false if the signer name is not handled by the controller.
+func (r *CertificateSigningRequestController) parseSignerName(signerName string) (kind, namespace, name string, ok bool) {
+	domain := r.SignerDomain
+	if domain == "" {
+		domain = DefaultSignerDomain
+	}
+
+	resource, ref, ok := strings.Cut(signerName, "/")
+	if !ok || ref == "" {
+		return "", "", "", false
+	}
+
+	switch resource {
+	case "originissuers." + domain:
+		namespace, name, ok = strings.Cut(ref, ".")
+		if !ok || namespace == "" || name == "" {
+			return "", "", "", false
+		}
+
+		return "OriginIssuer", namespace, name, true
+	case "clusteroriginissuers." + domain:
+		return "ClusterOriginIssuer", "", ref, true
+	}
+
+	return "", "", "", false
+}
+
+// canReference checks whether the user requesting csr may reference the OriginIssuer of its signer
+// name. As for the approval of requests by Kubernetes, the user must be allowed to "reference" the
+// signers resource of the certificates.k8s.io API group named by the signer name, or by a wildcard
+// such as "originissuers.<domain>/*".
+func (r *CertificateSigningRequestController) canReference(ctx context.Context, csr *certificates.CertificateSigningRequest) (bool, error) {
+	extra := make(map[string]authorization.ExtraValue, len(csr.Spec.Extra))
+	for k, v := range csr.Spec.Extra {
+		extra[k] = authorization.ExtraValue(v)
+	}
+
+	prefix, _, _ := strings.Cut(csr.Spec.SignerName, "/")
+
+	for _, n := range []string{csr.Spec.SignerName, prefix + "/*"} {
+		sar := &authorization.SubjectAccessReview{
+			Spec: authorization.SubjectAccessReviewSpec{
+				User:   csr.Spec.Username,
+				Groups: csr.Spec.Groups,
+				UID:    csr.Spec.UID,
+				Extra:  extra,
+				ResourceAttributes: &authorization.ResourceAttributes{
+					Group:    certificates.GroupName,
+					Resource: "signers",
+					Verb:     "reference",
+					Name:     n,
+				},
+			},
+		}
+
+		if err := r.Client.Create(ctx, sar); err != nil {
+			return false, err
+		}
+
+		if sar.Status.Allowed {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// signer returns the signer used to sign CertificateSigningRequests.
+func (r *CertificateSigningRequestController) signer() *issuerSigner {
+	return &issuerSigner{
+		client:      r.Client,
+		reader:      r.Reader,
+		builder:     r.Builder,
+		credentials: r.Credentials,
+		clock:       r.Clock,
+		recorder:    r.Recorder,
+		clients:     &r.clients,
+		breakers:    r.Breakers,
+		quotas:      cmp.Or(r.Quotas, &r.quotas),
+		apiPolicy:   r.APIPolicy,
+
+		local:                 r.LocalSigner,
+		localSigning:          r.LocalSigning,
+		allowLocalSigningMode: r.AllowLocalSigningMode,
+
+		clusterResourceNamespace: r.ClusterResourceNamespace,
+		clusterSecretNamespaces:  r.ClusterSecretNamespaces,
+	}
+}
+
+// setFailed is a helper function to mark the CertificateSigningRequest as Failed with reason
+// and message, and update the API. Failed CertificateSigningRequests are not reconciled again.
+func (r *CertificateSigningRequestController) setFailed(ctx context.Context, csr *certificates.CertificateSigningRequest, reason, message string) error {
+	now := metav1.NewTime(r.Clock.Now())
+	csr.Status.Conditions = append(csr.Status.Conditions, certificates.CertificateSigningRequestCondition{
+		Type:               certificates.CertificateFailed,
+		Status:             core.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+	})
+
+	return r.Client.Status().Update(ctx, csr)
+}
+
+// csrHasCondition reports whether the CertificateSigningRequest has a condition of the given
+// type with a True status.
+func csrHasCondition(csr *certificates.CertificateSigningRequest, conditionType certificates.RequestConditionType) bool {
+	for _, c := range csr.Status.Conditions {
+		if c.Type == conditionType && c.Status == core.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateCertificateSigningRequest ensures the CertificateSigningRequest only requests a
+// certificate that the Cloudflare Origin CA is able to issue: a server authentication
+// certificate for DNS names.
+func validateCertificateSigningRequest(csr *certificates.CertificateSigningRequest) error {
+	for _, usage := range csr.Spec.Usages {
+		if usage == certificates.UsageClientAuth {
+			return fmt.Errorf("key usage %q is not supported", usage)
+		}
+	}
+
+	return validateCSR(csr.Spec.Request)
+}
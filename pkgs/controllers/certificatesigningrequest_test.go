@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakeClock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCertificateSigningRequestReconcile(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+
+	ts, requests := originCAServerMust(t, clock)
+	defer ts.Close()
+
+	ready := []v1.OriginIssuerCondition{
+		{
+			Type:   v1.ConditionReady,
+			Status: v1.ConditionTrue,
+		},
+	}
+
+	issuers := []runtime.Object{
+		&v1.OriginIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+			Spec: v1.OriginIssuerSpec{
+				RequestType: v1.RequestTypeOriginECC,
+				Auth: v1.OriginIssuerAuthentication{
+					TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+				},
+			},
+			Status: v1.OriginIssuerStatus{Conditions: ready},
+		},
+		&v1.ClusterOriginIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar"},
+			Spec: v1.OriginIssuerSpec{
+				RequestType: v1.RequestTypeOriginECC,
+				Auth: v1.OriginIssuerAuthentication{
+					TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+				},
+			},
+			Status: v1.OriginIssuerStatus{Conditions: ready},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("valid-token")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "super-secret"},
+			Data:       map[string][]byte{"token": []byte("valid-token")},
+		},
+	}
+
+	request := csrMust(t, &x509.CertificateRequest{DNSNames: []string{"example.com"}})
+
+	csr := func(signerName, username string, conditions ...certificatesv1.RequestConditionType) *certificatesv1.CertificateSigningRequest {
+		csr := &certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar"},
+			Spec: certificatesv1.CertificateSigningRequestSpec{
+				Request:           request,
+				SignerName:        signerName,
+				ExpirationSeconds: ptr.To(int32(30 * 24 * 60 * 60)),
+				Usages:            []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageServerAuth},
+				Username:          username,
+			},
+		}
+
+		for _, c := range conditions {
+			csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+				Type:   c,
+				Status: corev1.ConditionTrue,
+			})
+		}
+
+		return csr
+	}
+
+	// references maps users to the signer name they may reference.
+	references := map[string]string{
+		"allowed":  "originissuers.cert-manager.k8s.cloudflare.com/default.foobar",
+		"wildcard": "originissuers.cert-manager.k8s.cloudflare.com/*",
+	}
+
+	tests := []struct {
+		name     string
+		csr      *certificatesv1.CertificateSigningRequest
+		issued   bool
+		failed   string
+		error    string
+		requests int
+
+		disableClusterIssuers bool
+	}{
+		{
+			name:     "approved request for a ClusterOriginIssuer",
+			csr:      csr("clusteroriginissuers.cert-manager.k8s.cloudflare.com/foobar", "someone", certificatesv1.CertificateApproved),
+			issued:   true,
+			requests: 1,
+		},
+		{
+			name:     "approved request for an OriginIssuer the requester may reference",
+			csr:      csr("originissuers.cert-manager.k8s.cloudflare.com/default.foobar", "allowed", certificatesv1.CertificateApproved),
+			issued:   true,
+			requests: 1,
+		},
+		{
+			name:     "approved request for an OriginIssuer the requester may reference with a wildcard",
+			csr:      csr("originissuers.cert-manager.k8s.cloudflare.com/default.foobar", "wildcard", certificatesv1.CertificateApproved),
+			issued:   true,
+			requests: 1,
+		},
+		{
+			name:   "approved request for an OriginIssuer the requester may not reference",
+			csr:    csr("originissuers.cert-manager.k8s.cloudflare.com/default.foobar", "someone", certificatesv1.CertificateApproved),
+			failed: "RequestForbidden",
+		},
+		{
+			name: "unapproved request",
+			csr:  csr("clusteroriginissuers.cert-manager.k8s.cloudflare.com/foobar", "someone"),
+		},
+		{
+			name: "denied request",
+			csr:  csr("clusteroriginissuers.cert-manager.k8s.cloudflare.com/foobar", "someone", certificatesv1.CertificateDenied),
+		},
+		{
+			name: "request for another signer",
+			csr:  csr("kubernetes.io/kube-apiserver-client", "someone", certificatesv1.CertificateApproved),
+		},
+		{
+			name:                  "request for a ClusterOriginIssuer when cluster issuers are disabled",
+			csr:                   csr("clusteroriginissuers.cert-manager.k8s.cloudflare.com/foobar", "someone", certificatesv1.CertificateApproved),
+			disableClusterIssuers: true,
+		},
+		{
+			name: "request for client authentication",
+			csr: func() *certificatesv1.CertificateSigningRequest {
+				csr := csr("clusteroriginissuers.cert-manager.k8s.cloudflare.com/foobar", "someone", certificatesv1.CertificateApproved)
+				csr.Spec.Usages = []certificatesv1.KeyUsage{certificatesv1.UsageClientAuth}
+				return csr
+			}(),
+			failed: "UnsupportedRequest",
+		},
+		{
+			name:  "request for a missing issuer",
+			csr:   csr("clusteroriginissuers.cert-manager.k8s.cloudflare.com/missing", "someone", certificatesv1.CertificateApproved),
+			error: `clusteroriginissuers.cert-manager.k8s.cloudflare.com "missing" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			*requests = 0
+
+			client := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(append(issuers, tt.csr)...).
+				WithStatusSubresource(&certificatesv1.CertificateSigningRequest{}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if sar, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+							attrs := sar.Spec.ResourceAttributes
+							sar.Status.Allowed = attrs.Group == "certificates.k8s.io" && attrs.Resource == "signers" && attrs.Verb == "reference" &&
+								attrs.Namespace == "" && attrs.Name == references[sar.Spec.User]
+							return nil
+						}
+
+						return c.Create(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			controller := &CertificateSigningRequestController{
+				Client:                   client,
+				Reader:                   client,
+				ClusterResourceNamespace: "super-secret",
+				DisableClusterIssuers:    tt.disableClusterIssuers,
+				Log:                      logf.Log,
+				Builder:                  cfapi.NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL),
+				Clock:                    clock,
+				Recorder:                 record.NewFakeRecorder(10),
+			}
+
+			_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: tt.csr.Name},
+			})
+			if tt.error != "" {
+				assert.ErrorContains(t, err, tt.error)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			got := &certificatesv1.CertificateSigningRequest{}
+			assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Name: tt.csr.Name}, got))
+			assert.Equal(t, *requests, tt.requests)
+
+			if tt.issued {
+				cert, err := pki.DecodeX509CertificateBytes(got.Status.Certificate)
+				assert.NilError(t, err)
+				assert.Equal(t, cert.NotAfter.Sub(cert.NotBefore), 30*24*time.Hour, "expirationSeconds should map to the Origin CA validity")
+			} else {
+				assert.Equal(t, len(got.Status.Certificate), 0)
+			}
+
+			failed := ""
+			for _, c := range got.Status.Conditions {
+				if c.Type == certificatesv1.CertificateFailed {
+					failed = c.Reason
+				}
+			}
+			assert.Equal(t, failed, tt.failed)
+		})
+	}
+}
+
+func TestParseSignerName(t *testing.T) {
+	tests := []struct {
+		name       string
+		domain     string
+		signerName string
+		kind       string
+		namespace  string
+		issuer     string
+		ok         bool
+	}{
+		{
+			name:       "OriginIssuer",
+			signerName: "originissuers.cert-manager.k8s.cloudflare.com/default.foobar",
+			kind:       "OriginIssuer",
+			namespace:  "default",
+			issuer:     "foobar",
+			ok:         true,
+		},
+		{
+			name:       "ClusterOriginIssuer",
+			signerName: "clusteroriginissuers.cert-manager.k8s.cloudflare.com/foo.bar",
+			kind:       "ClusterOriginIssuer",
+			issuer:     "foo.bar",
+			ok:         true,
+		},
+		{
+			name:       "custom domain",
+			domain:     "example.com",
+			signerName: "clusteroriginissuers.example.com/foobar",
+			kind:       "ClusterOriginIssuer",
+			issuer:     "foobar",
+			ok:         true,
+		},
+		{
+			name:       "default domain with custom domain configured",
+			domain:     "example.com",
+			signerName: "clusteroriginissuers.cert-manager.k8s.cloudflare.com/foobar",
+		},
+		{
+			name:       "OriginIssuer without namespace",
+			signerName: "originissuers.cert-manager.k8s.cloudflare.com/foobar",
+		},
+		{
+			name:       "missing issuer",
+			signerName: "clusteroriginissuers.cert-manager.k8s.cloudflare.com/",
+		},
+		{
+			name:       "Kubernetes signer",
+			signerName: "kubernetes.io/kubelet-serving",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CertificateSigningRequestController{SignerDomain: tt.domain}
+
+			kind, namespace, name, ok := r.parseSignerName(tt.signerName)
+			assert.Equal(t, ok, tt.ok)
+			assert.Equal(t, kind, tt.kind)
+			assert.Equal(t, namespace, tt.namespace)
+			assert.Equal(t, name, tt.issuer)
+		})
+	}
+}
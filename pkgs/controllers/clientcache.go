@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"sync"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+)
+
+// maxCachedClients bounds the number of clients held by a clientCache, so the clients of deleted
+// issuers do not accumulate. The least recently used client is evicted first.
+const maxCachedClients = 256
+
+// clientCache holds a Cloudflare API client per issuer credential, keyed by the
+// issuer's UID and the credential's position, so connections are reused across
+// reconciles. Each client is stored with the version of the issuer and
+// referenced objects it was built from, and is replaced once that version changes.
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[string]*cachedClient
+	uses    uint64
+}
+
+type cachedClient struct {
+	version string
+	client  *cfapi.Client
+	lastUse uint64
+}
+
+// get returns the client cached under key, if it was built from version.
+func (c *clientCache) get(key string, version string) (*cfapi.Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cc, ok := c.clients[key]
+	if !ok || cc.version != version {
+		return nil, false
+	}
+
+	c.uses++
+	cc.lastUse = c.uses
+
+	return cc.client, true
+}
+
+// put caches client under key, replacing any client built from another version, and evicts the
+// least recently used client once more than maxCachedClients are cached. The idle connections of
+// replaced and evicted clients are closed.
+func (c *clientCache) put(key string, version string, client *cfapi.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.clients == nil {
+		c.clients = make(map[string]*cachedClient)
+	}
+
+	if old, ok := c.clients[key]; ok && old.client != client {
+		old.client.CloseIdleConnections()
+	}
+
+	c.uses++
+	c.clients[key] = &cachedClient{version: version, client: client, lastUse: c.uses}
+
+	if len(c.clients) <= maxCachedClients {
+		return
+	}
+
+	var lru string
+	for k, cc := range c.clients {
+		if lru == "" || cc.lastUse < c.clients[lru].lastUse {
+			lru = k
+		}
+	}
+	c.clients[lru].client.CloseIdleConnections()
+	delete(c.clients, lru)
+}
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestClientCache(t *testing.T) {
+	var c clientCache
+
+	_, ok := c.get("issuer-a", "1/100")
+	assert.Assert(t, !ok, "empty cache should miss")
+
+	client := cfapi.New()
+	c.put("issuer-a", "1/100", client)
+
+	got, ok := c.get("issuer-a", "1/100")
+	assert.Assert(t, ok)
+	assert.Equal(t, got, client)
+
+	_, ok = c.get("issuer-a", "1/101")
+	assert.Assert(t, !ok, "changed version should miss")
+	_, ok = c.get("issuer-b", "1/100")
+	assert.Assert(t, !ok, "other issuers should miss")
+
+	c.put("issuer-a", "1/101", cfapi.New())
+	_, ok = c.get("issuer-a", "1/100")
+	assert.Assert(t, !ok, "stale versions should be replaced")
+}
+
+func TestClientCacheEviction(t *testing.T) {
+	var c clientCache
+
+	for i := 0; i < maxCachedClients; i++ {
+		c.put(fmt.Sprintf("issuer-%d", i), "1/100", cfapi.New())
+	}
+
+	_, ok := c.get("issuer-0", "1/100")
+	assert.Assert(t, ok)
+
+	c.put("issuer-new", "1/100", cfapi.New())
+	assert.Equal(t, len(c.clients), maxCachedClients)
+
+	_, ok = c.get("issuer-1", "1/100")
+	assert.Assert(t, !ok, "least recently used client should be evicted")
+	_, ok = c.get("issuer-0", "1/100")
+	assert.Assert(t, ok, "recently used clients should be kept")
+	_, ok = c.get("issuer-new", "1/100")
+	assert.Assert(t, ok)
+}
+
+func TestClientCacheClosesIdleConnections(t *testing.T) {
+	closed := make(chan struct{}, maxCachedClients+2)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"result":  map[string]string{"id": "token", "status": "active"},
+		})
+	}))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+
+	// connected builds a client with its own transport for the CA bundle, holding an idle
+	// connection to the server.
+	connected := func() *cfapi.Client {
+		client, err := cfapi.NewBuilder().WithEndpoint(ts.URL).WithCABundle(caBundle).WithToken([]byte("api-token")).Build()
+		assert.NilError(t, err)
+
+		_, err = client.VerifyToken(context.Background())
+		assert.NilError(t, err)
+
+		return client
+	}
+
+	waitClosed := func(msg string) {
+		t.Helper()
+
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal(msg)
+		}
+	}
+
+	var c clientCache
+
+	client := connected()
+	c.put("issuer-a", "1/100", client)
+	c.put("issuer-a", "1/100", client)
+	select {
+	case <-closed:
+		t.Fatal("putting the cached client again should keep its connections")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c.put("issuer-a", "1/101", connected())
+	waitClosed("the connections of replaced clients should be closed")
+
+	for i := 0; i < maxCachedClients-1; i++ {
+		c.put(fmt.Sprintf("issuer-%d", i), "1/100", cfapi.New())
+	}
+	c.put("issuer-new", "1/100", cfapi.New())
+	_, ok := c.get("issuer-a", "1/101")
+	assert.Assert(t, !ok, "least recently used client should be evicted")
+	waitClosed("the connections of evicted clients should be closed")
+}
+
+func TestProvisionerClientCache(t *testing.T) {
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "issuer-api-token",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token": []byte("api-token"),
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(secret).
+		Build()
+
+	controller := &CertificateRequestController{
+		Client:  client,
+		Reader:  client,
+		Builder: cfapi.NewBuilder(),
+		Log:     logf.Log,
+	}
+
+	iss := &issuer{
+		kind:      "OriginIssuer",
+		name:      types.NamespacedName{Name: "foo", Namespace: "default"},
+		meta:      metav1.ObjectMeta{Name: "foo", Namespace: "default", UID: "issuer-uid", Generation: 1},
+		spec:      v1.OriginIssuerSpec{RequestType: v1.RequestTypeOriginECC},
+		namespace: issuerNamespace{name: "default"},
+	}
+	cred := v1.OriginIssuerCredential{
+		TokenRef: &v1.SecretKeySelector{Name: "issuer-api-token", Key: "token"},
+	}
+
+	cached := func() *cfapi.Client {
+		t.Helper()
+
+		c, _, err := controller.signer().apiClient(context.Background(), iss, cred, "issuer-uid/0")
+		assert.NilError(t, err)
+		assert.Equal(t, controller.clients.clients["issuer-uid/0"].client, c)
+
+		return c
+	}
+
+	initial := cached()
+	assert.Equal(t, cached(), initial, "clients should be reused")
+
+	secret.Data["token"] = []byte("rotated-api-token")
+	assert.NilError(t, client.Update(context.Background(), secret))
+	rotated := cached()
+	assert.Assert(t, rotated != initial, "secret updates should rebuild the client")
+
+	iss.meta.Generation = 2
+	assert.Assert(t, cached() != rotated, "issuer spec updates should rebuild the client")
+}
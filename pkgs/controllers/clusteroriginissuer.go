@@ -6,9 +6,7 @@ import (
 
 	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
 	"github.com/go-logr/logr"
-	core "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -22,6 +20,12 @@ type ClusterOriginIssuerController struct {
 	ClusterResourceNamespace string
 	Log                      logr.Logger
 	Clock                    clock.Clock
+
+	SigningSettings
+
+	// ClusterSecretNamespaces lists the namespaces, besides ClusterResourceNamespace,
+	// that ClusterOriginIssuers may select secrets from.
+	ClusterSecretNamespaces []string
 }
 
 //go:generate controller-gen rbac:roleName=originissuer-control paths=./. output:rbac:artifacts:config=../../deploy/rbac
@@ -41,67 +45,70 @@ func (r *ClusterOriginIssuerController) Reconcile(ctx context.Context, iss *v1.C
 		return reconcile.Result{}, err
 	}
 
-	switch {
-	case iss.Spec.Auth.ServiceKeyRef != nil:
-		secret := &core.Secret{}
-		secretNamespaceName := types.NamespacedName{
-			Namespace: r.ClusterResourceNamespace,
-			Name:      iss.Spec.Auth.ServiceKeyRef.Name,
-		}
+	if iss.Spec.Quota != nil && !r.RecordCertificates {
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, "QuotaUnavailable", quotaUnavailableMessage)
+		return reconcile.Result{}, nil
+	}
 
-		if err := r.Reader.Get(ctx, secretNamespaceName, secret); err != nil {
-			log.Error(err, "failed to retieve ClusterOriginIssuer auth secret", "namespace", secretNamespaceName.Namespace, "name", secretNamespaceName.Name)
+	if err := updateQuotaStatus(ctx, r.Client, r.Clock, "ClusterOriginIssuer", iss.Name, "", iss.Spec, &iss.Status); err != nil {
+		log.Error(err, "failed to count certificates issued by ClusterOriginIssuer")
+	}
 
-			if apierrors.IsNotFound(err) {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			} else {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			}
+	if err := r.APIPolicy.check("ClusterOriginIssuer", iss.Spec.API); err != nil {
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, reason, message)
+		return reconcile.Result{}, nil
+	}
 
-			return reconcile.Result{}, err
-		}
+	if err := localSigningModeError(iss.Spec, r.LocalSigning, r.AllowLocalSigningMode); err != nil {
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, reason, message)
+		return reconcile.Result{}, nil
+	}
 
-		_, ok := secret.Data[iss.Spec.Auth.ServiceKeyRef.Key]
-		if !ok {
-			err := fmt.Errorf("secret %s does not contain key %q", secret.Name, iss.Spec.Auth.ServiceKeyRef.Key)
-			log.Error(err, "failed to retrieve ClusterOriginIssuer auth secret")
-			_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+	// Issuers signing certificates locally do not use their credentials.
+	if signsLocally(iss.Spec, r.LocalSigning, r.AllowLocalSigningMode) {
+		return quotaResult(iss.Spec), r.setStatus(ctx, iss, v1.ConditionTrue, "Verified", "ClusterOriginIssuer verified and ready to sign certificates with the local CA")
+	}
 
-			return reconcile.Result{}, err
-		}
-	case iss.Spec.Auth.TokenRef != nil:
-		secret := &core.Secret{}
-		secretNamespaceName := types.NamespacedName{
-			Namespace: r.ClusterResourceNamespace,
-			Name:      iss.Spec.Auth.TokenRef.Name,
-		}
+	namespace := issuerNamespace{name: r.ClusterResourceNamespace, allowed: r.ClusterSecretNamespaces}
+
+	if len(IssuerCredentials(iss.Spec.Auth)) == 0 {
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, "MissingAuthentication", "No authentication methods were configured")
+		return reconcile.Result{}, nil
+	}
+
+	if err := checkCredentials(ctx, r.Reader, r.Credentials, r.Clock, iss.Spec.Auth, &iss.Status, namespace); err != nil {
+		log.Error(err, "failed to retrieve ClusterOriginIssuer credentials")
+
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, reason, message)
 
-		if err := r.Reader.Get(ctx, secretNamespaceName, secret); err != nil {
-			log.Error(err, "failed to retieve ClusterOriginIssuer auth secret", "namespace", secretNamespaceName.Namespace, "name", secretNamespaceName.Name)
+		return reconcile.Result{}, err
+	}
+
+	if iss.Spec.API != nil && iss.Spec.API.CABundle != nil {
+		if _, err := caBundle(ctx, r.Reader, iss.Spec.API.CABundle, namespace); err != nil {
+			log.Error(err, "failed to retrieve ClusterOriginIssuer CA bundle")
 
 			if apierrors.IsNotFound(err) {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve CA bundle: %v", err))
 			} else {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve CA bundle: %v", err))
 			}
 
 			return reconcile.Result{}, err
 		}
+	}
 
-		_, ok := secret.Data[iss.Spec.Auth.TokenRef.Key]
-		if !ok {
-			err := fmt.Errorf("secret %s does not contain key %q", secret.Name, iss.Spec.Auth.TokenRef.Key)
-			log.Error(err, "failed to retrieve ClusterOriginIssuer auth secret")
-			_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+	if retryAfter, unavailable := apiUnavailable(r.Breakers, iss.Spec, namespace); unavailable {
+		log.Info("Cloudflare API is unavailable with every credential", "retryAfter", retryAfter)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, apiUnavailableReason, apiUnavailableMessage)
 
-			return reconcile.Result{}, err
-		}
-	default:
-		_ = r.setStatus(ctx, iss, v1.ConditionFalse, "MissingAuthentication", "No authentication methods were configured")
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: retryAfter}, nil
 	}
 
-	return reconcile.Result{}, r.setStatus(ctx, iss, v1.ConditionTrue, "Verified", "ClusterOriginIssuer verified and ready to sign certificates")
+	return quotaResult(iss.Spec), r.setStatus(ctx, iss, v1.ConditionTrue, "Verified", "ClusterOriginIssuer verified and ready to sign certificates")
 }
 
 // setStatus is a helper function to set the Issuer status condition with reason and message, and update the API.
@@ -75,6 +75,16 @@ func TestClusterOriginIssuerReconcile(t *testing.T) {
 						Message:            "ClusterOriginIssuer verified and ready to sign certificates",
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "999",
+					},
+				},
 			},
 			namespaceName: types.NamespacedName{
 				Name: "foo",
@@ -108,6 +118,15 @@ func TestClusterOriginIssuerReconcile(t *testing.T) {
 						Message:            `Failed to retrieve auth secret: secrets "issuer-service-key" not found`,
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "NotFound",
+						Message:            `Failed to retrieve auth secret: secrets "issuer-service-key" not found`,
+					},
+				},
 			},
 			error: `secrets "issuer-service-key" not found`,
 			namespaceName: types.NamespacedName{
@@ -149,6 +168,15 @@ func TestClusterOriginIssuerReconcile(t *testing.T) {
 						Message:            `Failed to retrieve auth secret: secret issuer-service-key does not contain key "key"`,
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "NotFound",
+						Message:            `Failed to retrieve auth secret: secret issuer-service-key does not contain key "key"`,
+					},
+				},
 			},
 			error: `secret issuer-service-key does not contain key "key"`,
 			namespaceName: types.NamespacedName{
@@ -192,7 +220,123 @@ func TestClusterOriginIssuerReconcile(t *testing.T) {
 						Message:            "ClusterOriginIssuer verified and ready to sign certificates",
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "999",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Name: "foo",
+			},
+		},
+		{
+			name: "tokenRef in allowed namespace",
+			objects: []runtime.Object{
+				&v1.ClusterOriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenRef: &v1.SecretKeySelector{
+								Name:      "issuer-token",
+								Key:       "token",
+								Namespace: "team-secrets",
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer-token",
+						Namespace: "team-secrets",
+					},
+					Data: map[string][]byte{
+						"token": []byte("api-token"),
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Verified",
+						Message:            "ClusterOriginIssuer verified and ready to sign certificates",
+					},
+				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "999",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Name: "foo",
+			},
+		},
+		{
+			name: "tokenRef in disallowed namespace",
+			objects: []runtime.Object{
+				&v1.ClusterOriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenRef: &v1.SecretKeySelector{
+								Name:      "issuer-token",
+								Key:       "token",
+								Namespace: "kube-system",
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer-token",
+						Namespace: "kube-system",
+					},
+					Data: map[string][]byte{
+						"token": []byte("api-token"),
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Error",
+						Message:            `Failed to retrieve auth secret: secret issuer-token is in namespace "kube-system", which the issuer is not allowed to read secrets from`,
+					},
+				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Error",
+						Message:            `Failed to retrieve auth secret: secret issuer-token is in namespace "kube-system", which the issuer is not allowed to read secrets from`,
+					},
+				},
 			},
+			error: `secret issuer-token is in namespace "kube-system", which the issuer is not allowed to read secrets from`,
 			namespaceName: types.NamespacedName{
 				Name: "foo",
 			},
@@ -242,6 +386,7 @@ func TestClusterOriginIssuerReconcile(t *testing.T) {
 				Client:                   client,
 				Reader:                   client,
 				ClusterResourceNamespace: "super-secret",
+				ClusterSecretNamespaces:  []string{"team-secrets"},
 				Clock:                    clock,
 				Log:                      logf.Log,
 			}
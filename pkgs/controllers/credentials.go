@@ -0,0 +1,299 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/credfile"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// credentialRejectedReason is the reason reported for credentials rejected by the Cloudflare API.
+const credentialRejectedReason = "Rejected"
+
+// statusError is an error with the reason and message to report in a resource's status.
+type statusError struct {
+	reason  string
+	message string
+	err     error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// statusReason returns the reason and message to report in a resource's status for err.
+func statusReason(err error) (string, string) {
+	var serr *statusError
+	if errors.As(err, &serr) {
+		return serr.reason, serr.message
+	}
+
+	return "Error", err.Error()
+}
+
+// IssuerCredentials returns the credentials of an issuer in the order they are tried.
+// A credential configured directly on auth is returned first, without a name.
+func IssuerCredentials(auth v1.OriginIssuerAuthentication) []v1.OriginIssuerCredential {
+	var creds []v1.OriginIssuerCredential
+
+	switch {
+	case auth.ServiceKeyRef != nil:
+		creds = append(creds, v1.OriginIssuerCredential{ServiceKeyRef: auth.ServiceKeyRef})
+	case auth.TokenRef != nil:
+		creds = append(creds, v1.OriginIssuerCredential{TokenRef: auth.TokenRef})
+	case auth.ServiceKeyFile != "":
+		creds = append(creds, v1.OriginIssuerCredential{ServiceKeyFile: auth.ServiceKeyFile})
+	case auth.TokenFile != "":
+		creds = append(creds, v1.OriginIssuerCredential{TokenFile: auth.TokenFile})
+	}
+
+	return append(creds, auth.Credentials...)
+}
+
+// credentialName returns the name used to identify cred in logs, events and the issuer's status.
+func credentialName(cred v1.OriginIssuerCredential) string {
+	if cred.Name == "" {
+		return "spec.auth"
+	}
+
+	return cred.Name
+}
+
+// secretRef returns the secret referenced by cred, if any.
+func secretRef(cred v1.OriginIssuerCredential) *v1.SecretKeySelector {
+	if cred.ServiceKeyRef != nil {
+		return cred.ServiceKeyRef
+	}
+
+	return cred.TokenRef
+}
+
+// credentialFile returns the file referenced by cred, if any.
+func credentialFile(cred v1.OriginIssuerCredential) string {
+	if cred.ServiceKeyFile != "" {
+		return cred.ServiceKeyFile
+	}
+
+	return cred.TokenFile
+}
+
+// issuerNamespace resolves the namespaces of the objects referenced by an issuer.
+type issuerNamespace struct {
+	// name is the namespace referenced objects are read from by default.
+	name string
+	// allowed lists the other namespaces Secrets may be selected from.
+	allowed []string
+	// files is the subdirectory of the credential directories that credential files are read
+	// from, or empty if files may be read from anywhere within them.
+	files string
+}
+
+// originIssuerNamespace returns the namespaces of the objects referenced by an OriginIssuer in
+// namespace. OriginIssuers only read Secrets from their namespace, and credential files from its
+// subdirectory of the credential directories, so tenants cannot use each other's credentials.
+func originIssuerNamespace(namespace string) issuerNamespace {
+	return issuerNamespace{name: namespace, files: namespace}
+}
+
+// secret returns the namespace the Secret selected by ref is read from.
+func (n issuerNamespace) secret(ref *v1.SecretKeySelector) (string, error) {
+	if ref.Namespace == "" || ref.Namespace == n.name || slices.Contains(n.allowed, ref.Namespace) {
+		return cmp.Or(ref.Namespace, n.name), nil
+	}
+
+	return "", fmt.Errorf("secret %s is in namespace %q, which the issuer is not allowed to read secrets from", ref.Name, ref.Namespace)
+}
+
+// secretName returns the namespaced name of the Secret selected by ref.
+func (n issuerNamespace) secretName(ref *v1.SecretKeySelector) (types.NamespacedName, error) {
+	namespace, err := n.secret(ref)
+	if err != nil {
+		return types.NamespacedName{}, err
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}, nil
+}
+
+// readCredential reads the value of cred, along with the version of the Secret or file it was
+// read from.
+func readCredential(ctx context.Context, reader client.Reader, files *credfile.Store, cred v1.OriginIssuerCredential, namespace issuerNamespace) ([]byte, string, error) {
+	if ref := secretRef(cred); ref != nil {
+		name, err := namespace.secretName(ref)
+		if err != nil {
+			return nil, "", secretError(err)
+		}
+
+		var secret core.Secret
+		if err := reader.Get(ctx, name, &secret); err != nil {
+			return nil, "", secretError(err)
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			err := fmt.Errorf("secret %s does not contain key %q", secret.Name, ref.Key)
+			return nil, "", &statusError{reason: "NotFound", message: fmt.Sprintf("Failed to retrieve auth secret: %v", err), err: err}
+		}
+
+		return value, secret.ResourceVersion, nil
+	}
+
+	if path := credentialFile(cred); path != "" {
+		value, version, err := files.Read(path, namespace.files)
+		if err != nil {
+			return nil, "", fileError(err)
+		}
+
+		return value, "file:" + version, nil
+	}
+
+	err := errors.New("credential does not reference a secret or file")
+
+	return nil, "", &statusError{reason: "Error", message: fmt.Sprintf("Invalid credential: %v", err), err: err}
+}
+
+// credentialVersion returns the version of the Secret or file cred is read from, reading
+// only the metadata of Secrets.
+func credentialVersion(ctx context.Context, reader client.Reader, files *credfile.Store, cred v1.OriginIssuerCredential, namespace issuerNamespace) (string, error) {
+	if ref := secretRef(cred); ref != nil {
+		name, err := namespace.secretName(ref)
+		if err != nil {
+			return "", secretError(err)
+		}
+
+		secret, err := objectMetadata(ctx, reader, "Secret", name)
+		if err != nil {
+			return "", secretError(err)
+		}
+
+		return secret.ResourceVersion, nil
+	}
+
+	_, version, err := readCredential(ctx, reader, files, cred, namespace)
+
+	return version, err
+}
+
+func secretError(err error) error {
+	reason := "Error"
+	if apierrors.IsNotFound(err) {
+		reason = "NotFound"
+	}
+
+	return &statusError{reason: reason, message: fmt.Sprintf("Failed to retrieve auth secret: %v", err), err: err}
+}
+
+func fileError(err error) error {
+	reason := "Error"
+	if errors.Is(err, fs.ErrNotExist) {
+		reason = "NotFound"
+	}
+
+	return &statusError{reason: reason, message: fmt.Sprintf("Failed to read credential file: %v", err), err: err}
+}
+
+// checkCredentials reads each of the credentials in auth, reporting their health in status under
+// the name returned by credentialName. Credentials previously rejected by the Cloudflare API remain unhealthy
+// until their Secret or file changes. An error is returned only when no credential is healthy.
+func checkCredentials(ctx context.Context, reader client.Reader, files *credfile.Store, cl clock.Clock, auth v1.OriginIssuerAuthentication, status *v1.OriginIssuerStatus, namespace issuerNamespace) error {
+	var (
+		statuses []v1.OriginIssuerCredentialStatus
+		healthy  bool
+		lastErr  error
+	)
+
+	for _, cred := range IssuerCredentials(auth) {
+		name := credentialName(cred)
+		cs := v1.OriginIssuerCredentialStatus{Name: name, Status: v1.ConditionTrue, Reason: "Available", Message: "Credential is available"}
+
+		_, version, err := readCredential(ctx, reader, files, cred, namespace)
+		switch prev := findCredentialStatus(status.Credentials, name); {
+		case err != nil:
+			lastErr = err
+			cs.Status = v1.ConditionFalse
+			cs.Reason, cs.Message = statusReason(err)
+		case prev != nil && prev.Reason == credentialRejectedReason && prev.ObservedVersion == version:
+			lastErr = &statusError{reason: prev.Reason, message: prev.Message, err: errors.New(prev.Message)}
+			cs = *prev
+		default:
+			healthy = true
+		}
+
+		cs.ObservedVersion = version
+
+		statuses = append(statuses, withTransitionTime(cs, findCredentialStatus(status.Credentials, name), cl))
+	}
+
+	status.Credentials = statuses
+
+	if healthy {
+		return nil
+	}
+
+	return lastErr
+}
+
+// rejectCredential marks the named credential, read at version, as rejected by the Cloudflare API.
+func rejectCredential(status *v1.OriginIssuerStatus, cl clock.Clock, name, version string, err error) {
+	cs := v1.OriginIssuerCredentialStatus{
+		Name:            name,
+		Status:          v1.ConditionFalse,
+		Reason:          credentialRejectedReason,
+		Message:         fmt.Sprintf("Credential was rejected by the Cloudflare API: %v", err),
+		ObservedVersion: version,
+	}
+
+	for i := range status.Credentials {
+		if status.Credentials[i].Name == name {
+			status.Credentials[i] = withTransitionTime(cs, &status.Credentials[i], cl)
+			return
+		}
+	}
+
+	status.Credentials = append(status.Credentials, withTransitionTime(cs, nil, cl))
+}
+
+// credentialRejected reports whether the named credential, read at version, was rejected by the
+// Cloudflare API.
+func credentialRejected(status v1.OriginIssuerStatus, name, version string) bool {
+	cs := findCredentialStatus(status.Credentials, name)
+
+	return cs != nil && cs.Reason == credentialRejectedReason && cs.ObservedVersion == version
+}
+
+func findCredentialStatus(statuses []v1.OriginIssuerCredentialStatus, name string) *v1.OriginIssuerCredentialStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+
+	return nil
+}
+
+// withTransitionTime sets the LastTransitionTime of cs, keeping the time of prev if the
+// status did not change.
+func withTransitionTime(cs v1.OriginIssuerCredentialStatus, prev *v1.OriginIssuerCredentialStatus, cl clock.Clock) v1.OriginIssuerCredentialStatus {
+	if prev != nil && prev.Status == cs.Status && prev.LastTransitionTime != nil {
+		cs.LastTransitionTime = prev.LastTransitionTime
+	} else {
+		now := metav1.NewTime(cl.Now())
+		cs.LastTransitionTime = &now
+	}
+
+	return cs
+}
@@ -0,0 +1,250 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmgen "github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakeClock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCredentialFailover(t *testing.T) {
+	if err := cmapi.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+	certificate := golden.Get(t, "certificate.golden")
+
+	requests := map[string]int{}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		requests[auth]++
+
+		if auth != "Bearer valid-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`))
+			return
+		}
+
+		result, _ := json.Marshal(map[string]any{
+			"certificate": string(certificate),
+			"expires_on":  "2014-01-01T05:20:00Z",
+		})
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "result": json.RawMessage(result)})
+	}))
+	defer ts.Close()
+
+	secret := func(name, token string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte(token)},
+		}
+	}
+
+	request := func(name string) *cmapi.CertificateRequest {
+		return cmgen.CertificateRequest(name,
+			cmgen.SetCertificateRequestNamespace("default"),
+			cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+			cmgen.SetCertificateRequestCSR(golden.Get(t, "csr.golden")),
+			cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+				Name:  "foobar",
+				Kind:  "OriginIssuer",
+				Group: "cert-manager.k8s.cloudflare.com",
+			}),
+		)
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			request("first"),
+			request("second"),
+			&v1.OriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar",
+					Namespace: "default",
+				},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginECC,
+					Auth: v1.OriginIssuerAuthentication{
+						Credentials: []v1.OriginIssuerCredential{
+							{
+								Name:     "primary",
+								TokenRef: &v1.SecretKeySelector{Name: "primary-token", Key: "token"},
+							},
+							{
+								Name:     "secondary",
+								TokenRef: &v1.SecretKeySelector{Name: "secondary-token", Key: "token"},
+							},
+						},
+					},
+				},
+				Status: v1.OriginIssuerStatus{
+					Conditions: []v1.OriginIssuerCondition{
+						{
+							Type:   v1.ConditionReady,
+							Status: v1.ConditionTrue,
+						},
+					},
+				},
+			},
+			secret("primary-token", "revoked-token"),
+			secret("secondary-token", "valid-token"),
+		).
+		WithStatusSubresource(&cmapi.CertificateRequest{}, &v1.OriginIssuer{}).
+		Build()
+
+	controller := &CertificateRequestController{
+		Client:   client,
+		Reader:   client,
+		Log:      logf.Log,
+		Builder:  cfapi.NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL),
+		Clock:    clock,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	issuers := &OriginIssuerController{
+		Client: client,
+		Reader: client,
+		Clock:  clock,
+		Log:    logf.Log,
+	}
+
+	issuerName := types.NamespacedName{Namespace: "default", Name: "foobar"}
+	issued := func(name string) {
+		t.Helper()
+
+		_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+		})
+		assert.NilError(t, err)
+
+		cr := &cmapi.CertificateRequest{}
+		assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, cr))
+		assert.Assert(t, cmutil.CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionTrue,
+			Reason: cmapi.CertificateRequestReasonIssued,
+		}))
+		assert.DeepEqual(t, cr.Status.Certificate, certificate)
+	}
+
+	credentialStatus := func(name string) v1.OriginIssuerCredentialStatus {
+		t.Helper()
+
+		iss := &v1.OriginIssuer{}
+		assert.NilError(t, client.Get(context.Background(), issuerName, iss))
+
+		cs := findCredentialStatus(iss.Status.Credentials, name)
+		assert.Assert(t, cs != nil, "missing status of credential %s", name)
+
+		return *cs
+	}
+
+	issued("first")
+	assert.Equal(t, requests["Bearer revoked-token"], 1)
+	assert.Equal(t, requests["Bearer valid-token"], 1)
+	assert.Equal(t, credentialStatus("primary").Reason, "Rejected")
+
+	issued("second")
+	assert.Equal(t, requests["Bearer revoked-token"], 1, "rejected credentials should be skipped")
+	assert.Equal(t, requests["Bearer valid-token"], 2)
+
+	_, err := reconcile.AsReconciler(client, issuers).Reconcile(context.Background(), reconcile.Request{NamespacedName: issuerName})
+	assert.NilError(t, err)
+	assert.Equal(t, credentialStatus("primary").Reason, "Rejected", "rejections should survive issuer reconciles")
+	assert.Equal(t, credentialStatus("secondary").Reason, "Available")
+
+	rotated := &corev1.Secret{}
+	assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "primary-token"}, rotated))
+	rotated.Data["token"] = []byte("valid-token")
+	assert.NilError(t, client.Update(context.Background(), rotated))
+
+	_, err = reconcile.AsReconciler(client, issuers).Reconcile(context.Background(), reconcile.Request{NamespacedName: issuerName})
+	assert.NilError(t, err)
+	assert.Equal(t, credentialStatus("primary").Reason, "Available", "rotated credentials should be healthy again")
+}
+
+func TestCheckCredentials(t *testing.T) {
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+	now := metav1.NewTime(clock.Now())
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "valid-token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("valid-token")},
+		}).
+		Build()
+
+	auth := v1.OriginIssuerAuthentication{
+		TokenRef: &v1.SecretKeySelector{Name: "valid-token", Key: "token"},
+		Credentials: []v1.OriginIssuerCredential{
+			{
+				Name:     "missing",
+				TokenRef: &v1.SecretKeySelector{Name: "missing-token", Key: "token"},
+			},
+			{
+				Name:     "valid",
+				TokenRef: &v1.SecretKeySelector{Name: "valid-token", Key: "token"},
+			},
+		},
+	}
+
+	status := v1.OriginIssuerStatus{}
+	assert.NilError(t, checkCredentials(context.Background(), client, nil, clock, auth, &status, issuerNamespace{name: "default"}))
+	assert.DeepEqual(t, status.Credentials, []v1.OriginIssuerCredentialStatus{
+		{
+			Name:               "spec.auth",
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: &now,
+			Reason:             "Available",
+			Message:            "Credential is available",
+			ObservedVersion:    "999",
+		},
+		{
+			Name:               "missing",
+			Status:             v1.ConditionFalse,
+			LastTransitionTime: &now,
+			Reason:             "NotFound",
+			Message:            `Failed to retrieve auth secret: secrets "missing-token" not found`,
+		},
+		{
+			Name:               "valid",
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: &now,
+			Reason:             "Available",
+			Message:            "Credential is available",
+			ObservedVersion:    "999",
+		},
+	})
+
+	auth.TokenRef = nil
+	auth.Credentials = auth.Credentials[:1]
+	assert.Error(t, checkCredentials(context.Background(), client, nil, clock, auth, &status, issuerNamespace{name: "default"}), `secrets "missing-token" not found`)
+	assert.Equal(t, len(status.Credentials), 1, "statuses of removed credentials should be pruned")
+}
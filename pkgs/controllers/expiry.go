@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// expiryListTimeout bounds how long a scrape waits for the certificates to be listed.
+const expiryListTimeout = 10 * time.Second
+
+var (
+	expiryCertificatesDesc = prometheus.NewDesc(
+		"origin_ca_issuer_certificates",
+		"Number of unexpired certificates issued by the controller, by namespace and issuer.",
+		[]string{"namespace", "issuer_kind", "issuer_name"}, nil,
+	)
+
+	expiryDaysDesc = prometheus.NewDesc(
+		"origin_ca_issuer_certificate_expiry_days",
+		"Days until the first of the unexpired certificates issued by the controller expires, by namespace and issuer.",
+		[]string{"namespace", "issuer_kind", "issuer_name"}, nil,
+	)
+
+	expiryExpiringDesc = prometheus.NewDesc(
+		"origin_ca_issuer_certificates_expiring",
+		"Number of unexpired certificates issued by the controller expiring within the window, by namespace and issuer.",
+		[]string{"namespace", "issuer_kind", "issuer_name", "window"}, nil,
+	)
+)
+
+// +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=origincertificaterecords,verbs=get;list;watch
+
+// CertificateExpiryCollector is a Prometheus collector exposing the expiry of the certificates issued by
+// the controller, as recorded by OriginCertificateRecords and the status of OriginCertificates. Only the
+// latest certificate issued for each Certificate, OriginCertificate or other resource is collected, unless
+// it was revoked. Certificates are listed when metrics are collected, so Reader should be backed by a cache.
+type CertificateExpiryCollector struct {
+	Reader client.Reader
+	Log    logr.Logger
+	Clock  clock.Clock
+
+	// Records enables collecting the certificates recorded by OriginCertificateRecords.
+	Records bool
+
+	// OriginCertificates enables collecting the certificates of OriginCertificates.
+	OriginCertificates bool
+
+	// Windows are the durations certificates expiring within are counted for.
+	Windows []time.Duration
+}
+
+// expiryKey groups certificates by the namespace they were issued for and their issuer.
+type expiryKey struct {
+	namespace, kind, name string
+}
+
+// expiryGroup summarizes the unexpired certificates of an expiryKey.
+type expiryGroup struct {
+	certificates int
+	first        time.Time
+	expiring     []int
+}
+
+// Describe implements prometheus.Collector.
+func (c *CertificateExpiryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- expiryCertificatesDesc
+	ch <- expiryDaysDesc
+	ch <- expiryExpiringDesc
+}
+
+// Collect implements prometheus.Collector. Errors listing certificates are logged, and the affected
+// certificates omitted, so the remaining metrics can still be scraped.
+func (c *CertificateExpiryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), expiryListTimeout)
+	defer cancel()
+
+	now := c.Clock.Now()
+	groups := make(map[expiryKey]*expiryGroup)
+	add := func(key expiryKey, notAfter time.Time) {
+		if !notAfter.After(now) {
+			return
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &expiryGroup{first: notAfter, expiring: make([]int, len(c.Windows))}
+			groups[key] = g
+		}
+
+		g.certificates++
+		if notAfter.Before(g.first) {
+			g.first = notAfter
+		}
+
+		for i, window := range c.Windows {
+			if notAfter.Sub(now) <= window {
+				g.expiring[i]++
+			}
+		}
+	}
+
+	// OriginCertificates with a record are collected from the record, so they are only counted once.
+	recorded := make(map[string]bool)
+
+	if c.Records {
+		records := &v1.OriginCertificateRecordList{}
+		if err := c.Reader.List(ctx, records); err != nil {
+			c.Log.Error(err, "failed to list OriginCertificateRecords for expiry metrics")
+		}
+
+		for _, r := range latestRecords(records.Items) {
+			if r.Spec.Source.Kind == "OriginCertificate" {
+				recorded[r.Spec.Source.Namespace+"/"+r.Spec.Source.Name] = true
+			}
+
+			if r.Status.RevocationTime != nil {
+				continue
+			}
+
+			key := expiryKey{r.Spec.Source.Namespace, cmp.Or(r.Spec.IssuerRef.Kind, "OriginIssuer"), r.Spec.IssuerRef.Name}
+			add(key, r.Spec.NotAfter.Time)
+		}
+	}
+
+	if c.OriginCertificates {
+		certificates := &v1.OriginCertificateList{}
+		if err := c.Reader.List(ctx, certificates); err != nil {
+			c.Log.Error(err, "failed to list OriginCertificates for expiry metrics")
+		}
+
+		for _, oc := range certificates.Items {
+			if oc.Status.NotAfter == nil || recorded[oc.Namespace+"/"+oc.Name] {
+				continue
+			}
+
+			key := expiryKey{oc.Namespace, cmp.Or(oc.Spec.IssuerRef.Kind, "OriginIssuer"), oc.Spec.IssuerRef.Name}
+			add(key, oc.Status.NotAfter.Time)
+		}
+	}
+
+	for key, g := range groups {
+		ch <- prometheus.MustNewConstMetric(expiryCertificatesDesc, prometheus.GaugeValue, float64(g.certificates), key.namespace, key.kind, key.name)
+		ch <- prometheus.MustNewConstMetric(expiryDaysDesc, prometheus.GaugeValue, g.first.Sub(now).Hours()/24, key.namespace, key.kind, key.name)
+
+		for i, window := range c.Windows {
+			ch <- prometheus.MustNewConstMetric(expiryExpiringDesc, prometheus.GaugeValue, float64(g.expiring[i]), key.namespace, key.kind, key.name, windowLabel(window))
+		}
+	}
+}
+
+// windowLabel formats window as a number of days when it is a whole number of days, such as "30d".
+func windowLabel(window time.Duration) string {
+	if window%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", window/(24*time.Hour))
+	}
+
+	return window.String()
+}
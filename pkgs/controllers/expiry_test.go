@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakeClock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCertificateExpiryCollector(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	day := 24 * time.Hour
+
+	record := func(name, namespace, issuer string, expiresIn time.Duration) *v1.OriginCertificateRecord {
+		return &v1.OriginCertificateRecord{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.OriginCertificateRecordSpec{
+				NotAfter:  metav1.NewTime(clock.Now().Add(expiresIn)),
+				IssuerRef: v1.IssuerReference{Name: issuer, Kind: "ClusterOriginIssuer"},
+				Source:    v1.RecordSource{Kind: "CertificateRequest", Namespace: namespace, Name: name},
+			},
+		}
+	}
+
+	renewed := func(name, certificate string, issuedAgo, expiresIn time.Duration) *v1.OriginCertificateRecord {
+		r := record(name, "default", "prod", expiresIn)
+		r.Spec.NotBefore = metav1.NewTime(clock.Now().Add(-issuedAgo))
+		r.Spec.Source.Certificate = certificate
+
+		return r
+	}
+
+	revoked := record("j", "default", "prod", day)
+	revoked.Status.RevocationTime = &metav1.Time{Time: clock.Now()}
+
+	ocRecord := record("k", "default", "local", 2*day)
+	ocRecord.Spec.IssuerRef = v1.IssuerReference{Name: "local"}
+	ocRecord.Spec.Source = v1.RecordSource{Kind: "OriginCertificate", Namespace: "default", Name: "f"}
+
+	objects := []runtime.Object{
+		record("a", "default", "prod", 5*day),
+		record("b", "default", "prod", 20*day),
+		record("c", "default", "prod", 400*day),
+		record("d", "default", "prod", -day),
+		record("e", "tenant", "prod", 60*day),
+		renewed("h", "web", 90*day, 3*day),
+		renewed("i", "web", day, 300*day),
+		revoked,
+		ocRecord,
+		&v1.OriginCertificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "f", Namespace: "default"},
+			Spec:       v1.OriginCertificateSpec{IssuerRef: v1.IssuerReference{Name: "local"}},
+			Status:     v1.OriginCertificateStatus{NotAfter: &metav1.Time{Time: clock.Now().Add(2 * day)}},
+		},
+		&v1.OriginCertificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "g", Namespace: "default"},
+			Spec:       v1.OriginCertificateSpec{IssuerRef: v1.IssuerReference{Name: "local"}},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		records            bool
+		originCertificates bool
+		expected           string
+	}{
+		{
+			name:               "records and OriginCertificates",
+			records:            true,
+			originCertificates: true,
+			expected: `
+# HELP origin_ca_issuer_certificate_expiry_days Days until the first of the unexpired certificates issued by the controller expires, by namespace and issuer.
+# TYPE origin_ca_issuer_certificate_expiry_days gauge
+origin_ca_issuer_certificate_expiry_days{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="default"} 5
+origin_ca_issuer_certificate_expiry_days{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="tenant"} 60
+origin_ca_issuer_certificate_expiry_days{issuer_kind="OriginIssuer",issuer_name="local",namespace="default"} 2
+# HELP origin_ca_issuer_certificates Number of unexpired certificates issued by the controller, by namespace and issuer.
+# TYPE origin_ca_issuer_certificates gauge
+origin_ca_issuer_certificates{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="default"} 4
+origin_ca_issuer_certificates{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="tenant"} 1
+origin_ca_issuer_certificates{issuer_kind="OriginIssuer",issuer_name="local",namespace="default"} 1
+# HELP origin_ca_issuer_certificates_expiring Number of unexpired certificates issued by the controller expiring within the window, by namespace and issuer.
+# TYPE origin_ca_issuer_certificates_expiring gauge
+origin_ca_issuer_certificates_expiring{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="default",window="30d"} 2
+origin_ca_issuer_certificates_expiring{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="default",window="7d"} 1
+origin_ca_issuer_certificates_expiring{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="tenant",window="30d"} 0
+origin_ca_issuer_certificates_expiring{issuer_kind="ClusterOriginIssuer",issuer_name="prod",namespace="tenant",window="7d"} 0
+origin_ca_issuer_certificates_expiring{issuer_kind="OriginIssuer",issuer_name="local",namespace="default",window="30d"} 1
+origin_ca_issuer_certificates_expiring{issuer_kind="OriginIssuer",issuer_name="local",namespace="default",window="7d"} 1
+`,
+		},
+		{
+			name:               "OriginCertificates only",
+			originCertificates: true,
+			expected: `
+# HELP origin_ca_issuer_certificate_expiry_days Days until the first of the unexpired certificates issued by the controller expires, by namespace and issuer.
+# TYPE origin_ca_issuer_certificate_expiry_days gauge
+origin_ca_issuer_certificate_expiry_days{issuer_kind="OriginIssuer",issuer_name="local",namespace="default"} 2
+# HELP origin_ca_issuer_certificates Number of unexpired certificates issued by the controller, by namespace and issuer.
+# TYPE origin_ca_issuer_certificates gauge
+origin_ca_issuer_certificates{issuer_kind="OriginIssuer",issuer_name="local",namespace="default"} 1
+# HELP origin_ca_issuer_certificates_expiring Number of unexpired certificates issued by the controller expiring within the window, by namespace and issuer.
+# TYPE origin_ca_issuer_certificates_expiring gauge
+origin_ca_issuer_certificates_expiring{issuer_kind="OriginIssuer",issuer_name="local",namespace="default",window="30d"} 1
+origin_ca_issuer_certificates_expiring{issuer_kind="OriginIssuer",issuer_name="local",namespace="default",window="7d"} 1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(objects...).
+				Build()
+
+			collector := &CertificateExpiryCollector{
+				Reader:             client,
+				Log:                logf.Log,
+				Clock:              clock,
+				Records:            tt.records,
+				OriginCertificates: tt.originCertificates,
+				Windows:            []time.Duration{7 * day, 30 * day},
+			}
+
+			assert.NilError(t, testutil.CollectAndCompare(collector, strings.NewReader(tt.expected)))
+		})
+	}
+}
+
+func TestWindowLabel(t *testing.T) {
+	assert.Equal(t, windowLabel(30*24*time.Hour), "30d")
+	assert.Equal(t, windowLabel(36*time.Hour), "36h0m0s")
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"github.com/cloudflare/origin-ca-issuer/internal/credfile"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IssuerClientConfig selects the issuer, and the credential of the issuer, IssuerClient authenticates with.
+type IssuerClientConfig struct {
+	// Kind of the issuer, either OriginIssuer or ClusterOriginIssuer.
+	Kind string
+	Name string
+
+	// Namespace of an OriginIssuer.
+	Namespace string
+
+	// Credential is the name of the credential to authenticate with, as listed in
+	// spec.auth.credentials. If empty, the first credential is used.
+	Credential string
+
+	// ClusterResourceNamespace and ClusterSecretNamespaces are the namespaces a
+	// ClusterOriginIssuer reads Secrets from, as configured on the controller.
+	ClusterResourceNamespace string
+	ClusterSecretNamespaces  []string
+
+	// Endpoint overrides the API endpoint of the issuer, if set.
+	Endpoint string
+
+	// Files reads credential files referenced by the issuer. If nil, credentials read from
+	// files are not supported.
+	Files *credfile.Store
+}
+
+// IssuerClient builds a Cloudflare API client authenticating with a credential of an issuer, and
+// configured with the API settings of the issuer, as the controller would. Unlike the controller, the
+// issuer does not need to be ready. Credentials read from files are only supported when cfg.Files is
+// set, as the files are only available to the controller.
+func IssuerClient(ctx context.Context, reader client.Reader, b *cfapi.Builder, cfg IssuerClientConfig) (*cfapi.Client, v1.OriginIssuerSpec, error) {
+	var spec v1.OriginIssuerSpec
+	var namespace issuerNamespace
+
+	switch cfg.Kind {
+	case "OriginIssuer":
+		oi := v1.OriginIssuer{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.Name}, &oi); err != nil {
+			return nil, spec, err
+		}
+
+		spec, namespace = oi.Spec, originIssuerNamespace(cfg.Namespace)
+	case "ClusterOriginIssuer":
+		coi := v1.ClusterOriginIssuer{}
+		if err := reader.Get(ctx, types.NamespacedName{Name: cfg.Name}, &coi); err != nil {
+			return nil, spec, err
+		}
+
+		spec, namespace = coi.Spec, issuerNamespace{name: cfg.ClusterResourceNamespace, allowed: cfg.ClusterSecretNamespaces}
+	default:
+		return nil, spec, fmt.Errorf("unknown issuer kind: %s", cfg.Kind)
+	}
+
+	creds := IssuerCredentials(spec.Auth)
+	i := 0
+	if cfg.Credential != "" {
+		i = slices.IndexFunc(creds, func(c v1.OriginIssuerCredential) bool { return c.Name == cfg.Credential })
+	}
+
+	if i < 0 || i >= len(creds) {
+		if cfg.Credential != "" {
+			return nil, spec, fmt.Errorf("issuer %s does not have a credential named %q", cfg.Name, cfg.Credential)
+		}
+
+		return nil, spec, fmt.Errorf("issuer %s does not have an authentication method configured", cfg.Name)
+	}
+	cred := creds[i]
+
+	ref := secretRef(cred)
+	if ref == nil && cfg.Files == nil {
+		return nil, spec, fmt.Errorf("credential %s of issuer %s is read from a file, which is only available to the controller", credentialName(cred), cfg.Name)
+	}
+
+	if ref != nil && namespace.name == "" && ref.Namespace == "" {
+		return nil, spec, fmt.Errorf("the cluster resource namespace must be set to read the secrets of ClusterOriginIssuer %s", cfg.Name)
+	}
+
+	value, _, err := readCredential(ctx, reader, cfg.Files, cred, namespace)
+	if err != nil {
+		return nil, spec, err
+	}
+
+	b = b.Clone()
+	if cred.ServiceKeyRef != nil || cred.ServiceKeyFile != "" {
+		b.WithServiceKey(value)
+	} else {
+		b.WithToken(value)
+	}
+
+	if err := configureAPI(ctx, reader, b, spec.API, namespace); err != nil {
+		return nil, spec, fmt.Errorf("failed to configure the API of issuer %s: %w", cfg.Name, err)
+	}
+
+	if cfg.Endpoint != "" {
+		b.WithEndpoint(cfg.Endpoint)
+	}
+
+	c, err := b.Build()
+	if err != nil {
+		return nil, spec, err
+	}
+
+	return c, spec, nil
+}
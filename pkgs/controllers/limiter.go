@@ -0,0 +1,48 @@
+package controllers
+
+import "sync"
+
+// issuerLimiter bounds the number of concurrent operations performed on behalf
+// of a single issuer. The zero value is ready to use.
+type issuerLimiter struct {
+	mu     sync.Mutex
+	active map[string]int
+}
+
+// tryAcquire reserves a slot for the issuer identified by key, returning false
+// if limit slots are already in use. A limit of zero or less is unbounded.
+func (l *issuerLimiter) tryAcquire(key string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active == nil {
+		l.active = make(map[string]int)
+	}
+
+	if l.active[key] >= limit {
+		return false
+	}
+
+	l.active[key]++
+
+	return true
+}
+
+// release frees a slot previously reserved with tryAcquire.
+func (l *issuerLimiter) release(key string, limit int) {
+	if limit <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active[key]--
+	if l.active[key] <= 0 {
+		delete(l.active, key)
+	}
+}
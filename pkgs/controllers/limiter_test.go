@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIssuerLimiter(t *testing.T) {
+	var l issuerLimiter
+
+	assert.Assert(t, l.tryAcquire("OriginIssuer/default/foo", 2))
+	assert.Assert(t, l.tryAcquire("OriginIssuer/default/foo", 2))
+	assert.Assert(t, !l.tryAcquire("OriginIssuer/default/foo", 2), "limit should be enforced")
+	assert.Assert(t, l.tryAcquire("OriginIssuer/default/bar", 2), "issuers should be limited independently")
+
+	l.release("OriginIssuer/default/foo", 2)
+	assert.Assert(t, l.tryAcquire("OriginIssuer/default/foo", 2), "released slots should be reusable")
+
+	for i := 0; i < 10; i++ {
+		assert.Assert(t, l.tryAcquire("ClusterOriginIssuer//baz", 0), "zero limit should be unbounded")
+	}
+}
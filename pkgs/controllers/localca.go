@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create
+
+// localCAValidity is the validity of generated local CAs, longer than the longest validity of
+// Origin CA certificates.
+const localCAValidity = 20 * 365 * 24 * time.Hour
+
+// LocalCA provides the CA issuers in the Local signing mode sign certificates with, stored as a
+// kubernetes.io/tls Secret. If the Secret does not exist, a CA is generated and stored in it, so a
+// CA can also be provided by creating the Secret beforehand.
+type LocalCA struct {
+	Client client.Client
+	Reader client.Reader
+	Clock  clock.Clock
+
+	// Secret is the Secret the CA is stored in.
+	Secret types.NamespacedName
+
+	mu      sync.Mutex
+	version string
+	cert    *x509.Certificate
+	key     crypto.Signer
+}
+
+// CA returns the CA certificate and key, reloading them when the Secret changes.
+func (ca *LocalCA) CA(ctx context.Context) (*x509.Certificate, crypto.Signer, error) {
+	if ca.Secret.Namespace == "" {
+		return nil, nil, errors.New("the cluster resource namespace must be set to store the local CA")
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	var secret core.Secret
+	err := ca.Reader.Get(ctx, ca.Secret, &secret)
+	if apierrors.IsNotFound(err) {
+		err = ca.create(ctx, &secret)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve local CA secret %s: %w", ca.Secret, err)
+	}
+
+	if ca.cert != nil && secret.ResourceVersion == ca.version {
+		return ca.cert, ca.key, nil
+	}
+
+	pair, err := tls.X509KeyPair(secret.Data[core.TLSCertKey], secret.Data[core.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse local CA secret %s: %w", ca.Secret, err)
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse local CA secret %s: %w", ca.Secret, err)
+	}
+
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("failed to parse local CA secret %s: unsupported private key", ca.Secret)
+	}
+
+	ca.version, ca.cert, ca.key = secret.ResourceVersion, cert, key
+
+	return ca.cert, ca.key, nil
+}
+
+// create generates a CA and stores it in the Secret. If the Secret was created concurrently, it is
+// retrieved instead.
+func (ca *LocalCA) create(ctx context.Context, secret *core.Secret) error {
+	certPEM, keyPEM, err := generateLocalCA(ca.Clock.Now())
+	if err != nil {
+		return err
+	}
+
+	*secret = core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ca.Secret.Namespace, Name: ca.Secret.Name},
+		Type:       core.SecretTypeTLS,
+		Data: map[string][]byte{
+			core.TLSCertKey:       certPEM,
+			core.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+
+	err = ca.Client.Create(ctx, secret)
+	if apierrors.IsAlreadyExists(err) {
+		return ca.Reader.Get(ctx, ca.Secret, secret)
+	}
+
+	return err
+}
+
+// generateLocalCA returns the PEM-encoded certificate and key of a new self-signed CA, named like the
+// Origin CA.
+func generateLocalCA(now time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 159))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		// The subject of the CA must not be mistaken for the Origin CA, only the certificates it
+		// signs are shaped like those of the Origin CA.
+		Subject: pkix.Name{
+			Organization:       []string{"origin-ca-issuer"},
+			OrganizationalUnit: []string{"Local Staging CA"},
+			CommonName:         "origin-ca-issuer local staging CA",
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(localCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), nil
+}
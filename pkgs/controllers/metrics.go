@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	inventoryCertificates = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "origin_ca_issuer_inventory_certificates",
+		Help: "Number of unexpired Origin CA certificates listed by an OriginInventory, by state: tracked, orphaned or expiring.",
+	}, []string{"namespace", "name", "state"})
+
+	inventoryRevocations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "origin_ca_issuer_inventory_revocations_total",
+		Help: "Number of orphaned Origin CA certificates revoked by an OriginInventory.",
+	}, []string{"namespace", "name"})
+
+	inventoryLastSync = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "origin_ca_issuer_inventory_last_sync_timestamp_seconds",
+		Help: "Time the certificates of an OriginInventory were last listed, as seconds since the Unix epoch.",
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(inventoryCertificates, inventoryRevocations, inventoryLastSync)
+}
+
+// deleteInventoryMetrics deletes the metrics of the OriginInventory in namespace with name.
+func deleteInventoryMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+
+	inventoryCertificates.DeletePartialMatch(labels)
+	inventoryRevocations.DeletePartialMatch(labels)
+	inventoryLastSync.DeletePartialMatch(labels)
+}
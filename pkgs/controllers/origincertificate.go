@@ -0,0 +1,583 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"github.com/go-logr/logr"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// IssuerNameAnnotation records the name of the issuer that signed the certificate in
+	// a Secret written by the OriginCertificate controller.
+	IssuerNameAnnotation = "cert-manager.k8s.cloudflare.com/issuer-name"
+
+	// IssuerKindAnnotation records the kind of the issuer that signed the certificate in
+	// a Secret written by the OriginCertificate controller.
+	IssuerKindAnnotation = "cert-manager.k8s.cloudflare.com/issuer-kind"
+
+	// IssuanceHashAnnotation records a hash of the parameters the certificate was issued with,
+	// such as the requested duration and the issuer's request type, in a Secret written by
+	// the OriginCertificate controller. The certificate is reissued when they change.
+	IssuanceHashAnnotation = "cert-manager.k8s.cloudflare.com/issuance-hash"
+)
+
+// OriginCertificateController implements a controller that issues and renews the
+// certificates requested by OriginCertificate resources, without cert-manager.
+type OriginCertificateController struct {
+	client.Client
+	Reader                   client.Reader
+	ClusterResourceNamespace string
+	DisableClusterIssuers    bool
+	Log                      logr.Logger
+	Builder                  *cfapi.Builder
+	Recorder                 record.EventRecorder
+	Clock                    clock.Clock
+
+	SigningSettings
+
+	// ClusterSecretNamespaces lists the namespaces, besides ClusterResourceNamespace,
+	// that ClusterOriginIssuers may select secrets from.
+	ClusterSecretNamespaces []string
+
+	quotas  Quotas
+	clients clientCache
+}
+
+// +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=origincertificates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=origincertificates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;update
+
+// Reconcile reconciles OriginCertificate resources by issuing a certificate when the Secret does
+// not hold an up to date one, and scheduling its renewal.
+func (r *OriginCertificateController) Reconcile(ctx context.Context, crt *v1.OriginCertificate) (reconcile.Result, error) {
+	log := r.Log.WithValues("namespace", crt.Namespace, "origincertificate", crt.Name)
+
+	if err := validateOriginCertificate(crt.Spec); err != nil {
+		log.Error(err, "failed to validate OriginCertificate resource")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Invalid", fmt.Sprintf("Invalid OriginCertificate: %v", err))
+
+		return reconcile.Result{}, nil
+	}
+
+	kind := cmp.Or(crt.Spec.IssuerRef.Kind, "OriginIssuer")
+	if r.DisableClusterIssuers && kind == "ClusterOriginIssuer" {
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Invalid", "OriginCertificate references a ClusterOriginIssuer, but cluster issuers are disabled")
+
+		return reconcile.Result{}, nil
+	}
+
+	secret := &core.Secret{}
+	err := r.Reader.Get(ctx, types.NamespacedName{Namespace: crt.Namespace, Name: crt.Spec.SecretName}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = nil
+	case err != nil:
+		log.Error(err, "failed to retrieve certificate secret")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve secret %s: %v", crt.Spec.SecretName, err))
+
+		return reconcile.Result{}, err
+	case secret.Type != core.SecretTypeTLS:
+		err := fmt.Errorf("secret %s has type %s, not %s", secret.Name, secret.Type, core.SecretTypeTLS)
+		log.Error(err, "cannot write certificate to secret")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Error", fmt.Sprintf("Cannot write certificate to secret: %v", err))
+
+		return reconcile.Result{}, err
+	case !metav1.IsControlledBy(secret, crt):
+		err := fmt.Errorf("secret %s is not owned by OriginCertificate %s", secret.Name, crt.Name)
+		log.Error(err, "cannot write certificate to secret")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Error", fmt.Sprintf("Cannot write certificate to secret: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	signer := r.signer()
+
+	// The issuer is looked up only to compute the issuance hash, an up to date certificate remains
+	// ready even if the issuer is not.
+	var hash string
+	if iss, err := signer.lookupIssuer(ctx, log, kind, crt.Spec.IssuerRef.Name, crt.Namespace); err == nil {
+		hash = r.issuanceHash(crt.Spec, iss.spec)
+	}
+
+	cert, reason := r.issuanceReason(crt, secret, hash)
+
+	// A pending certificate the Secret does not hold was issued by a reconcile that failed to write
+	// the Secret, and is revoked so that certificates are not leaked by retries.
+	if pending := crt.Status.PendingCertificate; pending != nil && (cert == nil || serialNumber(cert) != pending.SerialNumber) {
+		if err := r.revokePending(ctx, log, signer, crt, kind); err != nil {
+			log.Error(err, "failed to revoke pending certificate", "id", pending.ID)
+			reason, message := statusReason(err)
+			_ = r.setStatus(ctx, crt, v1.ConditionFalse, reason, message)
+
+			if retryAfter, open := circuitOpen(err); open {
+				return reconcile.Result{RequeueAfter: retryAfter}, nil
+			}
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	if reason == "" {
+		renewal := renewalTime(crt.Spec, cert)
+		_ = r.setIssued(ctx, crt, cert, renewal, "Certificate is up to date and has not expired")
+
+		return reconcile.Result{RequeueAfter: renewal.Sub(r.Clock.Now())}, nil
+	}
+
+	log.Info("issuing certificate", "reason", reason)
+
+	iss, err := signer.getIssuer(ctx, log, kind, crt.Spec.IssuerRef.Name, crt.Namespace)
+	if err != nil {
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, reason, message)
+
+		if retryAfter, open := circuitOpen(err); open {
+			return reconcile.Result{RequeueAfter: retryAfter}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	key, csr, err := generateCSR(iss.spec.RequestType, crt.Spec.DNSNames)
+	if err != nil {
+		log.Error(err, "failed to generate certificate signing request")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to generate certificate signing request: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	var duration time.Duration
+	if crt.Spec.Duration != nil {
+		duration = crt.Spec.Duration.Duration
+	}
+
+	if iss.spec.Quota != nil && !r.RecordCertificates {
+		err := errors.New("issuer quota cannot be enforced without certificate records")
+		log.Error(err, "failed to check issuer quota")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "QuotaUnavailable", quotaUnavailableMessage)
+
+		return reconcile.Result{}, err
+	}
+
+	reservation, exceeded, err := signer.reserveQuota(ctx, iss, crt.Namespace, originCertificateSource(crt), duration)
+	if err != nil {
+		log.Error(err, "failed to count certificates issued by issuer")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to count certificates issued by issuer: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	if exceeded != nil {
+		log.Info("issuer quota exceeded, requeue-ing", "reason", exceeded.message, "retryAfter", exceeded.retryAfter)
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "QuotaExceeded", fmt.Sprintf("Issuer quota exceeded: %s", exceeded.message))
+
+		return reconcile.Result{RequeueAfter: exceeded.retryAfter}, nil
+	}
+
+	resp, err := signer.issue(ctx, log, crt, iss, csr, duration)
+	if err != nil {
+		reservation.release()
+	} else {
+		reservation.confirm(resp)
+	}
+
+	var openErr *provisioners.CircuitOpenError
+	if errors.As(err, &openErr) {
+		log.Info("Cloudflare API is unavailable, requeue-ing", "reason", openErr.Err.Error(), "retryAfter", openErr.RetryAfter)
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, apiUnavailableReason, openErr.Error())
+
+		return reconcile.Result{RequeueAfter: openErr.RetryAfter}, nil
+	}
+
+	if err != nil {
+		log.Error(err, "failed to sign certificate")
+
+		var serr *statusError
+		if errors.As(err, &serr) {
+			_ = r.setStatus(ctx, crt, v1.ConditionFalse, serr.reason, serr.message)
+		} else {
+			r.Recorder.Event(crt, core.EventTypeWarning, "Failed", fmt.Sprintf("Failed to sign certificate: %v", err))
+			_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Failed", fmt.Sprintf("Failed to sign certificate: %v", err))
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	certPEM := []byte(resp.Certificate)
+	cert, err = pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		log.Error(err, "failed to decode signed certificate")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to decode signed certificate: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	if r.RecordCertificates {
+		r.record(ctx, log, crt, iss, resp)
+	}
+
+	// The certificate is recorded as pending until the Secret is written, so that it is revoked
+	// rather than leaked if writing the Secret fails.
+	pending := &v1.PendingCertificate{ID: resp.Id, SerialNumber: serialNumber(cert)}
+	crt.Status.PendingCertificate = pending
+	if err := r.Client.Status().Update(ctx, crt); err != nil {
+		log.Error(err, "failed to record pending certificate")
+		if err := r.revoke(ctx, log, signer, crt, iss, pending); err != nil {
+			log.Error(err, "failed to revoke certificate", "id", pending.ID)
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if err := r.writeSecret(ctx, crt, secret, kind, r.issuanceHash(crt.Spec, iss.spec), certPEM, key); err != nil {
+		log.Error(err, "failed to write certificate secret")
+		_ = r.setStatus(ctx, crt, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to write secret %s: %v", crt.Spec.SecretName, err))
+
+		return reconcile.Result{}, err
+	}
+
+	r.Recorder.Eventf(crt, core.EventTypeNormal, "Issued", "Certificate issued, valid until %s", cert.NotAfter.Format(time.RFC3339))
+
+	renewal := renewalTime(crt.Spec, cert)
+	if err := r.setIssued(ctx, crt, cert, renewal, "Certificate issued"); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: renewal.Sub(r.Clock.Now())}, nil
+}
+
+// issuanceReason returns the certificate held by secret, and why a new certificate must be issued
+// for crt. The reason is empty if the certificate is up to date. The issuance hash recorded in the
+// Secret is compared with hash, unless it is empty because the issuer could not be retrieved.
+func (r *OriginCertificateController) issuanceReason(crt *v1.OriginCertificate, secret *core.Secret, hash string) (*x509.Certificate, string) {
+	if secret == nil {
+		return nil, "Secret does not exist"
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[core.TLSCertKey])
+	if err != nil {
+		return nil, "Secret does not contain a valid certificate"
+	}
+
+	key, err := pki.DecodePrivateKeyBytes(secret.Data[core.TLSPrivateKeyKey])
+	if err != nil {
+		return cert, "Secret does not contain a valid private key"
+	}
+
+	if matches, err := pki.PublicKeyMatchesCertificate(key.Public(), cert); err != nil || !matches {
+		return cert, "Private key does not match the certificate"
+	}
+
+	if secret.Annotations[IssuerNameAnnotation] != crt.Spec.IssuerRef.Name || secret.Annotations[IssuerKindAnnotation] != cmp.Or(crt.Spec.IssuerRef.Kind, "OriginIssuer") {
+		return cert, "Issuer changed"
+	}
+
+	if !sameDNSNames(cert.DNSNames, crt.Spec.DNSNames) {
+		return cert, "DNS names changed"
+	}
+
+	if hash != "" && secret.Annotations[IssuanceHashAnnotation] != hash {
+		return cert, "Duration or issuer request type changed"
+	}
+
+	if !r.Clock.Now().Before(renewalTime(crt.Spec, cert)) {
+		return cert, "Certificate is due for renewal"
+	}
+
+	return cert, ""
+}
+
+// writeSecret writes the certificate and private key to the OriginCertificate's Secret, creating
+// it if existing is nil.
+func (r *OriginCertificateController) writeSecret(ctx context.Context, crt *v1.OriginCertificate, existing *core.Secret, kind, hash string, certPEM []byte, key crypto.Signer) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	secret := existing
+	if secret == nil {
+		secret = &core.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      crt.Spec.SecretName,
+				Namespace: crt.Namespace,
+			},
+			Type: core.SecretTypeTLS,
+		}
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[core.TLSCertKey] = certPEM
+	secret.Data[core.TLSPrivateKeyKey] = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, IssuerNameAnnotation, crt.Spec.IssuerRef.Name)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, IssuerKindAnnotation, kind)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, IssuanceHashAnnotation, hash)
+
+	if err := controllerutil.SetControllerReference(crt, secret, r.Client.Scheme()); err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return r.Client.Create(ctx, secret)
+	}
+
+	return r.Client.Update(ctx, secret)
+}
+
+// record creates the OriginCertificateRecord of the certificate issued for the OriginCertificate. As the
+// certificate is already issued, failures are only logged and recorded as events.
+func (r *OriginCertificateController) record(ctx context.Context, log logr.Logger, crt *v1.OriginCertificate, iss *issuer, resp *cfapi.SignResponse) {
+	err := createCertificateRecord(ctx, r.Client, iss, resp, originCertificateSource(crt))
+	if err != nil {
+		log.Error(err, "failed to record issued certificate")
+		r.Recorder.Event(crt, core.EventTypeWarning, "RecordFailed", fmt.Sprintf("Failed to record issued certificate: %v", err))
+	}
+}
+
+// revokePending revokes the pending certificate of the OriginCertificate, which was issued but not
+// written to its Secret, and clears it from the status.
+func (r *OriginCertificateController) revokePending(ctx context.Context, log logr.Logger, signer *issuerSigner, crt *v1.OriginCertificate, kind string) error {
+	iss, err := signer.getIssuer(ctx, log, kind, crt.Spec.IssuerRef.Name, crt.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := r.revoke(ctx, log, signer, crt, iss, crt.Status.PendingCertificate); err != nil {
+		return err
+	}
+
+	crt.Status.PendingCertificate = nil
+
+	return r.Client.Status().Update(ctx, crt)
+}
+
+// revoke revokes the pending certificate issued by iss for the OriginCertificate, and records the
+// revocation in the certificate's record. Certificates signed locally are not known to the Cloudflare
+// API, so they are not revoked.
+func (r *OriginCertificateController) revoke(ctx context.Context, log logr.Logger, signer *issuerSigner, crt *v1.OriginCertificate, iss *issuer, pending *v1.PendingCertificate) error {
+	if signsLocally(iss.spec, r.LocalSigning, r.AllowLocalSigningMode) {
+		return nil
+	}
+
+	err := signer.withClient(ctx, log, crt, iss, func(c *cfapi.Client) error {
+		return c.Revoke(ctx, pending.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info("revoked certificate not written to secret", "id", pending.ID, "serial", pending.SerialNumber)
+	r.Recorder.Eventf(crt, core.EventTypeNormal, "Revoked", "Revoked certificate %s, which was not written to secret %s", pending.ID, crt.Spec.SecretName)
+
+	if r.RecordCertificates {
+		rec := &v1.OriginCertificateRecord{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: pending.SerialNumber}, rec)
+		if err == nil {
+			err = revokeCertificateRecord(ctx, r.Client, rec, r.Clock.Now())
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to record revoked certificate", "id", pending.ID)
+		}
+	}
+
+	return nil
+}
+
+// originCertificateSource returns the source recorded for certificates issued for the OriginCertificate.
+func originCertificateSource(crt *v1.OriginCertificate) v1.RecordSource {
+	return v1.RecordSource{
+		APIGroup:  v1.GroupVersion.Group,
+		Kind:      "OriginCertificate",
+		Namespace: crt.Namespace,
+		Name:      crt.Name,
+		UID:       crt.UID,
+	}
+}
+
+// IssuerCertificates maps an OriginIssuer or ClusterOriginIssuer to the OriginCertificates referencing
+// it, so their certificates are reissued when the issuer's request type or signing mode changes.
+func (r *OriginCertificateController) IssuerCertificates(ctx context.Context, obj client.Object) []reconcile.Request {
+	kind := "OriginIssuer"
+	if _, ok := obj.(*v1.ClusterOriginIssuer); ok {
+		kind = "ClusterOriginIssuer"
+	}
+
+	var crts v1.OriginCertificateList
+	if err := r.Client.List(ctx, &crts, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list OriginCertificates referencing issuer", "kind", kind, "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, crt := range crts.Items {
+		if crt.Spec.IssuerRef.Name == obj.GetName() && cmp.Or(crt.Spec.IssuerRef.Kind, "OriginIssuer") == kind {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name}})
+		}
+	}
+
+	return requests
+}
+
+// signer returns the signer used to sign OriginCertificates.
+func (r *OriginCertificateController) signer() *issuerSigner {
+	return &issuerSigner{
+		client:      r.Client,
+		reader:      r.Reader,
+		builder:     r.Builder,
+		credentials: r.Credentials,
+		clock:       r.Clock,
+		recorder:    r.Recorder,
+		clients:     &r.clients,
+		breakers:    r.Breakers,
+		quotas:      cmp.Or(r.Quotas, &r.quotas),
+		apiPolicy:   r.APIPolicy,
+
+		local:                 r.LocalSigner,
+		localSigning:          r.LocalSigning,
+		allowLocalSigningMode: r.AllowLocalSigningMode,
+
+		clusterResourceNamespace: r.ClusterResourceNamespace,
+		clusterSecretNamespaces:  r.ClusterSecretNamespaces,
+	}
+}
+
+// issuanceHash returns a hash of the parameters a certificate for spec is issued with by an issuer
+// with iss: the requested duration, and the request type and signing mode of the issuer.
+func (r *OriginCertificateController) issuanceHash(spec v1.OriginCertificateSpec, iss v1.OriginIssuerSpec) string {
+	var duration time.Duration
+	if spec.Duration != nil {
+		duration = spec.Duration.Duration
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "duration=%d\nrequestType=%s\nlocal=%t\n", duration, iss.RequestType, signsLocally(iss, r.LocalSigning, r.AllowLocalSigningMode))
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// setIssued is a helper function to record the validity of cert in the OriginCertificate status,
+// clear the pending certificate it was written as, mark it as Ready, and update the API.
+func (r *OriginCertificateController) setIssued(ctx context.Context, crt *v1.OriginCertificate, cert *x509.Certificate, renewal time.Time, message string) error {
+	notBefore, notAfter, renewalTime := metav1.NewTime(cert.NotBefore), metav1.NewTime(cert.NotAfter), metav1.NewTime(renewal)
+	crt.Status.NotBefore, crt.Status.NotAfter, crt.Status.RenewalTime = &notBefore, &notAfter, &renewalTime
+	crt.Status.PendingCertificate = nil
+
+	return r.setStatus(ctx, crt, v1.ConditionTrue, "Issued", message)
+}
+
+// setStatus is a helper function to set the OriginCertificate status condition with reason and message, and update the API.
+func (r *OriginCertificateController) setStatus(ctx context.Context, crt *v1.OriginCertificate, status v1.ConditionStatus, reason, message string) error {
+	SetCertificateStatusCondition(&crt.Status, v1.ConditionReady, status, r.Log, r.Clock, reason, message)
+
+	return r.Client.Status().Update(ctx, crt)
+}
+
+// renewalTime returns when cert should be renewed: RenewBefore its expiry or, by default or if
+// RenewBefore is not shorter than the certificate's validity, once two thirds of it have passed.
+func renewalTime(spec v1.OriginCertificateSpec, cert *x509.Certificate) time.Time {
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+
+	renewBefore := validity / 3
+	if spec.RenewBefore != nil && spec.RenewBefore.Duration < validity {
+		renewBefore = spec.RenewBefore.Duration
+	}
+
+	return cert.NotAfter.Add(-renewBefore)
+}
+
+// generateCSR generates a private key for the given request type, and a PEM-encoded certificate
+// signing request for dnsNames signed by it.
+func generateCSR(requestType v1.RequestType, dnsNames []string) (crypto.Signer, []byte, error) {
+	var key crypto.Signer
+	var err error
+
+	switch requestType {
+	case v1.RequestTypeOriginECC:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case v1.RequestTypeOriginRSA:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		err = fmt.Errorf("unknown request type %q", requestType)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: dnsNames[0]},
+		DNSNames: dnsNames,
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// sameDNSNames reports whether a and b contain the same DNS names, in any order.
+func sameDNSNames(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// validateOriginCertificate ensures the OriginCertificate requests a certificate the Cloudflare
+// Origin CA is able to issue.
+func validateOriginCertificate(s v1.OriginCertificateSpec) error {
+	switch {
+	case s.SecretName == "":
+		return errors.New("spec.secretName cannot be empty")
+	case len(s.DNSNames) == 0:
+		return errors.New("spec.dnsNames cannot be empty")
+	case s.IssuerRef.Name == "":
+		return errors.New("spec.issuerRef.name cannot be empty")
+	case s.IssuerRef.Kind != "" && s.IssuerRef.Kind != "OriginIssuer" && s.IssuerRef.Kind != "ClusterOriginIssuer":
+		return fmt.Errorf("spec.issuerRef.kind has invalid value %q", s.IssuerRef.Kind)
+	case s.Duration != nil && s.Duration.Duration <= 0:
+		return fmt.Errorf("spec.duration has invalid value %s: must be positive", s.Duration.Duration)
+	case s.RenewBefore != nil && s.RenewBefore.Duration <= 0:
+		return fmt.Errorf("spec.renewBefore has invalid value %s: must be positive", s.RenewBefore.Duration)
+	}
+
+	for i, name := range s.DNSNames {
+		if name == "" || net.ParseIP(name) != nil {
+			return fmt.Errorf("spec.dnsNames[%d] has invalid value %q: must be a DNS name", i, name)
+		}
+	}
+
+	return nil
+}
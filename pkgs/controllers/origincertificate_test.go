@@ -0,0 +1,460 @@
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	fakeClock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestOriginCertificateReconcile(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+
+	ts, requests := originCAServerMust(t, clock)
+	defer ts.Close()
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			&v1.OriginCertificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+				Spec: v1.OriginCertificateSpec{
+					SecretName: "example-tls",
+					DNSNames:   []string{"example.com", "*.example.com"},
+					Duration:   &metav1.Duration{Duration: 30 * 24 * time.Hour},
+					IssuerRef:  v1.IssuerReference{Name: "foobar"},
+				},
+			},
+			&v1.OriginCertificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "default"},
+				Spec: v1.OriginCertificateSpec{
+					SecretName: "invalid-tls",
+					DNSNames:   []string{"192.0.2.1"},
+					IssuerRef:  v1.IssuerReference{Name: "foobar"},
+				},
+			},
+			&v1.OriginCertificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "default"},
+				Spec: v1.OriginCertificateSpec{
+					SecretName: "opaque",
+					DNSNames:   []string{"example.com"},
+					IssuerRef:  v1.IssuerReference{Name: "foobar"},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "default"},
+				Type:       corev1.SecretTypeOpaque,
+			},
+			&v1.OriginCertificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "unowned", Namespace: "default"},
+				Spec: v1.OriginCertificateSpec{
+					SecretName: "unowned-tls",
+					DNSNames:   []string{"example.com"},
+					IssuerRef:  v1.IssuerReference{Name: "foobar"},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "unowned-tls", Namespace: "default"},
+				Type:       corev1.SecretTypeTLS,
+			},
+			&v1.OriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginECC,
+					Auth: v1.OriginIssuerAuthentication{
+						TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+					},
+				},
+				Status: v1.OriginIssuerStatus{
+					Conditions: []v1.OriginIssuerCondition{
+						{
+							Type:   v1.ConditionReady,
+							Status: v1.ConditionTrue,
+						},
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("valid-token")},
+			},
+		).
+		WithStatusSubresource(&v1.OriginCertificate{}, &v1.OriginIssuer{}).
+		Build()
+
+	controller := &OriginCertificateController{
+		Client:   client,
+		Reader:   client,
+		Log:      logf.Log,
+		Builder:  cfapi.NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL),
+		Clock:    clock,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	reconcileCertificate := func(name string) (reconcile.Result, error) {
+		t.Helper()
+
+		return reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+		})
+	}
+
+	ready := func(name string) v1.OriginCertificateCondition {
+		t.Helper()
+
+		crt := &v1.OriginCertificate{}
+		assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, crt))
+		assert.Equal(t, len(crt.Status.Conditions), 1)
+
+		return crt.Status.Conditions[0]
+	}
+
+	issued := func() *x509.Certificate {
+		t.Helper()
+
+		secret := &corev1.Secret{}
+		assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "example-tls"}, secret))
+		assert.Equal(t, secret.Type, corev1.SecretTypeTLS)
+		assert.Equal(t, secret.Annotations[IssuerNameAnnotation], "foobar")
+		assert.Equal(t, secret.Annotations[IssuerKindAnnotation], "OriginIssuer")
+		assert.Equal(t, secret.OwnerReferences[0].Name, "example")
+
+		cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+		assert.NilError(t, err)
+		key, err := pki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey])
+		assert.NilError(t, err)
+
+		matches, err := pki.PublicKeyMatchesCertificate(key.Public(), cert)
+		assert.NilError(t, err)
+		assert.Assert(t, matches, "private key should match the certificate")
+
+		return cert
+	}
+
+	res, err := reconcileCertificate("example")
+	assert.NilError(t, err)
+	assert.Equal(t, *requests, 1)
+	assert.Equal(t, ready("example").Status, v1.ConditionTrue)
+	assert.Equal(t, res.RequeueAfter, 20*24*time.Hour, "certificates should be renewed after two thirds of their validity")
+
+	first := issued()
+	assert.DeepEqual(t, first.DNSNames, []string{"example.com", "*.example.com"})
+
+	res, err = reconcileCertificate("example")
+	assert.NilError(t, err)
+	assert.Equal(t, *requests, 1, "up to date certificates should not be reissued")
+	assert.Equal(t, res.RequeueAfter, 20*24*time.Hour)
+
+	clock.Step(20 * 24 * time.Hour)
+
+	_, err = reconcileCertificate("example")
+	assert.NilError(t, err)
+	assert.Equal(t, *requests, 2, "certificates due for renewal should be reissued")
+	assert.Assert(t, issued().NotAfter.After(first.NotAfter))
+
+	crt := &v1.OriginCertificate{}
+	assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "example"}, crt))
+	crt.Spec.DNSNames = []string{"example.net"}
+	assert.NilError(t, client.Update(context.Background(), crt))
+
+	_, err = reconcileCertificate("example")
+	assert.NilError(t, err)
+	assert.Equal(t, *requests, 3, "certificates should be reissued when their DNS names change")
+	assert.DeepEqual(t, issued().DNSNames, []string{"example.net"})
+
+	assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "example"}, crt))
+	crt.Spec.Duration = &metav1.Duration{Duration: 90 * 24 * time.Hour}
+	assert.NilError(t, client.Update(context.Background(), crt))
+
+	_, err = reconcileCertificate("example")
+	assert.NilError(t, err)
+	assert.Equal(t, *requests, 4, "certificates should be reissued when their duration changes")
+
+	iss := &v1.OriginIssuer{}
+	assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "foobar"}, iss))
+	iss.Spec.RequestType = v1.RequestTypeOriginRSA
+	assert.NilError(t, client.Update(context.Background(), iss))
+
+	_, err = reconcileCertificate("example")
+	assert.NilError(t, err)
+	assert.Equal(t, *requests, 5, "certificates should be reissued when the issuer's request type changes")
+	assert.Equal(t, issued().PublicKeyAlgorithm, x509.RSA)
+
+	assert.Equal(t, len(controller.IssuerCertificates(context.Background(), iss)), 4, "changes to issuers should reconcile the certificates referencing them")
+	assert.Equal(t, len(controller.IssuerCertificates(context.Background(), &v1.ClusterOriginIssuer{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}})), 0)
+
+	_, err = reconcileCertificate("invalid")
+	assert.NilError(t, err)
+	assert.Equal(t, ready("invalid").Reason, "Invalid")
+
+	_, err = reconcileCertificate("opaque")
+	assert.Error(t, err, "secret opaque has type Opaque, not kubernetes.io/tls")
+	assert.Equal(t, ready("opaque").Reason, "Error")
+
+	_, err = reconcileCertificate("unowned")
+	assert.Error(t, err, "secret unowned-tls is not owned by OriginCertificate unowned")
+	assert.Equal(t, ready("unowned").Reason, "Error")
+	assert.Equal(t, *requests, 5)
+}
+
+func TestOriginCertificateWriteFailure(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+
+	ts, requests := originCAServerMust(t, clock)
+	defer ts.Close()
+
+	writeErr := errors.New("secrets is forbidden")
+	c := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			&v1.OriginCertificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+				Spec: v1.OriginCertificateSpec{
+					SecretName: "example-tls",
+					DNSNames:   []string{"example.com"},
+					IssuerRef:  v1.IssuerReference{Name: "foobar"},
+				},
+			},
+			&v1.OriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginECC,
+					Auth: v1.OriginIssuerAuthentication{
+						TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+					},
+				},
+				Status: v1.OriginIssuerStatus{
+					Conditions: []v1.OriginIssuerCondition{
+						{
+							Type:   v1.ConditionReady,
+							Status: v1.ConditionTrue,
+						},
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("valid-token")},
+			},
+		).
+		WithStatusSubresource(&v1.OriginCertificate{}, &v1.OriginIssuer{}, &v1.OriginCertificateRecord{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*corev1.Secret); ok && writeErr != nil {
+					return writeErr
+				}
+
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	controller := &OriginCertificateController{
+		Client:   c,
+		Reader:   c,
+		Log:      logf.Log,
+		Builder:  cfapi.NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL),
+		Clock:    clock,
+		Recorder: record.NewFakeRecorder(10),
+		SigningSettings: SigningSettings{
+			RecordCertificates: true,
+		},
+	}
+
+	reconcileCertificate := func() error {
+		t.Helper()
+
+		_, err := reconcile.AsReconciler(c, controller).Reconcile(context.Background(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "example"},
+		})
+
+		return err
+	}
+
+	crt := &v1.OriginCertificate{}
+	get := func() {
+		t.Helper()
+
+		assert.NilError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "example"}, crt))
+	}
+
+	assert.ErrorIs(t, reconcileCertificate(), writeErr)
+	assert.Equal(t, *requests, 1)
+
+	get()
+	assert.Equal(t, crt.Status.Conditions[0].Reason, "Error")
+	assert.Assert(t, crt.Status.PendingCertificate != nil, "certificates should be pending until the secret is written")
+	pending := *crt.Status.PendingCertificate
+
+	rec := &v1.OriginCertificateRecord{}
+	assert.NilError(t, c.Get(context.Background(), types.NamespacedName{Name: pending.SerialNumber}, rec))
+	assert.Assert(t, rec.Status.RevocationTime == nil)
+
+	writeErr = nil
+	assert.NilError(t, reconcileCertificate())
+	assert.Equal(t, *requests, 2)
+
+	assert.NilError(t, c.Get(context.Background(), types.NamespacedName{Name: pending.SerialNumber}, rec))
+	assert.Assert(t, rec.Status.RevocationTime != nil, "pending certificates not written to the secret should be revoked")
+
+	get()
+	assert.Equal(t, crt.Status.Conditions[0].Reason, "Issued")
+	assert.Assert(t, crt.Status.PendingCertificate == nil)
+
+	secret := &corev1.Secret{}
+	assert.NilError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "example-tls"}, secret))
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	assert.NilError(t, err)
+	assert.Assert(t, serialNumber(cert) != pending.SerialNumber)
+}
+
+func TestValidateOriginCertificate(t *testing.T) {
+	valid := v1.OriginCertificateSpec{
+		SecretName: "example-tls",
+		DNSNames:   []string{"example.com"},
+		IssuerRef:  v1.IssuerReference{Name: "foobar"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *v1.OriginCertificateSpec)
+		error  string
+	}{
+		{
+			name:   "valid",
+			modify: func(s *v1.OriginCertificateSpec) {},
+		},
+		{
+			name:   "missing secret name",
+			modify: func(s *v1.OriginCertificateSpec) { s.SecretName = "" },
+			error:  "spec.secretName cannot be empty",
+		},
+		{
+			name:   "missing DNS names",
+			modify: func(s *v1.OriginCertificateSpec) { s.DNSNames = nil },
+			error:  "spec.dnsNames cannot be empty",
+		},
+		{
+			name:   "IP address",
+			modify: func(s *v1.OriginCertificateSpec) { s.DNSNames = []string{"example.com", "2001:db8::1"} },
+			error:  `spec.dnsNames[1] has invalid value "2001:db8::1": must be a DNS name`,
+		},
+		{
+			name:   "unknown issuer kind",
+			modify: func(s *v1.OriginCertificateSpec) { s.IssuerRef.Kind = "Issuer" },
+			error:  `spec.issuerRef.kind has invalid value "Issuer"`,
+		},
+		{
+			name:   "negative renew before",
+			modify: func(s *v1.OriginCertificateSpec) { s.RenewBefore = &metav1.Duration{Duration: -time.Hour} },
+			error:  "spec.renewBefore has invalid value -1h0m0s: must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.modify(&s)
+
+			err := validateOriginCertificate(s)
+			if tt.error != "" {
+				assert.Error(t, err, tt.error)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+// originCAServerMust returns a server signing certificates like the Origin CA API, valid from the
+// current time of cl for the requested validity, and a count of the requests it received.
+func originCAServerMust(t *testing.T, cl clock.Clock) (*httptest.Server, *int) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Origin CA"},
+		NotBefore:             cl.Now().Add(-time.Hour),
+		NotAfter:              cl.Now().Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	requests := 0
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "result": map[string]any{"id": path.Base(r.URL.Path)}})
+			return
+		}
+
+		requests++
+
+		sr := cfapi.SignRequest{}
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&sr))
+
+		csr, err := pki.DecodeX509CertificateRequestBytes([]byte(sr.CSR))
+		assert.NilError(t, err)
+
+		notAfter := cl.Now().Add(time.Duration(sr.Validity) * 24 * time.Hour)
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(int64(requests + 1)),
+			DNSNames:     csr.DNSNames,
+			NotBefore:    cl.Now(),
+			NotAfter:     notAfter,
+		}, ca, csr.PublicKey, caKey)
+		assert.NilError(t, err)
+
+		result, _ := json.Marshal(map[string]any{
+			"id":          fmt.Sprint(requests),
+			"certificate": string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+			"hostnames":   sr.Hostnames,
+			"expires_on":  notAfter.UTC().Format(time.RFC3339),
+		})
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "result": json.RawMessage(result)})
+	}))
+
+	return ts, &requests
+}
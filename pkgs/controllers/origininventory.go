@@ -0,0 +1,429 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	certmanager "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"github.com/go-logr/logr"
+	certificates "k8s.io/api/certificates/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultInventoryInterval          = time.Hour
+	defaultInventoryExpiryThreshold   = 30 * 24 * time.Hour
+	defaultInventoryOrphanGracePeriod = 7 * 24 * time.Hour
+
+	// inventoryListLimit bounds the number of certificates listed in each of the
+	// OriginInventory status lists, so the status stays small.
+	inventoryListLimit = 50
+
+	// revocationUnavailableMessage explains why orphans cannot be revoked when certificates are not recorded.
+	revocationUnavailableMessage = "Orphans cannot be revoked without certificate records, enable them with --enable-certificate-records"
+)
+
+// OriginInventoryController implements a controller that periodically lists the Origin CA
+// certificates of OriginInventory resources, and correlates them with the certificates
+// found in the cluster.
+type OriginInventoryController struct {
+	client.Client
+	Reader   client.Reader
+	Log      logr.Logger
+	Builder  *cfapi.Builder
+	Recorder record.EventRecorder
+	Clock    clock.Clock
+
+	SigningSettings
+
+	// Namespaces restricts the Secrets and CertificateRequests correlated with certificates to
+	// the namespaces watched by the controller. By default, all namespaces are listed.
+	Namespaces []string
+
+	// DisableCertificateRequests skips correlating certificates with cert-manager
+	// CertificateRequests, for clusters without cert-manager installed.
+	DisableCertificateRequests bool
+
+	// CertificateSigningRequests enables correlating certificates with Kubernetes
+	// CertificateSigningRequests.
+	CertificateSigningRequests bool
+
+	clients clientCache
+}
+
+// +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=origininventories,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=origininventories/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=list
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=list
+
+// Reconciler returns the reconciler of OriginInventory resources retrieved with c, which also
+// deletes the metrics of OriginInventories once they are deleted.
+func (r *OriginInventoryController) Reconciler(c client.Client) reconcile.Reconciler {
+	typed := reconcile.AsReconciler[*v1.OriginInventory](c, r)
+
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if err := c.Get(ctx, req.NamespacedName, &v1.OriginInventory{}); apierrors.IsNotFound(err) {
+			deleteInventoryMetrics(req.Namespace, req.Name)
+
+			return reconcile.Result{}, nil
+		}
+
+		return typed.Reconcile(ctx, req)
+	})
+}
+
+// Reconcile reconciles OriginInventory resources by listing the certificates of their zones,
+// reporting the orphaned and expiring ones, and revoking recorded orphans if enabled.
+func (r *OriginInventoryController) Reconcile(ctx context.Context, inv *v1.OriginInventory) (reconcile.Result, error) {
+	log := r.Log.WithValues("namespace", inv.Namespace, "origininventory", inv.Name)
+
+	if err := validateOriginInventory(inv.Spec); err != nil {
+		log.Error(err, "failed to validate OriginInventory resource")
+		_ = r.setStatus(ctx, inv, v1.ConditionFalse, "Invalid", fmt.Sprintf("Invalid OriginInventory: %v", err))
+
+		return reconcile.Result{}, nil
+	}
+
+	if inv.Spec.RevokeOrphans && !r.RecordCertificates {
+		_ = r.setStatus(ctx, inv, v1.ConditionFalse, "RevocationUnavailable", revocationUnavailableMessage)
+
+		return reconcile.Result{}, nil
+	}
+
+	signer := r.signer()
+
+	iss, err := signer.getIssuer(ctx, log, "OriginIssuer", inv.Spec.IssuerRef.Name, inv.Namespace)
+	if err != nil {
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, inv, v1.ConditionFalse, reason, message)
+
+		if retryAfter, open := circuitOpen(err); open {
+			return reconcile.Result{RequeueAfter: retryAfter}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	serials, err := r.clusterSerials(ctx)
+	if err != nil {
+		log.Error(err, "failed to list certificates in the cluster")
+		_ = r.setStatus(ctx, inv, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to list certificates in the cluster: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	records, err := r.namespaceRecords(ctx, inv.Namespace, serials)
+	if err != nil {
+		log.Error(err, "failed to list certificate records")
+		_ = r.setStatus(ctx, inv, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to list certificate records: %v", err))
+
+		return reconcile.Result{}, err
+	}
+
+	// Certificates are listed once, even if they are valid for several of the zones, or listed again
+	// by a retried page.
+	var listed []cfapi.SignResponse
+	seen := make(map[string]bool)
+	for _, zone := range inv.Spec.ZoneIDs {
+		err := signer.withClient(ctx, log, inv, iss, func(c *cfapi.Client) error {
+			certs, err := c.List(ctx, zone)
+			for _, cert := range certs {
+				if !seen[cert.Id] {
+					seen[cert.Id] = true
+					listed = append(listed, cert)
+				}
+			}
+
+			return err
+		})
+		var openErr *provisioners.CircuitOpenError
+		if errors.As(err, &openErr) {
+			log.Info("Cloudflare API is unavailable, requeue-ing", "reason", openErr.Err.Error(), "retryAfter", openErr.RetryAfter)
+			_ = r.setStatus(ctx, inv, v1.ConditionFalse, apiUnavailableReason, openErr.Error())
+
+			return reconcile.Result{RequeueAfter: openErr.RetryAfter}, nil
+		}
+
+		if err != nil {
+			log.Error(err, "failed to list certificates", "zone", zone)
+
+			var serr *statusError
+			if errors.As(err, &serr) {
+				_ = r.setStatus(ctx, inv, v1.ConditionFalse, serr.reason, serr.message)
+			} else {
+				_ = r.setStatus(ctx, inv, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to list certificates of zone %s: %v", zone, err))
+			}
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	now := r.Clock.Now()
+	threshold := durationOr(inv.Spec.ExpiryThreshold, defaultInventoryExpiryThreshold)
+	grace := durationOr(inv.Spec.OrphanGracePeriod, defaultInventoryOrphanGracePeriod)
+
+	var total, tracked, revoked int
+	var orphans, expiring []v1.InventoryCertificate
+	for _, listing := range listed {
+		if !listing.Expiration.After(now) {
+			continue
+		}
+
+		crt := v1.InventoryCertificate{
+			ID:        listing.Id,
+			Hostnames: listing.Hostnames,
+			ExpiresOn: ptrTime(listing.Expiration),
+		}
+
+		cert, err := pki.DecodeX509CertificateBytes([]byte(listing.Certificate))
+		if err == nil {
+			crt.SerialNumber = serialNumber(cert)
+		}
+
+		orphaned := cert == nil || !serials[crt.SerialNumber]
+
+		// Only certificates recorded as issued by the controller for the namespace are revoked, as
+		// others may be in use outside of the cluster, or belong to other namespaces. Their records
+		// keep when they were first found orphaned, which the grace period is measured from.
+		rec := records[crt.SerialNumber]
+		if rec != nil {
+			if err := setCertificateRecordOrphaned(ctx, r.Client, rec, orphaned, now); err != nil {
+				log.Error(err, "failed to record orphaned certificate", "id", crt.ID)
+			}
+		}
+
+		if orphaned && inv.Spec.RevokeOrphans && cert != nil && rec != nil && rec.Status.OrphanedTime != nil && now.Sub(rec.Status.OrphanedTime.Time) >= grace {
+			if err := r.revoke(ctx, log, signer, inv, iss, crt, rec); err == nil {
+				revoked++
+				continue
+			}
+		}
+
+		total++
+		if orphaned {
+			orphans = append(orphans, crt)
+		} else {
+			tracked++
+		}
+
+		if listing.Expiration.Before(now.Add(threshold)) {
+			expiring = append(expiring, crt)
+		}
+	}
+
+	byExpiry := func(a, b v1.InventoryCertificate) int {
+		return cmp.Or(a.ExpiresOn.Time.Compare(b.ExpiresOn.Time), cmp.Compare(a.ID, b.ID))
+	}
+	slices.SortFunc(orphans, byExpiry)
+	slices.SortFunc(expiring, byExpiry)
+
+	syncTime := metav1.NewTime(now)
+	inv.Status.LastSyncTime = &syncTime
+	inv.Status.Certificates = total
+	inv.Status.Orphaned = len(orphans)
+	inv.Status.Expiring = len(expiring)
+	inv.Status.Revoked = revoked
+	inv.Status.Orphans = orphans[:min(len(orphans), inventoryListLimit)]
+	inv.Status.ExpiringCertificates = expiring[:min(len(expiring), inventoryListLimit)]
+
+	inventoryCertificates.WithLabelValues(inv.Namespace, inv.Name, "tracked").Set(float64(tracked))
+	inventoryCertificates.WithLabelValues(inv.Namespace, inv.Name, "orphaned").Set(float64(len(orphans)))
+	inventoryCertificates.WithLabelValues(inv.Namespace, inv.Name, "expiring").Set(float64(len(expiring)))
+	inventoryLastSync.WithLabelValues(inv.Namespace, inv.Name).Set(float64(now.Unix()))
+
+	message := fmt.Sprintf("Listed %d certificates, %d orphaned and %d expiring", total, len(orphans), len(expiring))
+	if err := r.setStatus(ctx, inv, v1.ConditionTrue, "Synced", message); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: durationOr(inv.Spec.Interval, defaultInventoryInterval)}, nil
+}
+
+// revoke revokes the orphaned certificate, recording an event on the OriginInventory and the
+// revocation in the certificate's record.
+func (r *OriginInventoryController) revoke(ctx context.Context, log logr.Logger, signer *issuerSigner, inv *v1.OriginInventory, iss *issuer, crt v1.InventoryCertificate, rec *v1.OriginCertificateRecord) error {
+	err := signer.withClient(ctx, log, inv, iss, func(c *cfapi.Client) error {
+		return c.Revoke(ctx, crt.ID)
+	})
+	if err != nil {
+		log.Error(err, "failed to revoke orphaned certificate", "id", crt.ID)
+		r.Recorder.Eventf(inv, core.EventTypeWarning, "RevokeFailed", "Failed to revoke orphaned certificate %s: %v", crt.ID, err)
+
+		return err
+	}
+
+	log.Info("revoked orphaned certificate", "id", crt.ID, "serial", crt.SerialNumber, "hostnames", crt.Hostnames)
+	r.Recorder.Eventf(inv, core.EventTypeNormal, "Revoked", "Revoked orphaned certificate %s for %v", crt.ID, crt.Hostnames)
+	inventoryRevocations.WithLabelValues(inv.Namespace, inv.Name).Inc()
+
+	if err := revokeCertificateRecord(ctx, r.Client, rec, r.Clock.Now()); err != nil {
+		log.Error(err, "failed to record revoked certificate", "id", crt.ID)
+	}
+
+	return nil
+}
+
+// clusterSerials returns the serial numbers of the certificates held by TLS Secrets, CertificateRequests
+// and, if enabled, CertificateSigningRequests in the watched namespaces. CertificateRequests and
+// CertificateSigningRequests are read from the cache of their controllers, while only TLS Secrets are
+// listed from the API server, as Secrets are not cached.
+func (r *OriginInventoryController) clusterSerials(ctx context.Context) (map[string]bool, error) {
+	serials := make(map[string]bool)
+	add := func(pem []byte) {
+		if cert, err := pki.DecodeX509CertificateBytes(pem); err == nil {
+			serials[serialNumber(cert)] = true
+		}
+	}
+
+	namespaces := r.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	for _, namespace := range namespaces {
+		secrets := &core.SecretList{}
+		if err := r.Reader.List(ctx, secrets, client.InNamespace(namespace), client.MatchingFields{"type": string(core.SecretTypeTLS)}); err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		for _, secret := range secrets.Items {
+			add(secret.Data[core.TLSCertKey])
+		}
+	}
+
+	if !r.DisableCertificateRequests {
+		crs := &certmanager.CertificateRequestList{}
+		if err := r.Client.List(ctx, crs); err != nil {
+			return nil, fmt.Errorf("failed to list certificate requests: %w", err)
+		}
+		for _, cr := range crs.Items {
+			add(cr.Status.Certificate)
+		}
+	}
+
+	if r.CertificateSigningRequests {
+		csrs := &certificates.CertificateSigningRequestList{}
+		if err := r.Client.List(ctx, csrs); err != nil {
+			return nil, fmt.Errorf("failed to list certificate signing requests: %w", err)
+		}
+		for _, csr := range csrs.Items {
+			add(csr.Status.Certificate)
+		}
+	}
+
+	return serials, nil
+}
+
+// namespaceRecords returns the OriginCertificateRecords of the unrevoked certificates issued for resources
+// in namespace, by serial number, if certificates are recorded. The certificates of CertificateSigningRequests
+// are instead added to serials, as they are used outside of the cluster until they expire, long after their
+// requests are garbage collected.
+func (r *OriginInventoryController) namespaceRecords(ctx context.Context, namespace string, serials map[string]bool) (map[string]*v1.OriginCertificateRecord, error) {
+	records := make(map[string]*v1.OriginCertificateRecord)
+	if !r.RecordCertificates {
+		return records, nil
+	}
+
+	list := &v1.OriginCertificateRecordList{}
+	if err := r.Client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list certificate records: %w", err)
+	}
+
+	for i := range list.Items {
+		rec := &list.Items[i]
+		switch {
+		case rec.Status.RevocationTime != nil:
+		case rec.Spec.Source.Kind == "CertificateSigningRequest":
+			serials[rec.Spec.SerialNumber] = true
+		case rec.Spec.Source.Namespace == namespace:
+			records[rec.Spec.SerialNumber] = rec
+		}
+	}
+
+	return records, nil
+}
+
+// signer returns the signer used to list and revoke certificates.
+func (r *OriginInventoryController) signer() *issuerSigner {
+	return &issuerSigner{
+		client:      r.Client,
+		reader:      r.Reader,
+		builder:     r.Builder,
+		credentials: r.Credentials,
+		clock:       r.Clock,
+		recorder:    r.Recorder,
+		clients:     &r.clients,
+		breakers:    r.Breakers,
+		apiPolicy:   r.APIPolicy,
+
+		localSigning:          r.LocalSigning,
+		allowLocalSigningMode: r.AllowLocalSigningMode,
+	}
+}
+
+// setStatus is a helper function to set the OriginInventory status condition with reason and message, and update the API.
+func (r *OriginInventoryController) setStatus(ctx context.Context, inv *v1.OriginInventory, status v1.ConditionStatus, reason, message string) error {
+	SetInventoryStatusCondition(&inv.Status, v1.ConditionReady, status, r.Log, r.Clock, reason, message)
+
+	return r.Client.Status().Update(ctx, inv)
+}
+
+// validateOriginInventory ensures the OriginInventory references an OriginIssuer and zones to list.
+func validateOriginInventory(s v1.OriginInventorySpec) error {
+	switch {
+	case s.IssuerRef.Name == "":
+		return errors.New("spec.issuerRef.name cannot be empty")
+	case s.IssuerRef.Kind != "" && s.IssuerRef.Kind != "OriginIssuer":
+		return fmt.Errorf("spec.issuerRef.kind has invalid value %q: only OriginIssuers may be referenced", s.IssuerRef.Kind)
+	case len(s.ZoneIDs) == 0:
+		return errors.New("spec.zoneIDs cannot be empty")
+	case s.Interval != nil && s.Interval.Duration <= 0:
+		return fmt.Errorf("spec.interval has invalid value %s: must be positive", s.Interval.Duration)
+	case s.ExpiryThreshold != nil && s.ExpiryThreshold.Duration <= 0:
+		return fmt.Errorf("spec.expiryThreshold has invalid value %s: must be positive", s.ExpiryThreshold.Duration)
+	case s.OrphanGracePeriod != nil && s.OrphanGracePeriod.Duration < 0:
+		return fmt.Errorf("spec.orphanGracePeriod has invalid value %s: must not be negative", s.OrphanGracePeriod.Duration)
+	}
+
+	for i, zone := range s.ZoneIDs {
+		if zone == "" {
+			return fmt.Errorf("spec.zoneIDs[%d] cannot be empty", i)
+		}
+	}
+
+	return nil
+}
+
+// serialNumber returns the hex-encoded serial number of cert.
+func serialNumber(cert *x509.Certificate) string {
+	return cert.SerialNumber.Text(16)
+}
+
+// durationOr returns the duration d, or def if d is not set.
+func durationOr(d *metav1.Duration, def time.Duration) time.Duration {
+	if d == nil {
+		return def
+	}
+
+	return d.Duration
+}
+
+// ptrTime returns a pointer to t as a metav1.Time.
+func ptrTime(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
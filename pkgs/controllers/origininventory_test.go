@@ -0,0 +1,370 @@
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakeClock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestOriginInventoryReconcile(t *testing.T) {
+	if err := cmapi.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+	now := clock.Now()
+	day := 24 * time.Hour
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate := func(serial int64, notBefore, notAfter time.Time) string {
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "example.com"},
+			DNSNames:     []string{"example.com"},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+		}, &x509.Certificate{SerialNumber: big.NewInt(1)}, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+
+	listing := func(id string, serial int64, notBefore, notAfter time.Time) cfapi.SignResponse {
+		return cfapi.SignResponse{
+			Id:          id,
+			Certificate: certificate(serial, notBefore, notAfter),
+			Hostnames:   []string{"example.com"},
+			Expiration:  notAfter.UTC(),
+		}
+	}
+
+	secretCert := listing("in-secret", 10, now.Add(-30*day), now.Add(60*day))
+	requestCert := listing("in-request", 11, now.Add(-80*day), now.Add(10*day))
+	listed := []cfapi.SignResponse{
+		secretCert,
+		requestCert,
+		listing("new-orphan", 12, now.Add(-day), now.Add(300*day)),
+		listing("old-orphan", 13, now.Add(-30*day), now.Add(300*day)),
+		listing("expired", 14, now.Add(-100*day), now.Add(-day)),
+		listing("unrecorded", 15, now.Add(-30*day), now.Add(300*day)),
+		listing("other-namespace", 16, now.Add(-30*day), now.Add(300*day)),
+		listing("signing-request", 17, now.Add(-30*day), now.Add(300*day)),
+		// A long-lived certificate replaced by a renewal, once its CertificateRequest was deleted.
+		listing("renewed", 18, now.Add(-300*day), now.Add(60*day)),
+	}
+
+	certificateRecord := func(serial int64, source v1.RecordSource, orphaned time.Duration) *v1.OriginCertificateRecord {
+		rec := &v1.OriginCertificateRecord{
+			ObjectMeta: metav1.ObjectMeta{Name: big.NewInt(serial).Text(16)},
+			Spec: v1.OriginCertificateRecordSpec{
+				SerialNumber: big.NewInt(serial).Text(16),
+				IssuerRef:    v1.IssuerReference{Name: "foobar", Kind: "OriginIssuer"},
+				Source:       source,
+			},
+		}
+
+		if orphaned > 0 {
+			rec.Status.OrphanedTime = &metav1.Time{Time: now.Add(-orphaned)}
+		}
+
+		return rec
+	}
+
+	var revoked []string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result any
+		switch r.Method {
+		case http.MethodGet:
+			// Certificates valid for several zones are listed for each of them.
+			result = listed
+		case http.MethodDelete:
+			revoked = append(revoked, path.Base(r.URL.Path))
+			result = map[string]string{"id": path.Base(r.URL.Path)}
+		}
+
+		p, _ := json.Marshal(result)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success":     true,
+			"result":      json.RawMessage(p),
+			"result_info": map[string]int{"page": 1, "total_pages": 1},
+		})
+	}))
+	defer ts.Close()
+
+	objects := []runtime.Object{
+		&v1.OriginIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+			Spec: v1.OriginIssuerSpec{
+				RequestType: v1.RequestTypeOriginECC,
+				Auth: v1.OriginIssuerAuthentication{
+					TokenRef: &v1.SecretKeySelector{Name: "token", Key: "token"},
+				},
+			},
+			Status: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{{Type: v1.ConditionReady, Status: v1.ConditionTrue}},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("valid-token")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-tls", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte(secretCert.Certificate)},
+		},
+		&cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-1", Namespace: "default"},
+			Status:     cmapi.CertificateRequestStatus{Certificate: []byte(requestCert.Certificate)},
+		},
+		certificateRecord(12, v1.RecordSource{Kind: "CertificateRequest", Namespace: "default", Name: "example-2"}, day),
+		certificateRecord(13, v1.RecordSource{Kind: "CertificateRequest", Namespace: "default", Name: "example-3"}, 30*day),
+		certificateRecord(16, v1.RecordSource{Kind: "CertificateRequest", Namespace: "other", Name: "example-1"}, 30*day),
+		certificateRecord(17, v1.RecordSource{Kind: "CertificateSigningRequest", Name: "garbage-collected"}, 0),
+		certificateRecord(18, v1.RecordSource{Kind: "CertificateRequest", Namespace: "default", Name: "example-4", Certificate: "example"}, 0),
+	}
+
+	inventory := func(modify func(spec *v1.OriginInventorySpec)) *v1.OriginInventory {
+		inv := &v1.OriginInventory{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "default"},
+			Spec: v1.OriginInventorySpec{
+				IssuerRef: v1.IssuerReference{Name: "foobar", Kind: "OriginIssuer"},
+				ZoneIDs:   []string{"zone"},
+				Interval:  &metav1.Duration{Duration: 6 * time.Hour},
+			},
+		}
+		modify(&inv.Spec)
+
+		return inv
+	}
+
+	tests := []struct {
+		name      string
+		inventory *v1.OriginInventory
+		reason    string
+		status    v1.OriginInventoryStatus
+		orphans   []string
+		revoked   []string
+		requeue   time.Duration
+
+		disableCertificateRequests bool
+		certificateRecords         bool
+	}{
+		{
+			name:      "reports orphaned and expiring certificates",
+			inventory: inventory(func(spec *v1.OriginInventorySpec) {}),
+			reason:    "Synced",
+			status:    v1.OriginInventoryStatus{Certificates: 8, Orphaned: 6, Expiring: 1},
+			orphans:   []string{"renewed", "new-orphan", "old-orphan", "other-namespace", "signing-request", "unrecorded"},
+			requeue:   6 * time.Hour,
+		},
+		{
+			name: "counts certificates listed for several zones once",
+			inventory: inventory(func(spec *v1.OriginInventorySpec) {
+				spec.ZoneIDs = []string{"zone", "other-zone"}
+			}),
+			reason:  "Synced",
+			status:  v1.OriginInventoryStatus{Certificates: 8, Orphaned: 6, Expiring: 1},
+			orphans: []string{"renewed", "new-orphan", "old-orphan", "other-namespace", "signing-request", "unrecorded"},
+			requeue: 6 * time.Hour,
+		},
+		{
+			name:               "considers recorded CertificateSigningRequest certificates in use",
+			inventory:          inventory(func(spec *v1.OriginInventorySpec) {}),
+			certificateRecords: true,
+			reason:             "Synced",
+			status:             v1.OriginInventoryStatus{Certificates: 8, Orphaned: 5, Expiring: 1},
+			orphans:            []string{"renewed", "new-orphan", "old-orphan", "other-namespace", "unrecorded"},
+			requeue:            6 * time.Hour,
+		},
+		{
+			name: "revokes recorded orphans orphaned for the grace period",
+			inventory: inventory(func(spec *v1.OriginInventorySpec) {
+				spec.RevokeOrphans = true
+			}),
+			certificateRecords: true,
+			reason:             "Synced",
+			status:             v1.OriginInventoryStatus{Certificates: 7, Orphaned: 4, Expiring: 1, Revoked: 1},
+			orphans:            []string{"renewed", "new-orphan", "other-namespace", "unrecorded"},
+			revoked:            []string{"old-orphan"},
+			requeue:            6 * time.Hour,
+		},
+		{
+			name: "revokes recorded orphans with a shorter grace period",
+			inventory: inventory(func(spec *v1.OriginInventorySpec) {
+				spec.RevokeOrphans = true
+				spec.OrphanGracePeriod = &metav1.Duration{Duration: time.Hour}
+			}),
+			certificateRecords: true,
+			reason:             "Synced",
+			status:             v1.OriginInventoryStatus{Certificates: 6, Orphaned: 3, Expiring: 1, Revoked: 2},
+			orphans:            []string{"renewed", "other-namespace", "unrecorded"},
+			revoked:            []string{"new-orphan", "old-orphan"},
+			requeue:            6 * time.Hour,
+		},
+		{
+			name: "revoking orphans requires certificate records",
+			inventory: inventory(func(spec *v1.OriginInventorySpec) {
+				spec.RevokeOrphans = true
+			}),
+			reason: "RevocationUnavailable",
+		},
+		{
+			name:                       "ignores CertificateRequests when disabled",
+			inventory:                  inventory(func(spec *v1.OriginInventorySpec) {}),
+			disableCertificateRequests: true,
+			reason:                     "Synced",
+			status:                     v1.OriginInventoryStatus{Certificates: 8, Orphaned: 7, Expiring: 1},
+			orphans:                    []string{"in-request", "renewed", "new-orphan", "old-orphan", "other-namespace", "signing-request", "unrecorded"},
+			requeue:                    6 * time.Hour,
+		},
+		{
+			name: "invalid inventory",
+			inventory: inventory(func(spec *v1.OriginInventorySpec) {
+				spec.ZoneIDs = nil
+			}),
+			reason: "Invalid",
+		},
+		{
+			name: "ClusterOriginIssuer reference",
+			inventory: inventory(func(spec *v1.OriginInventorySpec) {
+				spec.IssuerRef.Kind = "ClusterOriginIssuer"
+			}),
+			reason: "Invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			revoked = nil
+
+			client := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(append(objects, tt.inventory)...).
+				WithStatusSubresource(&v1.OriginInventory{}, &v1.OriginCertificateRecord{}).
+				WithIndex(&corev1.Secret{}, "type", func(obj client.Object) []string {
+					return []string{string(obj.(*corev1.Secret).Type)}
+				}).
+				Build()
+
+			controller := &OriginInventoryController{
+				Client:                     client,
+				Reader:                     client,
+				DisableCertificateRequests: tt.disableCertificateRequests,
+				Log:                        logf.Log,
+				Builder:                    cfapi.NewBuilder().WithClient(ts.Client()).WithEndpoint(ts.URL),
+				Clock:                      clock,
+				Recorder:                   record.NewFakeRecorder(10),
+				SigningSettings: SigningSettings{
+					RecordCertificates: tt.certificateRecords,
+				},
+			}
+
+			result, err := controller.Reconciler(client).Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: tt.inventory.Namespace, Name: tt.inventory.Name},
+			})
+			assert.NilError(t, err)
+			assert.Equal(t, result.RequeueAfter, tt.requeue)
+			assert.DeepEqual(t, revoked, tt.revoked)
+
+			got := &v1.OriginInventory{}
+			assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: tt.inventory.Namespace, Name: tt.inventory.Name}, got))
+
+			assert.Equal(t, len(got.Status.Conditions), 1)
+			assert.Equal(t, got.Status.Conditions[0].Reason, tt.reason)
+			assert.Equal(t, got.Status.Certificates, tt.status.Certificates)
+			assert.Equal(t, got.Status.Orphaned, tt.status.Orphaned)
+			assert.Equal(t, got.Status.Expiring, tt.status.Expiring)
+			assert.Equal(t, got.Status.Revoked, tt.status.Revoked)
+
+			var orphans []string
+			for _, o := range got.Status.Orphans {
+				orphans = append(orphans, o.ID)
+			}
+			assert.DeepEqual(t, orphans, tt.orphans)
+
+			if tt.status.Expiring > 0 {
+				assert.Equal(t, got.Status.ExpiringCertificates[0].ID, "in-request")
+			}
+
+			for _, id := range tt.revoked {
+				serial := map[string]int64{"new-orphan": 12, "old-orphan": 13}[id]
+
+				rec := &v1.OriginCertificateRecord{}
+				assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Name: big.NewInt(serial).Text(16)}, rec))
+				assert.Assert(t, rec.Status.RevocationTime != nil, "revocation of %s should be recorded", id)
+			}
+
+			// The grace period of a certificate renewed long after being issued starts once it is found orphaned.
+			if tt.certificateRecords {
+				rec := &v1.OriginCertificateRecord{}
+				assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Name: big.NewInt(18).Text(16)}, rec))
+				assert.Assert(t, rec.Status.RevocationTime == nil)
+				assert.Assert(t, rec.Status.OrphanedTime != nil && rec.Status.OrphanedTime.Time.Equal(now))
+			}
+		})
+	}
+}
+
+func TestOriginInventoryDeletedMetrics(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	inventoryCertificates.WithLabelValues("default", "deleted", "tracked").Set(1)
+	inventoryLastSync.WithLabelValues("default", "deleted").Set(1)
+	inventoryRevocations.WithLabelValues("default", "deleted").Inc()
+	inventoryLastSync.WithLabelValues("default", "other").Set(1)
+
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	controller := &OriginInventoryController{Client: client, Reader: client, Log: logf.Log}
+
+	_, err := controller.Reconciler(client).Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "deleted"},
+	})
+	assert.NilError(t, err)
+
+	// Deleting a series reports whether it still existed.
+	assert.Assert(t, !inventoryCertificates.DeleteLabelValues("default", "deleted", "tracked"))
+	assert.Assert(t, !inventoryRevocations.DeleteLabelValues("default", "deleted"))
+	assert.Assert(t, !inventoryLastSync.DeleteLabelValues("default", "deleted"))
+	assert.Assert(t, inventoryLastSync.DeleteLabelValues("default", "other"))
+}
@@ -6,9 +6,7 @@ import (
 
 	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
 	"github.com/go-logr/logr"
-	core "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -21,6 +19,7 @@ type OriginIssuerController struct {
 	Reader client.Reader
 	Log    logr.Logger
 	Clock  clock.Clock
+	SigningSettings
 }
 
 //go:generate controller-gen rbac:roleName=originissuer-control paths=./. output:rbac:artifacts:config=../../deploy/rbac
@@ -28,6 +27,7 @@ type OriginIssuerController struct {
 // +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=originissuers,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=originissuers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile reconciles OriginIssuer resources by managing Cloudflare API provisioners.
@@ -40,67 +40,68 @@ func (r *OriginIssuerController) Reconcile(ctx context.Context, iss *v1.OriginIs
 		return reconcile.Result{}, err
 	}
 
-	switch {
-	case iss.Spec.Auth.ServiceKeyRef != nil:
-		secret := &core.Secret{}
-		secretNamespaceName := types.NamespacedName{
-			Namespace: iss.Namespace,
-			Name:      iss.Spec.Auth.ServiceKeyRef.Name,
-		}
+	if iss.Spec.Quota != nil && !r.RecordCertificates {
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, "QuotaUnavailable", quotaUnavailableMessage)
+		return reconcile.Result{}, nil
+	}
 
-		if err := r.Reader.Get(ctx, secretNamespaceName, secret); err != nil {
-			log.Error(err, "failed to retieve OriginIssuer auth secret", "namespace", secretNamespaceName.Namespace, "name", secretNamespaceName.Name)
+	if err := updateQuotaStatus(ctx, r.Client, r.Clock, "OriginIssuer", iss.Name, iss.Namespace, iss.Spec, &iss.Status); err != nil {
+		log.Error(err, "failed to count certificates issued by OriginIssuer")
+	}
 
-			if apierrors.IsNotFound(err) {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			} else {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
-			}
+	if err := r.APIPolicy.check("OriginIssuer", iss.Spec.API); err != nil {
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, reason, message)
+		return reconcile.Result{}, nil
+	}
 
-			return reconcile.Result{}, err
-		}
+	if err := localSigningModeError(iss.Spec, r.LocalSigning, r.AllowLocalSigningMode); err != nil {
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, reason, message)
+		return reconcile.Result{}, nil
+	}
 
-		_, ok := secret.Data[iss.Spec.Auth.ServiceKeyRef.Key]
-		if !ok {
-			err := fmt.Errorf("secret %s does not contain key %q", secret.Name, iss.Spec.Auth.ServiceKeyRef.Key)
-			log.Error(err, "failed to retrieve OriginIssuer auth secret")
-			_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+	// Issuers signing certificates locally do not use their credentials.
+	if signsLocally(iss.Spec, r.LocalSigning, r.AllowLocalSigningMode) {
+		return quotaResult(iss.Spec), r.setStatus(ctx, iss, v1.ConditionTrue, "Verified", "OriginIssuer verified and ready to sign certificates with the local CA")
+	}
 
-			return reconcile.Result{}, err
-		}
-	case iss.Spec.Auth.TokenRef != nil:
-		secret := &core.Secret{}
-		secretNamespaceName := types.NamespacedName{
-			Namespace: iss.Namespace,
-			Name:      iss.Spec.Auth.TokenRef.Name,
-		}
+	if len(IssuerCredentials(iss.Spec.Auth)) == 0 {
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, "MissingAuthentication", "No authentication methods were configured")
+		return reconcile.Result{}, nil
+	}
+
+	if err := checkCredentials(ctx, r.Reader, r.Credentials, r.Clock, iss.Spec.Auth, &iss.Status, originIssuerNamespace(iss.Namespace)); err != nil {
+		log.Error(err, "failed to retrieve OriginIssuer credentials")
+
+		reason, message := statusReason(err)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, reason, message)
+
+		return reconcile.Result{}, err
+	}
 
-		if err := r.Reader.Get(ctx, secretNamespaceName, secret); err != nil {
-			log.Error(err, "failed to retieve OriginIssuer auth secret", "namespace", secretNamespaceName.Namespace, "name", secretNamespaceName.Name)
+	if iss.Spec.API != nil && iss.Spec.API.CABundle != nil {
+		if _, err := caBundle(ctx, r.Reader, iss.Spec.API.CABundle, originIssuerNamespace(iss.Namespace)); err != nil {
+			log.Error(err, "failed to retrieve OriginIssuer CA bundle")
 
 			if apierrors.IsNotFound(err) {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve CA bundle: %v", err))
 			} else {
-				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+				_ = r.setStatus(ctx, iss, v1.ConditionFalse, "Error", fmt.Sprintf("Failed to retrieve CA bundle: %v", err))
 			}
 
 			return reconcile.Result{}, err
 		}
+	}
 
-		_, ok := secret.Data[iss.Spec.Auth.TokenRef.Key]
-		if !ok {
-			err := fmt.Errorf("secret %s does not contain key %q", secret.Name, iss.Spec.Auth.TokenRef.Key)
-			log.Error(err, "failed to retrieve OriginIssuer auth secret")
-			_ = r.setStatus(ctx, iss, v1.ConditionFalse, "NotFound", fmt.Sprintf("Failed to retrieve auth secret: %v", err))
+	if retryAfter, unavailable := apiUnavailable(r.Breakers, iss.Spec, originIssuerNamespace(iss.Namespace)); unavailable {
+		log.Info("Cloudflare API is unavailable with every credential", "retryAfter", retryAfter)
+		_ = r.setStatus(ctx, iss, v1.ConditionFalse, apiUnavailableReason, apiUnavailableMessage)
 
-			return reconcile.Result{}, err
-		}
-	default:
-		_ = r.setStatus(ctx, iss, v1.ConditionFalse, "MissingAuthentication", "No authentication methods were configured")
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: retryAfter}, nil
 	}
 
-	return reconcile.Result{}, r.setStatus(ctx, iss, v1.ConditionTrue, "Verified", "OriginIssuer verified and ready to sign certificates")
+	return quotaResult(iss.Spec), r.setStatus(ctx, iss, v1.ConditionTrue, "Verified", "OriginIssuer verified and ready to sign certificates")
 }
 
 // setStatus is a helper function to set the Issuer status condition with reason and message, and update the API.
@@ -120,5 +121,48 @@ func validateOriginIssuer(s v1.OriginIssuerSpec) error {
 		return fmt.Errorf("spec.requestType has invalid value %q", s.RequestType)
 	}
 
+	if err := validateCredentials(s.Auth.Credentials); err != nil {
+		return err
+	}
+
+	if err := validatePolicy(s.Policy); err != nil {
+		return err
+	}
+
+	if err := validateQuota(s.Quota); err != nil {
+		return err
+	}
+
+	return validateAPI(s.API)
+}
+
+// validateCredentials checks that each credential has a unique name and exactly one source.
+func validateCredentials(creds []v1.OriginIssuerCredential) error {
+	names := make(map[string]bool, len(creds))
+
+	for i, cred := range creds {
+		switch {
+		case cred.Name == "":
+			return fmt.Errorf("spec.auth.credentials[%d].name cannot be empty", i)
+		case cred.Name == credentialName(v1.OriginIssuerCredential{}):
+			return fmt.Errorf("spec.auth.credentials[%d].name cannot be %q, which identifies the credential configured directly on spec.auth", i, cred.Name)
+		case names[cred.Name]:
+			return fmt.Errorf("spec.auth.credentials[%d].name has duplicate value %q", i, cred.Name)
+		}
+
+		names[cred.Name] = true
+
+		sources := 0
+		for _, set := range []bool{cred.ServiceKeyRef != nil, cred.TokenRef != nil, cred.ServiceKeyFile != "", cred.TokenFile != ""} {
+			if set {
+				sources++
+			}
+		}
+
+		if sources != 1 {
+			return fmt.Errorf("spec.auth.credentials[%d] must set exactly one of serviceKeyRef, tokenRef, serviceKeyFile or tokenFile", i)
+		}
+	}
+
 	return nil
 }
@@ -2,10 +2,13 @@ package controllers
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cloudflare/origin-ca-issuer/internal/credfile"
 	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +34,22 @@ func TestOriginIssuerReconcile(t *testing.T) {
 	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
 	now := metav1.NewTime(clock.Now())
 
+	credentialDir := t.TempDir()
+	for _, namespace := range []string{"default", "other"} {
+		if err := os.MkdirAll(filepath.Join(credentialDir, namespace), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(credentialDir, namespace, "token"), []byte("djEuMC0weDAwQkFCMTBD\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	credentials, err := credfile.NewStore([]string{credentialDir}, logf.Log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []struct {
 		name          string
 		objects       []runtime.Object
@@ -76,6 +95,16 @@ func TestOriginIssuerReconcile(t *testing.T) {
 						Message:            "OriginIssuer verified and ready to sign certificates",
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "999",
+					},
+				},
 			},
 			namespaceName: types.NamespacedName{
 				Namespace: "default",
@@ -111,6 +140,15 @@ func TestOriginIssuerReconcile(t *testing.T) {
 						Message:            `Failed to retrieve auth secret: secrets "issuer-service-key" not found`,
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "NotFound",
+						Message:            `Failed to retrieve auth secret: secrets "issuer-service-key" not found`,
+					},
+				},
 			},
 			error: `secrets "issuer-service-key" not found`,
 			namespaceName: types.NamespacedName{
@@ -154,6 +192,15 @@ func TestOriginIssuerReconcile(t *testing.T) {
 						Message:            `Failed to retrieve auth secret: secret issuer-service-key does not contain key "key"`,
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "NotFound",
+						Message:            `Failed to retrieve auth secret: secret issuer-service-key does not contain key "key"`,
+					},
+				},
 			},
 			error: `secret issuer-service-key does not contain key "key"`,
 			namespaceName: types.NamespacedName{
@@ -199,7 +246,279 @@ func TestOriginIssuerReconcile(t *testing.T) {
 						Message:            "OriginIssuer verified and ready to sign certificates",
 					},
 				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "999",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "working tokenFile",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenFile: filepath.Join(credentialDir, "default", "token"),
+						},
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Verified",
+						Message:            "OriginIssuer verified and ready to sign certificates",
+					},
+				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "file:1",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "tokenFile outside credential directories",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+						},
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Error",
+						Message:            "Failed to read credential file: credential file /var/run/secrets/kubernetes.io/serviceaccount/token is not within the default subdirectory of an allowed credential directory",
+					},
+				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Error",
+						Message:            "Failed to read credential file: credential file /var/run/secrets/kubernetes.io/serviceaccount/token is not within the default subdirectory of an allowed credential directory",
+					},
+				},
+			},
+			error: "credential file /var/run/secrets/kubernetes.io/serviceaccount/token is not within the default subdirectory of an allowed credential directory",
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "tokenFile of another namespace",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenFile: filepath.Join(credentialDir, "other", "token"),
+						},
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Error",
+						Message:            "Failed to read credential file: credential file " + filepath.Join(credentialDir, "other", "token") + " is not within the default subdirectory of an allowed credential directory",
+					},
+				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "Error",
+						Message:            "Failed to read credential file: credential file " + filepath.Join(credentialDir, "other", "token") + " is not within the default subdirectory of an allowed credential directory",
+					},
+				},
+			},
+			error: "credential file " + filepath.Join(credentialDir, "other", "token") + " is not within the default subdirectory of an allowed credential directory",
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "working caBundle configMapRef",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenRef: &v1.SecretKeySelector{
+								Name: "issuer-api-token",
+								Key:  "token",
+							},
+						},
+						API: &v1.OriginIssuerAPI{
+							Endpoint: "https://api.cloudflare.example",
+							Proxy:    "http://proxy.example:3128",
+							CABundle: &v1.CABundleSource{
+								ConfigMapRef: &v1.ConfigMapKeySelector{
+									Name: "cloudflare-ca",
+									Key:  "ca.crt",
+								},
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer-api-token",
+						Namespace: "default",
+					},
+					Data: map[string][]byte{
+						"token": []byte("djEuMC0weDAwQkFCMTBD"),
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloudflare-ca",
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						"ca.crt": "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n",
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Verified",
+						Message:            "OriginIssuer verified and ready to sign certificates",
+					},
+				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "999",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "missing caBundle configMapRef",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenRef: &v1.SecretKeySelector{
+								Name: "issuer-api-token",
+								Key:  "token",
+							},
+						},
+						API: &v1.OriginIssuerAPI{
+							CABundle: &v1.CABundleSource{
+								ConfigMapRef: &v1.ConfigMapKeySelector{
+									Name: "cloudflare-ca",
+									Key:  "ca.crt",
+								},
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer-api-token",
+						Namespace: "default",
+					},
+					Data: map[string][]byte{
+						"token": []byte("djEuMC0weDAwQkFCMTBD"),
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "NotFound",
+						Message:            `Failed to retrieve CA bundle: configmaps "cloudflare-ca" not found`,
+					},
+				},
+				Credentials: []v1.OriginIssuerCredentialStatus{
+					{
+						Name:               "spec.auth",
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: &now,
+						Reason:             "Available",
+						Message:            "Credential is available",
+						ObservedVersion:    "999",
+					},
+				},
 			},
+			error: `configmaps "cloudflare-ca" not found`,
 			namespaceName: types.NamespacedName{
 				Namespace: "default",
 				Name:      "foo",
@@ -235,6 +554,181 @@ func TestOriginIssuerReconcile(t *testing.T) {
 				Name:      "foo",
 			},
 		},
+		{
+			name: "endpoint not allowed",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenRef: &v1.SecretKeySelector{
+								Name: "issuer-api-token",
+								Key:  "token",
+							},
+						},
+						API: &v1.OriginIssuerAPI{
+							Endpoint: "https://169.254.169.254",
+						},
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "APINotAllowed",
+						Message:            `spec.api.endpoint "https://169.254.169.254" is not allowed for OriginIssuers, unless listed in the controller's --allowed-api-endpoints`,
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "insecure endpoint",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenRef: &v1.SecretKeySelector{
+								Name: "issuer-api-token",
+								Key:  "token",
+							},
+						},
+						API: &v1.OriginIssuerAPI{
+							Endpoint: "http://api.cloudflare.example",
+						},
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "APINotAllowed",
+						Message:            `spec.api.endpoint "http://api.cloudflare.example" must use https, unless the controller is started with --allow-insecure-api-endpoints`,
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "proxy not allowed",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						Auth: v1.OriginIssuerAuthentication{
+							TokenRef: &v1.SecretKeySelector{
+								Name: "issuer-api-token",
+								Key:  "token",
+							},
+						},
+						API: &v1.OriginIssuerAPI{
+							Proxy: "http://kubernetes.default.svc:443",
+						},
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "APINotAllowed",
+						Message:            `spec.api.proxy "http://kubernetes.default.svc:443" is not allowed for OriginIssuers, unless listed in the controller's --allowed-api-proxies`,
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "quota without certificate records",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						SigningMode: v1.SigningModeLocal,
+						Quota:       &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{PerHour: 10}},
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "QuotaUnavailable",
+						Message:            "Quota cannot be enforced without certificate records, enable them with --enable-certificate-records",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
+		{
+			name: "local signing mode not allowed",
+			objects: []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginRSA,
+						SigningMode: v1.SigningModeLocal,
+					},
+				},
+			},
+			expected: v1.OriginIssuerStatus{
+				Conditions: []v1.OriginIssuerCondition{
+					{
+						Type:               v1.ConditionReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: &now,
+						Reason:             "LocalSigningModeNotAllowed",
+						Message:            "Issuer is in the Local signing mode, which is only honoured when the controller is started with --allow-local-signing-mode",
+					},
+				},
+			},
+			namespaceName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "foo",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -251,6 +745,13 @@ func TestOriginIssuerReconcile(t *testing.T) {
 				Reader: client,
 				Clock:  clock,
 				Log:    logf.Log,
+				SigningSettings: SigningSettings{
+					Credentials: credentials,
+					APIPolicy: APIPolicy{
+						AllowedEndpoints: []string{"https://api.cloudflare.example"},
+						AllowedProxies:   []string{"http://proxy.example:3128"},
+					},
+				},
 			}
 
 			_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
@@ -273,3 +774,216 @@ func TestOriginIssuerReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOriginIssuerAPI(t *testing.T) {
+	tests := []struct {
+		name  string
+		api   *v1.OriginIssuerAPI
+		error string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "valid",
+			api: &v1.OriginIssuerAPI{
+				Endpoint: "https://api.cloudflare.com",
+				Timeout:  &metav1.Duration{Duration: time.Minute},
+				Proxy:    "http://proxy.example:3128",
+			},
+		},
+		{
+			name:  "endpoint without scheme",
+			api:   &v1.OriginIssuerAPI{Endpoint: "api.cloudflare.com"},
+			error: `spec.api.endpoint has invalid value "api.cloudflare.com": scheme must be http or https`,
+		},
+		{
+			name:  "proxy without host",
+			api:   &v1.OriginIssuerAPI{Proxy: "http://"},
+			error: `spec.api.proxy has invalid value "http://": host must be set`,
+		},
+		{
+			name:  "negative timeout",
+			api:   &v1.OriginIssuerAPI{Timeout: &metav1.Duration{Duration: -time.Second}},
+			error: "spec.api.timeout has invalid value -1s: must be positive",
+		},
+		{
+			name:  "empty caBundle",
+			api:   &v1.OriginIssuerAPI{CABundle: &v1.CABundleSource{}},
+			error: "spec.api.caBundle must set one of secretRef or configMapRef",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOriginIssuer(v1.OriginIssuerSpec{
+				RequestType: v1.RequestTypeOriginECC,
+				API:         tt.api,
+			})
+
+			if tt.error == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Fatalf("expected error %q", tt.error)
+			} else if diff := cmp.Diff(err.Error(), tt.error); diff != "" {
+				t.Fatalf("diff: (-wanted +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIPolicy(t *testing.T) {
+	policy := APIPolicy{
+		AllowedEndpoints: []string{"https://api.cloudflare.example"},
+		AllowedProxies:   []string{"http://proxy.example:3128"},
+	}
+
+	tests := []struct {
+		name   string
+		kind   string
+		policy APIPolicy
+		api    *v1.OriginIssuerAPI
+		error  string
+	}{
+		{
+			name: "unset",
+			kind: "OriginIssuer",
+		},
+		{
+			name: "allowed endpoint and proxy",
+			kind: "OriginIssuer",
+			api:  &v1.OriginIssuerAPI{Endpoint: "https://API.cloudflare.example/client/v4", Proxy: "http://proxy.example:3128"},
+		},
+		{
+			name:  "endpoint on another port",
+			kind:  "OriginIssuer",
+			api:   &v1.OriginIssuerAPI{Endpoint: "https://api.cloudflare.example:8443"},
+			error: `spec.api.endpoint "https://api.cloudflare.example:8443" is not allowed for OriginIssuers, unless listed in the controller's --allowed-api-endpoints`,
+		},
+		{
+			name:  "in-cluster proxy",
+			kind:  "OriginIssuer",
+			api:   &v1.OriginIssuerAPI{Proxy: "http://10.0.0.1:3128"},
+			error: `spec.api.proxy "http://10.0.0.1:3128" is not allowed for OriginIssuers, unless listed in the controller's --allowed-api-proxies`,
+		},
+		{
+			name: "any endpoint and proxy of ClusterOriginIssuers",
+			kind: "ClusterOriginIssuer",
+			api:  &v1.OriginIssuerAPI{Endpoint: "https://staging.example", Proxy: "http://10.0.0.1:3128"},
+		},
+		{
+			name:  "insecure endpoint of ClusterOriginIssuers",
+			kind:  "ClusterOriginIssuer",
+			api:   &v1.OriginIssuerAPI{Endpoint: "http://staging.example"},
+			error: `spec.api.endpoint "http://staging.example" must use https, unless the controller is started with --allow-insecure-api-endpoints`,
+		},
+		{
+			name:   "allowed insecure endpoint",
+			kind:   "OriginIssuer",
+			policy: APIPolicy{AllowInsecureEndpoints: true, AllowedEndpoints: []string{"http://staging.example"}},
+			api:    &v1.OriginIssuerAPI{Endpoint: "http://staging.example"},
+		},
+		{
+			name:   "insecure endpoint allowed only over https",
+			kind:   "OriginIssuer",
+			policy: APIPolicy{AllowInsecureEndpoints: true, AllowedEndpoints: []string{"https://staging.example"}},
+			api:    &v1.OriginIssuerAPI{Endpoint: "http://staging.example"},
+			error:  `spec.api.endpoint "http://staging.example" is not allowed for OriginIssuers, unless listed in the controller's --allowed-api-endpoints`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			if tt.policy.AllowedEndpoints != nil {
+				p = tt.policy
+			}
+
+			err := p.check(tt.kind, tt.api)
+			if tt.error == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Fatalf("expected error %q", tt.error)
+			} else if diff := cmp.Diff(err.Error(), tt.error); diff != "" {
+				t.Fatalf("diff: (-wanted +got)\n%s", diff)
+			} else if reason, _ := statusReason(err); reason != "APINotAllowed" {
+				t.Fatalf("unexpected reason %q", reason)
+			}
+		})
+	}
+}
+
+func TestValidateOriginIssuerCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials []v1.OriginIssuerCredential
+		error       string
+	}{
+		{
+			name: "valid",
+			credentials: []v1.OriginIssuerCredential{
+				{Name: "primary", TokenRef: &v1.SecretKeySelector{Name: "primary", Key: "token"}},
+				{Name: "secondary", TokenFile: "/etc/origin-ca-issuer/token"},
+			},
+		},
+		{
+			name: "missing name",
+			credentials: []v1.OriginIssuerCredential{
+				{TokenRef: &v1.SecretKeySelector{Name: "primary", Key: "token"}},
+			},
+			error: "spec.auth.credentials[0].name cannot be empty",
+		},
+		{
+			name: "duplicate name",
+			credentials: []v1.OriginIssuerCredential{
+				{Name: "primary", TokenRef: &v1.SecretKeySelector{Name: "primary", Key: "token"}},
+				{Name: "primary", TokenRef: &v1.SecretKeySelector{Name: "secondary", Key: "token"}},
+			},
+			error: `spec.auth.credentials[1].name has duplicate value "primary"`,
+		},
+		{
+			name: "reserved name",
+			credentials: []v1.OriginIssuerCredential{
+				{Name: "spec.auth", TokenRef: &v1.SecretKeySelector{Name: "primary", Key: "token"}},
+			},
+			error: `spec.auth.credentials[0].name cannot be "spec.auth", which identifies the credential configured directly on spec.auth`,
+		},
+		{
+			name: "multiple sources",
+			credentials: []v1.OriginIssuerCredential{
+				{
+					Name:          "primary",
+					TokenRef:      &v1.SecretKeySelector{Name: "primary", Key: "token"},
+					ServiceKeyRef: &v1.SecretKeySelector{Name: "primary", Key: "key"},
+				},
+			},
+			error: "spec.auth.credentials[0] must set exactly one of serviceKeyRef, tokenRef, serviceKeyFile or tokenFile",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOriginIssuer(v1.OriginIssuerSpec{
+				RequestType: v1.RequestTypeOriginECC,
+				Auth:        v1.OriginIssuerAuthentication{Credentials: tt.credentials},
+			})
+
+			if tt.error == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Fatalf("expected error %q", tt.error)
+			} else if diff := cmp.Diff(err.Error(), tt.error); diff != "" {
+				t.Fatalf("diff: (-wanted +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// quotaStatusInterval is how often the quota usage reported in the status of issuers with a
+// quota is refreshed.
+const quotaStatusInterval = time.Minute
+
+// quotaUnavailableMessage explains why quotas cannot be enforced when certificates are not recorded.
+const quotaUnavailableMessage = "Quota cannot be enforced without certificate records, enable them with --enable-certificate-records"
+
+// issuance is a certificate issued by an issuer, as recorded by an OriginCertificateRecord.
+type issuance struct {
+	namespace string
+	issued    time.Time
+	notAfter  time.Time
+
+	// serial is the serial number of the certificate, once it is signed.
+	serial string
+
+	// source identifies the resource the certificate is used by, as returned by recordedCertificate.
+	source string
+
+	// current is set unless the certificate was revoked, or superseded by a certificate issued
+	// later for the same resource. Only current certificates count as active.
+	current bool
+}
+
+// active reports whether the issuance counts as an active certificate at now.
+func (i issuance) active(now time.Time) bool {
+	return i.current && i.notAfter.After(now)
+}
+
+// listIssuances returns the certificates recorded as issued by the issuer of kind and name.
+// OriginIssuers are retrieved from namespace, so only certificates issued for namespace are
+// returned for them. Certificates are counted in the namespace of their record, which is the
+// namespace of the issuer for CertificateSigningRequests.
+func listIssuances(ctx context.Context, c client.Reader, kind, name, namespace string) ([]issuance, error) {
+	var opts []client.ListOption
+	if kind == "OriginIssuer" {
+		opts = append(opts, client.MatchingLabels{v1.RecordNamespaceLabel: namespace})
+	}
+
+	records := &v1.OriginCertificateRecordList{}
+	if err := c.List(ctx, records, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list OriginCertificateRecords: %w", err)
+	}
+
+	latest := latestRecords(records.Items)
+
+	var issuances []issuance
+	for _, r := range records.Items {
+		if cmp.Or(r.Spec.IssuerRef.Kind, "OriginIssuer") != kind || r.Spec.IssuerRef.Name != name {
+			continue
+		}
+
+		source := recordedCertificate(r.Spec.Source)
+		issuances = append(issuances, issuance{
+			namespace: r.Labels[v1.RecordNamespaceLabel],
+			issued:    r.CreationTimestamp.Time,
+			notAfter:  r.Spec.NotAfter.Time,
+			serial:    r.Name,
+			source:    source,
+			current:   latest[source].Name == r.Name && r.Status.RevocationTime == nil,
+		})
+	}
+
+	return issuances, nil
+}
+
+// Quotas reserves the quota of issuers for the certificates being signed, until the
+// OriginCertificateRecords of the certificates are listed. Otherwise, certificates signed
+// concurrently, or whose records are not yet in the cache, would not count against the quota.
+// Controllers sharing Quotas count each other's certificates. The zero value is ready to use.
+type Quotas struct {
+	mu      sync.Mutex
+	issuers map[string]*issuerQuota
+}
+
+// issuerQuota holds the reservations of a single issuer. Its lock is held while the quota is
+// checked, so that reservations are checked one at a time.
+type issuerQuota struct {
+	mu      sync.Mutex
+	pending []*quotaReservation
+}
+
+// quotaReservation is a certificate counted against the quota of an issuer until it is recorded.
+// A nil *quotaReservation, returned for issuers without a quota, reserves nothing.
+type quotaReservation struct {
+	quota *issuerQuota
+	issuance
+
+	// signed is set once the certificate is signed, after which the reservation is only
+	// released once the certificate is recorded or no longer counts against any limit.
+	signed bool
+}
+
+// issuer returns the reservations of the issuer identified by key.
+func (q *Quotas) issuer(key string) *issuerQuota {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.issuers == nil {
+		q.issuers = make(map[string]*issuerQuota)
+	}
+
+	iq, ok := q.issuers[key]
+	if !ok {
+		iq = &issuerQuota{}
+		q.issuers[key] = iq
+	}
+
+	return iq
+}
+
+// reserve counts a certificate issued at now, valid until notAfter, for the resource identified
+// by source in namespace, against the quota of iss. The limit another certificate would exceed
+// is returned instead if the certificates recorded and reserved already reach it. The certificate
+// supersedes those issued earlier for source, which no longer count as active.
+func (q *Quotas) reserve(ctx context.Context, c client.Reader, iss *issuer, namespace, source string, now, notAfter time.Time) (*quotaReservation, *quotaExceeded, error) {
+	iq := q.issuer(iss.key())
+
+	iq.mu.Lock()
+	defer iq.mu.Unlock()
+
+	issuances, err := listIssuances(ctx, c, iss.kind, iss.name.Name, iss.name.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recorded := make(map[string]bool, len(issuances))
+	for _, i := range issuances {
+		recorded[i.serial] = true
+	}
+
+	iq.pending = slices.DeleteFunc(iq.pending, func(r *quotaReservation) bool {
+		expired := now.Sub(r.issued) >= 24*time.Hour && !r.notAfter.After(now)
+		return r.signed && (recorded[r.serial] || expired)
+	})
+
+	// Each reservation supersedes the certificates recorded and reserved earlier for its resource.
+	supersede := func(source string) {
+		for i := range issuances {
+			if issuances[i].source == source {
+				issuances[i].current = false
+			}
+		}
+	}
+
+	for _, r := range iq.pending {
+		supersede(r.source)
+		issuances = append(issuances, r.issuance)
+	}
+	supersede(source)
+
+	if exceeded := checkQuota(iss.spec.Quota, issuances, namespace, now); exceeded != nil {
+		return nil, exceeded, nil
+	}
+
+	r := &quotaReservation{
+		quota: iq,
+		issuance: issuance{
+			namespace: namespace,
+			issued:    now,
+			notAfter:  notAfter,
+			source:    source,
+			current:   true,
+		},
+	}
+	iq.pending = append(iq.pending, r)
+
+	return r, nil, nil
+}
+
+// reserveQuota reserves the quota of iss for a certificate signed for source in namespace, valid
+// for duration, as described by Quotas.reserve. Issuers without a quota reserve nothing.
+func (s *issuerSigner) reserveQuota(ctx context.Context, iss *issuer, namespace string, source v1.RecordSource, duration time.Duration) (*quotaReservation, *quotaExceeded, error) {
+	if iss.spec.Quota == nil {
+		return nil, nil, nil
+	}
+
+	now := s.clock.Now()
+	notAfter := now.Add(time.Duration(provisioners.Validity(duration)) * 24 * time.Hour)
+
+	return s.quotas.reserve(ctx, s.client, iss, namespace, recordedCertificate(source), now, notAfter)
+}
+
+// confirm records that the certificate of the reservation was signed, as resp. The reservation is
+// kept until the certificate is recorded.
+func (r *quotaReservation) confirm(resp *cfapi.SignResponse) {
+	if r == nil {
+		return
+	}
+
+	r.quota.mu.Lock()
+	defer r.quota.mu.Unlock()
+
+	r.signed = true
+	if cert, err := pki.DecodeX509CertificateBytes([]byte(resp.Certificate)); err == nil {
+		r.serial = serialNumber(cert)
+		r.notAfter = cert.NotAfter
+	}
+}
+
+// release releases the reservation of a certificate that was not signed.
+func (r *quotaReservation) release() {
+	if r == nil {
+		return
+	}
+
+	r.quota.mu.Lock()
+	defer r.quota.mu.Unlock()
+
+	r.quota.pending = slices.DeleteFunc(r.quota.pending, func(p *quotaReservation) bool { return p == r })
+}
+
+// issuanceUsage counts issuances at now.
+func issuanceUsage(issuances []issuance, now time.Time) v1.IssuanceUsage {
+	var usage v1.IssuanceUsage
+
+	for _, i := range issuances {
+		if now.Sub(i.issued) < time.Hour {
+			usage.IssuedLastHour++
+		}
+		if now.Sub(i.issued) < 24*time.Hour {
+			usage.IssuedLastDay++
+		}
+		if i.active(now) {
+			usage.Active++
+		}
+	}
+
+	return usage
+}
+
+// quotaStatus returns the usage of an issuer's quota by issuances at now.
+func quotaStatus(issuances []issuance, now time.Time) *v1.OriginIssuerQuotaStatus {
+	byNamespace := make(map[string][]issuance)
+	for _, i := range issuances {
+		byNamespace[i.namespace] = append(byNamespace[i.namespace], i)
+	}
+
+	status := &v1.OriginIssuerQuotaStatus{
+		Issuer:         issuanceUsage(issuances, now),
+		LastUpdateTime: &metav1.Time{Time: now},
+	}
+
+	for namespace, issuances := range byNamespace {
+		usage := issuanceUsage(issuances, now)
+		if namespace == "" || (usage.IssuedLastDay == 0 && usage.Active == 0) {
+			continue
+		}
+
+		status.Namespaces = append(status.Namespaces, v1.NamespaceIssuanceUsage{Namespace: namespace, IssuanceUsage: usage})
+	}
+
+	slices.SortFunc(status.Namespaces, func(a, b v1.NamespaceIssuanceUsage) int {
+		return cmp.Compare(a.Namespace, b.Namespace)
+	})
+
+	return status
+}
+
+// quotaExceeded describes the limit of a quota another certificate would exceed.
+type quotaExceeded struct {
+	message string
+
+	// retryAfter is how long until the limit allows another certificate.
+	retryAfter time.Duration
+}
+
+// checkQuota returns the limit of quota that issuing another certificate for namespace would
+// exceed at now, or nil if the certificate is within quota. Certificates issued outside of any
+// namespace, for CertificateSigningRequests referencing a ClusterOriginIssuer, are only counted
+// against the limits of the issuer.
+func checkQuota(quota *v1.OriginIssuerQuota, issuances []issuance, namespace string, now time.Time) *quotaExceeded {
+	if exceeded := checkLimits(quota.Issuer, issuances, "the issuer", now); exceeded != nil {
+		return exceeded
+	}
+
+	if namespace == "" {
+		return nil
+	}
+
+	var inNamespace []issuance
+	for _, i := range issuances {
+		if i.namespace == namespace {
+			inNamespace = append(inNamespace, i)
+		}
+	}
+
+	return checkLimits(quota.Namespace, inNamespace, fmt.Sprintf("namespace %s", namespace), now)
+}
+
+// checkLimits returns the first of limits reached by issuances at now, describing the issuances as scope.
+func checkLimits(limits *v1.IssuanceLimits, issuances []issuance, scope string, now time.Time) *quotaExceeded {
+	if limits == nil {
+		return nil
+	}
+
+	windows := []struct {
+		limit  int32
+		window time.Duration
+		name   string
+	}{
+		{limits.PerHour, time.Hour, "hour"},
+		{limits.PerDay, 24 * time.Hour, "day"},
+	}
+
+	for _, w := range windows {
+		if w.limit <= 0 {
+			continue
+		}
+
+		// The limit allows another certificate once the first issued within the window leaves it.
+		var issued []time.Time
+		for _, i := range issuances {
+			if now.Sub(i.issued) < w.window {
+				issued = append(issued, i.issued)
+			}
+		}
+
+		if len(issued) >= int(w.limit) {
+			slices.SortFunc(issued, time.Time.Compare)
+
+			return &quotaExceeded{
+				message:    fmt.Sprintf("%s has been issued %d certificates in the last %s, reaching its limit of %d", scope, len(issued), w.name, w.limit),
+				retryAfter: issued[len(issued)-int(w.limit)].Add(w.window).Sub(now),
+			}
+		}
+	}
+
+	if limits.MaxActive > 0 {
+		var active []time.Time
+		for _, i := range issuances {
+			if i.active(now) {
+				active = append(active, i.notAfter)
+			}
+		}
+
+		if len(active) >= int(limits.MaxActive) {
+			slices.SortFunc(active, time.Time.Compare)
+
+			return &quotaExceeded{
+				message:    fmt.Sprintf("%s has %d active certificates, reaching its limit of %d", scope, len(active), limits.MaxActive),
+				retryAfter: active[len(active)-int(limits.MaxActive)].Sub(now),
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateQuota ensures the limits of quota are not negative.
+func validateQuota(quota *v1.OriginIssuerQuota) error {
+	if quota == nil {
+		return nil
+	}
+
+	for _, scope := range []struct {
+		field  string
+		limits *v1.IssuanceLimits
+	}{{"issuer", quota.Issuer}, {"namespace", quota.Namespace}} {
+		field, limits := scope.field, scope.limits
+		if limits == nil {
+			continue
+		}
+
+		switch {
+		case limits.PerHour < 0:
+			return fmt.Errorf("spec.quota.%s.perHour has invalid value %d: must not be negative", field, limits.PerHour)
+		case limits.PerDay < 0:
+			return fmt.Errorf("spec.quota.%s.perDay has invalid value %d: must not be negative", field, limits.PerDay)
+		case limits.MaxActive < 0:
+			return fmt.Errorf("spec.quota.%s.maxActive has invalid value %d: must not be negative", field, limits.MaxActive)
+		}
+	}
+
+	return nil
+}
+
+// updateQuotaStatus sets the quota usage in the status of an issuer of kind with spec, retrieved
+// from namespace if it is an OriginIssuer. Issuers without a quota report no usage. The status is
+// left unchanged if the usage is, so that refreshing it does not update the issuer.
+func updateQuotaStatus(ctx context.Context, c client.Reader, cl clock.Clock, kind, name, namespace string, spec v1.OriginIssuerSpec, status *v1.OriginIssuerStatus) error {
+	if spec.Quota == nil {
+		status.Quota = nil
+		return nil
+	}
+
+	issuances, err := listIssuances(ctx, c, kind, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	quota := quotaStatus(issuances, cl.Now())
+	if status.Quota != nil && equality.Semantic.DeepEqual(status.Quota.Issuer, quota.Issuer) && equality.Semantic.DeepEqual(status.Quota.Namespaces, quota.Namespaces) {
+		return nil
+	}
+
+	status.Quota = quota
+
+	return nil
+}
+
+// quotaResult returns the result of reconciling a ready issuer with spec, refreshing the usage of
+// its quota periodically.
+func quotaResult(spec v1.OriginIssuerSpec) reconcile.Result {
+	if spec.Quota == nil {
+		return reconcile.Result{}
+	}
+
+	return reconcile.Result{RequeueAfter: quotaStatusInterval}
+}
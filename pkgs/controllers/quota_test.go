@@ -0,0 +1,448 @@
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"testing"
+	"time"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmgen "github.com/cert-manager/cert-manager/test/unit/gen"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakeClock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCheckQuota(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	issued := func(namespace string, ago, validity time.Duration) issuance {
+		return issuance{namespace: namespace, issued: now.Add(-ago), notAfter: now.Add(-ago + validity), current: true}
+	}
+	superseded := issued("default", 40*24*time.Hour, 90*24*time.Hour)
+	superseded.current = false
+
+	issuances := []issuance{
+		issued("default", 10*time.Minute, 7*24*time.Hour),
+		issued("default", 30*time.Minute, 7*24*time.Hour),
+		issued("other", 20*time.Minute, 7*24*time.Hour),
+		issued("default", 5*time.Hour, 7*24*time.Hour),
+		issued("default", 30*24*time.Hour, 90*24*time.Hour),
+		issued("default", 100*24*time.Hour, 90*24*time.Hour),
+		superseded,
+	}
+
+	tests := []struct {
+		name       string
+		quota      *v1.OriginIssuerQuota
+		namespace  string
+		message    string
+		retryAfter time.Duration
+	}{
+		{
+			name:      "no limits",
+			quota:     &v1.OriginIssuerQuota{},
+			namespace: "default",
+		},
+		{
+			name:      "within limits",
+			quota:     &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{PerHour: 3, PerDay: 4, MaxActive: 5}},
+			namespace: "default",
+		},
+		{
+			name:       "namespace per hour",
+			quota:      &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{PerHour: 2}},
+			namespace:  "default",
+			message:    "namespace default has been issued 2 certificates in the last hour, reaching its limit of 2",
+			retryAfter: 30 * time.Minute,
+		},
+		{
+			name:      "other namespace per hour",
+			quota:     &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{PerHour: 2}},
+			namespace: "other",
+		},
+		{
+			name:       "issuer per hour",
+			quota:      &v1.OriginIssuerQuota{Issuer: &v1.IssuanceLimits{PerHour: 2}},
+			namespace:  "other",
+			message:    "the issuer has been issued 3 certificates in the last hour, reaching its limit of 2",
+			retryAfter: 40 * time.Minute,
+		},
+		{
+			name:       "namespace per day",
+			quota:      &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{PerDay: 3}},
+			namespace:  "default",
+			message:    "namespace default has been issued 3 certificates in the last day, reaching its limit of 3",
+			retryAfter: 19 * time.Hour,
+		},
+		{
+			name:       "namespace max active",
+			quota:      &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{MaxActive: 4}},
+			namespace:  "default",
+			message:    "namespace default has 4 active certificates, reaching its limit of 4",
+			retryAfter: 7*24*time.Hour - 5*time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			exceeded := checkQuota(tt.quota, issuances, tt.namespace, now)
+			if tt.message == "" {
+				assert.Assert(t, exceeded == nil, "unexpected quota exceeded: %v", exceeded)
+				return
+			}
+
+			assert.Assert(t, exceeded != nil)
+			assert.Equal(t, exceeded.message, tt.message)
+			assert.Equal(t, exceeded.retryAfter, tt.retryAfter)
+		})
+	}
+}
+
+func TestQuotaStatus(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	status := quotaStatus([]issuance{
+		{namespace: "default", issued: now.Add(-10 * time.Minute), notAfter: now.Add(7 * 24 * time.Hour), current: true},
+		{namespace: "default", issued: now.Add(-2 * time.Hour), notAfter: now.Add(7 * 24 * time.Hour), current: true},
+		{namespace: "default", issued: now.Add(-3 * time.Hour), notAfter: now.Add(7 * 24 * time.Hour)},
+		{namespace: "apps", issued: now.Add(-30 * 24 * time.Hour), notAfter: now.Add(60 * 24 * time.Hour), current: true},
+		{namespace: "expired", issued: now.Add(-100 * 24 * time.Hour), notAfter: now.Add(-10 * 24 * time.Hour), current: true},
+	}, now)
+
+	assert.DeepEqual(t, status, &v1.OriginIssuerQuotaStatus{
+		Issuer: v1.IssuanceUsage{IssuedLastHour: 1, IssuedLastDay: 3, Active: 3},
+		Namespaces: []v1.NamespaceIssuanceUsage{
+			{Namespace: "apps", IssuanceUsage: v1.IssuanceUsage{Active: 1}},
+			{Namespace: "default", IssuanceUsage: v1.IssuanceUsage{IssuedLastHour: 1, IssuedLastDay: 3, Active: 2}},
+		},
+		LastUpdateTime: &metav1.Time{Time: now},
+	})
+}
+
+func TestListIssuances(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	revoked := metav1.NewTime(now.Add(-time.Hour))
+
+	newRecord := func(name, source string, ago time.Duration, revocation *metav1.Time) *v1.OriginCertificateRecord {
+		return &v1.OriginCertificateRecord{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Labels:            map[string]string{v1.RecordNamespaceLabel: "default"},
+				CreationTimestamp: metav1.NewTime(now.Add(-ago)),
+			},
+			Spec: v1.OriginCertificateRecordSpec{
+				NotBefore: metav1.NewTime(now.Add(-ago)),
+				NotAfter:  metav1.NewTime(now.Add(-ago + 90*24*time.Hour)),
+				IssuerRef: v1.IssuerReference{Name: "foobar", Kind: "OriginIssuer"},
+				Source:    v1.RecordSource{Kind: "OriginCertificate", Namespace: "default", Name: source},
+			},
+			Status: v1.OriginCertificateRecordStatus{RevocationTime: revocation},
+		}
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRuntimeObjects(
+			newRecord("renewed", "web", 60*24*time.Hour, nil),
+			newRecord("renewal", "web", 2*24*time.Hour, nil),
+			newRecord("revoked", "api", 10*24*time.Hour, &revoked),
+			newRecord("active", "db", 5*24*time.Hour, nil),
+		).
+		Build()
+
+	issuances, err := listIssuances(context.Background(), client, "OriginIssuer", "foobar", "default")
+	assert.NilError(t, err)
+
+	current := make(map[string]bool)
+	for _, i := range issuances {
+		current[i.serial] = i.current
+	}
+
+	assert.DeepEqual(t, current, map[string]bool{"renewed": false, "renewal": true, "revoked": false, "active": true})
+	assert.Equal(t, quotaStatus(issuances, now).Issuer.Active, int32(2))
+}
+
+func TestOriginIssuerQuotaStatusUnchanged(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	name := types.NamespacedName{Namespace: "default", Name: "foobar"}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRuntimeObjects(
+			&v1.OriginIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+				Spec: v1.OriginIssuerSpec{
+					RequestType: v1.RequestTypeOriginRSA,
+					SigningMode: v1.SigningModeLocal,
+					Quota:       &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{MaxActive: 10}},
+				},
+			},
+			&v1.OriginCertificateRecord{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "active",
+					Labels:            map[string]string{v1.RecordNamespaceLabel: "default"},
+					CreationTimestamp: metav1.NewTime(clock.Now().Add(-24 * time.Hour)),
+				},
+				Spec: v1.OriginCertificateRecordSpec{
+					NotBefore: metav1.NewTime(clock.Now().Add(-24 * time.Hour)),
+					NotAfter:  metav1.NewTime(clock.Now().Add(90 * 24 * time.Hour)),
+					IssuerRef: v1.IssuerReference{Name: name.Name, Kind: "OriginIssuer"},
+					Source:    v1.RecordSource{Kind: "OriginCertificate", Namespace: "default", Name: "web"},
+				},
+			},
+		).
+		WithStatusSubresource(&v1.OriginIssuer{}).
+		Build()
+
+	controller := &OriginIssuerController{
+		Client: client,
+		Reader: client,
+		Clock:  clock,
+		Log:    logf.Log,
+		SigningSettings: SigningSettings{
+			AllowLocalSigningMode: true,
+			RecordCertificates:    true,
+		},
+	}
+
+	reconcileStatus := func() v1.OriginIssuerStatus {
+		_, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{NamespacedName: name})
+		assert.NilError(t, err)
+
+		got := &v1.OriginIssuer{}
+		assert.NilError(t, client.Get(context.Background(), name, got))
+
+		return got.Status
+	}
+
+	status := reconcileStatus()
+	assert.Equal(t, status.Quota.Issuer.Active, int32(1))
+
+	clock.Step(quotaStatusInterval)
+	assert.DeepEqual(t, reconcileStatus(), status)
+}
+
+func TestQuotasReserve(t *testing.T) {
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	notAfter := now.Add(7 * 24 * time.Hour)
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	t.Run("per hour", func(t *testing.T) {
+		quotas := &Quotas{}
+		iss := &issuer{
+			kind: "OriginIssuer",
+			name: types.NamespacedName{Namespace: "default", Name: "foobar"},
+			spec: v1.OriginIssuerSpec{Quota: &v1.OriginIssuerQuota{Issuer: &v1.IssuanceLimits{PerHour: 1}}},
+		}
+
+		// The first certificate is not recorded yet, but still counts against the quota.
+		first, exceeded, err := quotas.reserve(context.Background(), client, iss, "default", "OriginCertificate/default/web", now, notAfter)
+		assert.NilError(t, err)
+		assert.Assert(t, exceeded == nil)
+
+		_, exceeded, err = quotas.reserve(context.Background(), client, iss, "default", "OriginCertificate/default/api", now, notAfter)
+		assert.NilError(t, err)
+		assert.Assert(t, exceeded != nil)
+		assert.Equal(t, exceeded.message, "the issuer has been issued 1 certificates in the last hour, reaching its limit of 1")
+
+		// A certificate that failed to be signed releases its reservation.
+		first.release()
+
+		_, exceeded, err = quotas.reserve(context.Background(), client, iss, "default", "OriginCertificate/default/api", now, notAfter)
+		assert.NilError(t, err)
+		assert.Assert(t, exceeded == nil)
+	})
+
+	t.Run("max active", func(t *testing.T) {
+		quotas := &Quotas{}
+		iss := &issuer{
+			kind: "OriginIssuer",
+			name: types.NamespacedName{Namespace: "default", Name: "foobar"},
+			spec: v1.OriginIssuerSpec{Quota: &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{MaxActive: 1}}},
+		}
+
+		_, exceeded, err := quotas.reserve(context.Background(), client, iss, "default", "OriginCertificate/default/web", now, notAfter)
+		assert.NilError(t, err)
+		assert.Assert(t, exceeded == nil)
+
+		// Renewing the certificate supersedes it, so the limit is not reached.
+		_, exceeded, err = quotas.reserve(context.Background(), client, iss, "default", "OriginCertificate/default/web", now, notAfter)
+		assert.NilError(t, err)
+		assert.Assert(t, exceeded == nil)
+
+		_, exceeded, err = quotas.reserve(context.Background(), client, iss, "default", "OriginCertificate/default/api", now, notAfter)
+		assert.NilError(t, err)
+		assert.Assert(t, exceeded != nil)
+		assert.Equal(t, exceeded.message, "namespace default has 1 active certificates, reaching its limit of 1")
+
+		// Other namespaces have their own limit.
+		_, exceeded, err = quotas.reserve(context.Background(), client, iss, "other", "OriginCertificate/other/api", now, notAfter)
+		assert.NilError(t, err)
+		assert.Assert(t, exceeded == nil)
+	})
+}
+
+func TestCertificateRequestQuota(t *testing.T) {
+	if err := cmapi.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeClock.NewFakeClock(time.Now().Truncate(time.Second))
+
+	newRecord := func(name, namespace string, ago time.Duration) *v1.OriginCertificateRecord {
+		return &v1.OriginCertificateRecord{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Labels:            map[string]string{v1.RecordNamespaceLabel: namespace},
+				CreationTimestamp: metav1.NewTime(clock.Now().Add(-ago)),
+			},
+			Spec: v1.OriginCertificateRecordSpec{
+				NotAfter:  metav1.NewTime(clock.Now().Add(7 * 24 * time.Hour)),
+				IssuerRef: v1.IssuerReference{Name: "foobar", Kind: "OriginIssuer"},
+				Source:    v1.RecordSource{Namespace: namespace},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		records    bool
+		issued     []time.Duration
+		ready      bool
+		message    string
+		requeue    time.Duration
+		retryError string
+	}{
+		{
+			name:    "within quota",
+			records: true,
+			issued:  []time.Duration{20 * time.Minute},
+			ready:   true,
+		},
+		{
+			name:    "over quota",
+			records: true,
+			issued:  []time.Duration{20 * time.Minute, 30 * time.Minute, 2 * time.Hour},
+			message: "Issuer quota exceeded: namespace default has been issued 2 certificates in the last hour, reaching its limit of 2",
+			requeue: 30 * time.Minute,
+		},
+		{
+			name:       "records disabled",
+			message:    quotaUnavailableMessage,
+			retryError: "issuer quota cannot be enforced without certificate records",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []runtime.Object{
+				&v1.OriginIssuer{
+					ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"},
+					Spec: v1.OriginIssuerSpec{
+						RequestType: v1.RequestTypeOriginECC,
+						SigningMode: v1.SigningModeLocal,
+						Quota:       &v1.OriginIssuerQuota{Namespace: &v1.IssuanceLimits{PerHour: 2}},
+					},
+					Status: v1.OriginIssuerStatus{
+						Conditions: []v1.OriginIssuerCondition{{Type: v1.ConditionReady, Status: v1.ConditionTrue}},
+					},
+				},
+				cmgen.CertificateRequest("foobar",
+					cmgen.SetCertificateRequestNamespace("default"),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 7 * 24 * time.Hour}),
+					cmgen.SetCertificateRequestCSR(csrMust(t, &x509.CertificateRequest{DNSNames: []string{"example.com"}})),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "foobar",
+						Kind:  "OriginIssuer",
+						Group: "cert-manager.k8s.cloudflare.com",
+					}),
+				),
+				newRecord("other", "other", 10*time.Minute),
+			}
+
+			for i, ago := range tt.issued {
+				objects = append(objects, newRecord(fmt.Sprintf("default-%d", i), "default", ago))
+			}
+
+			client := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(objects...).
+				WithStatusSubresource(&cmapi.CertificateRequest{}).
+				Build()
+
+			controller := &CertificateRequestController{
+				Client:   client,
+				Reader:   client,
+				Log:      logf.Log,
+				Clock:    clock,
+				Recorder: record.NewFakeRecorder(10),
+				SigningSettings: SigningSettings{
+					LocalSigner: provisioners.NewLocalSigner(&LocalCA{
+						Client: client,
+						Reader: client,
+						Clock:  clock,
+						Secret: types.NamespacedName{Namespace: "origin-ca-issuer", Name: "local-ca"},
+					}, clock),
+					RecordCertificates:    tt.records,
+					AllowLocalSigningMode: true,
+				},
+			}
+
+			res, err := reconcile.AsReconciler(client, controller).Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "foobar"},
+			})
+			if tt.retryError != "" {
+				assert.Error(t, err, tt.retryError)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, res.RequeueAfter, tt.requeue)
+
+			cr := &cmapi.CertificateRequest{}
+			assert.NilError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "foobar"}, cr))
+
+			cond := cmutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady)
+			assert.Assert(t, cond != nil)
+
+			if tt.ready {
+				assert.Equal(t, cond.Status, cmmeta.ConditionTrue)
+				return
+			}
+
+			assert.Equal(t, cond.Status, cmmeta.ConditionFalse)
+			assert.Equal(t, cond.Reason, cmapi.CertificateRequestReasonPending)
+			assert.Equal(t, cond.Message, tt.message)
+			assert.Equal(t, len(cr.Status.Certificate), 0)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=origincertificaterecords,verbs=create
+// +kubebuilder:rbac:groups=cert-manager.k8s.cloudflare.com,resources=origincertificaterecords/status,verbs=update
+
+// newCertificateRecord returns the OriginCertificateRecord of the certificate issued by iss for source.
+func newCertificateRecord(iss *issuer, resp *cfapi.SignResponse, source v1.RecordSource) (*v1.OriginCertificateRecord, error) {
+	cert, err := pki.DecodeX509CertificateBytes([]byte(resp.Certificate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode issued certificate: %w", err)
+	}
+
+	record := &v1.OriginCertificateRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: serialNumber(cert),
+		},
+		Spec: v1.OriginCertificateRecordSpec{
+			CertificateID: resp.Id,
+			SerialNumber:  serialNumber(cert),
+			Hostnames:     cert.DNSNames,
+			RequestType:   iss.spec.RequestType,
+			NotBefore:     metav1.NewTime(cert.NotBefore),
+			NotAfter:      metav1.NewTime(cert.NotAfter),
+			IssuerRef:     v1.IssuerReference{Name: iss.name.Name, Kind: iss.kind},
+			Source:        source,
+		},
+	}
+
+	if namespace := cmp.Or(source.Namespace, iss.name.Namespace); namespace != "" {
+		record.Labels = map[string]string{v1.RecordNamespaceLabel: namespace}
+	}
+
+	return record, nil
+}
+
+// createCertificateRecord creates the OriginCertificateRecord of the certificate issued by iss for
+// source. A record that already exists for the certificate is left unchanged.
+func createCertificateRecord(ctx context.Context, c client.Client, iss *issuer, resp *cfapi.SignResponse, source v1.RecordSource) error {
+	record, err := newCertificateRecord(iss, resp, source)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Create(ctx, record); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create OriginCertificateRecord %s: %w", record.Name, err)
+	}
+
+	return nil
+}
+
+// revokeCertificateRecord records that the certificate of record was revoked at now.
+func revokeCertificateRecord(ctx context.Context, c client.Client, record *v1.OriginCertificateRecord, now time.Time) error {
+	revoked := metav1.NewTime(now)
+	record.Status.RevocationTime = &revoked
+
+	if err := c.Status().Update(ctx, record); err != nil {
+		return fmt.Errorf("failed to record revocation in OriginCertificateRecord %s: %w", record.Name, err)
+	}
+
+	return nil
+}
+
+// setCertificateRecordOrphaned records in record that its certificate was found orphaned at now, unless
+// it already was, or clears the time it was found orphaned if it no longer is.
+func setCertificateRecordOrphaned(ctx context.Context, c client.Client, record *v1.OriginCertificateRecord, orphaned bool, now time.Time) error {
+	switch {
+	case orphaned && record.Status.OrphanedTime == nil:
+		orphanedTime := metav1.NewTime(now)
+		record.Status.OrphanedTime = &orphanedTime
+	case !orphaned && record.Status.OrphanedTime != nil:
+		record.Status.OrphanedTime = nil
+	default:
+		return nil
+	}
+
+	if err := c.Status().Update(ctx, record); err != nil {
+		return fmt.Errorf("failed to record orphaned certificate in OriginCertificateRecord %s: %w", record.Name, err)
+	}
+
+	return nil
+}
+
+// recordedCertificate identifies the resource a recorded certificate is used by: the cert-manager Certificate
+// a CertificateRequest was created for, or otherwise the resource the certificate was issued for.
+func recordedCertificate(source v1.RecordSource) string {
+	if source.Kind == "CertificateRequest" && source.Certificate != "" {
+		return "Certificate/" + source.Namespace + "/" + source.Certificate
+	}
+
+	return source.Kind + "/" + source.Namespace + "/" + source.Name
+}
+
+// latestRecords returns the latest of records for each resource identified by recordedCertificate. Earlier
+// certificates were superseded when the resource was renewed.
+func latestRecords(records []v1.OriginCertificateRecord) map[string]v1.OriginCertificateRecord {
+	latest := make(map[string]v1.OriginCertificateRecord)
+	for _, r := range records {
+		key := recordedCertificate(r.Spec.Source)
+		if cur, ok := latest[key]; !ok || r.Spec.NotBefore.After(cur.Spec.NotBefore.Time) ||
+			(r.Spec.NotBefore.Equal(&cur.Spec.NotBefore) && r.CreationTimestamp.After(cur.CreationTimestamp.Time)) {
+			latest[key] = r
+		}
+	}
+
+	return latest
+}
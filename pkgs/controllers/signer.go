@@ -0,0 +1,409 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"github.com/cloudflare/origin-ca-issuer/internal/credfile"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/cloudflare/origin-ca-issuer/pkgs/provisioners"
+	"github.com/go-logr/logr"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// issuer is an OriginIssuer or ClusterOriginIssuer that is ready to sign certificates.
+type issuer struct {
+	kind      string
+	name      types.NamespacedName
+	meta      metav1.ObjectMeta
+	spec      v1.OriginIssuerSpec
+	status    v1.OriginIssuerStatus
+	namespace issuerNamespace
+}
+
+// key identifies the issuer among issuers of all kinds.
+func (iss *issuer) key() string {
+	if iss.name.Namespace == "" {
+		return iss.kind + "/" + iss.name.Name
+	}
+
+	return iss.kind + "/" + iss.name.String()
+}
+
+// SigningSettings holds the settings shared by every controller signing certificates or checking
+// issuers, so they sign certificates and call the Cloudflare API alike.
+type SigningSettings struct {
+	// Credentials reads credential files referenced by issuers.
+	Credentials *credfile.Store
+
+	// LocalSigner signs the certificates of issuers in the Local signing mode.
+	LocalSigner provisioners.Signer
+
+	// LocalSigning signs the certificates of every issuer with LocalSigner,
+	// regardless of their signing mode.
+	LocalSigning bool
+
+	// AllowLocalSigningMode honours the Local signing mode of issuers. Otherwise,
+	// issuers in the Local signing mode are not ready.
+	AllowLocalSigningMode bool
+
+	// RecordCertificates enables creating an OriginCertificateRecord for each certificate
+	// issued, as an audit trail. The quotas of issuers are counted from the records, and
+	// orphaned certificates are only revoked by inventories if they are recorded.
+	RecordCertificates bool
+
+	// Breakers holds the circuit breakers of the Cloudflare API, shared by every
+	// controller calling the API. A nil value never opens. Issuers are not ready
+	// while the breakers of all their credentials are open.
+	Breakers *provisioners.Breakers
+
+	// Quotas reserves the quota of issuers for the certificates being signed, and is shared
+	// by every controller signing certificates. If nil, reservations are only shared between
+	// the reconciles of a controller.
+	Quotas *Quotas
+
+	// APIPolicy restricts the settings of the Cloudflare API issuers may use. Issuers with
+	// settings it does not allow are not ready.
+	APIPolicy APIPolicy
+}
+
+// issuerSigner signs certificates with the credentials of OriginIssuers and ClusterOriginIssuers,
+// reusing a Cloudflare API client for each credential while it remains unchanged.
+type issuerSigner struct {
+	client      client.Client
+	reader      client.Reader
+	builder     *cfapi.Builder
+	credentials *credfile.Store
+	clock       clock.Clock
+	recorder    record.EventRecorder
+	clients     *clientCache
+	breakers    *provisioners.Breakers
+	quotas      *Quotas
+	apiPolicy   APIPolicy
+
+	// local signs the certificates of issuers in the Local signing mode, if allowLocalSigningMode
+	// is set, or of every issuer if localSigning is set.
+	local                 provisioners.Signer
+	localSigning          bool
+	allowLocalSigningMode bool
+
+	clusterResourceNamespace string
+	clusterSecretNamespaces  []string
+}
+
+// getIssuer retrieves the issuer of the given kind and name, ensuring it is ready to sign certificates.
+// OriginIssuers are retrieved from namespace. Errors are returned as a *statusError.
+func (s *issuerSigner) getIssuer(ctx context.Context, log logr.Logger, kind, name, namespace string) (*issuer, error) {
+	iss, err := s.lookupIssuer(ctx, log, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Issuers in a Local signing mode, or with API settings, the controller does not allow are not ready,
+	// even if their status was last updated by a controller that allowed them.
+	ready := IssuerStatusHasCondition(iss.status, v1.OriginIssuerCondition{Type: v1.ConditionReady, Status: v1.ConditionTrue})
+	if !ready || localSigningModeError(iss.spec, s.localSigning, s.allowLocalSigningMode) != nil || s.apiPolicy.check(iss.kind, iss.spec.API) != nil {
+		err := fmt.Errorf("resource %s is not ready", iss.name)
+
+		// Requests wait for the circuit breakers of the issuer to half-open, rather than backing off.
+		if retryAfter, unavailable := apiUnavailable(s.breakers, iss.spec, iss.namespace); unavailable {
+			log.V(4).Info("Cloudflare API is unavailable with every credential of the issuer", "retryAfter", retryAfter)
+			err := &provisioners.CircuitOpenError{RetryAfter: retryAfter, Err: err}
+			return nil, &statusError{reason: "Pending", message: fmt.Sprintf("OriginIssuer %s is not Ready: %s", iss.name, apiUnavailableMessage), err: err}
+		}
+
+		log.Error(err, "issuer failed readiness checks", "namespace", iss.name.Namespace, "name", iss.name.Name)
+		return nil, &statusError{reason: "Pending", message: fmt.Sprintf("OriginIssuer %s is not Ready", iss.name), err: err}
+	}
+
+	return iss, nil
+}
+
+// lookupIssuer retrieves the issuer of the given kind and name, whether or not it is ready. Errors are
+// returned as a *statusError.
+func (s *issuerSigner) lookupIssuer(ctx context.Context, log logr.Logger, kind, name, namespace string) (*issuer, error) {
+	iss := &issuer{kind: kind, name: types.NamespacedName{Name: name}}
+
+	var err error
+	switch kind {
+	case "OriginIssuer":
+		oi := v1.OriginIssuer{}
+		iss.name.Namespace = namespace
+		err = s.client.Get(ctx, iss.name, &oi)
+		iss.meta, iss.spec, iss.status = oi.ObjectMeta, oi.Spec, oi.Status
+		iss.namespace = originIssuerNamespace(namespace)
+	case "ClusterOriginIssuer":
+		coi := v1.ClusterOriginIssuer{}
+		err = s.client.Get(ctx, iss.name, &coi)
+		iss.meta, iss.spec, iss.status = coi.ObjectMeta, coi.Spec, coi.Status
+		iss.namespace = issuerNamespace{name: s.clusterResourceNamespace, allowed: s.clusterSecretNamespaces}
+	default:
+		err := fmt.Errorf("unknown issuer kind: %s", kind)
+		return nil, &statusError{reason: "Error", message: fmt.Sprintf("Unknown issuer kind: %s", kind), err: err}
+	}
+
+	if err != nil {
+		log.Error(err, "failed to retrieve OriginIssuer resource", "namespace", iss.name.Namespace, "name", iss.name.Name)
+		return nil, &statusError{reason: "Pending", message: fmt.Sprintf("Failed to retrieve OriginIssuer resource %s: %v", iss.name, err), err: err}
+	}
+
+	return iss, nil
+}
+
+// issue signs the PEM-encoded csr for duration with the first of the issuer's credentials the Cloudflare
+// API accepts, as described by withClient, returning the complete response of the API. Issuers in the
+// Local signing mode sign with the local signer instead.
+func (s *issuerSigner) issue(ctx context.Context, log logr.Logger, obj client.Object, iss *issuer, csr []byte, duration time.Duration) (*cfapi.SignResponse, error) {
+	if err := localSigningModeError(iss.spec, s.localSigning, s.allowLocalSigningMode); err != nil {
+		return nil, err
+	}
+
+	if signsLocally(iss.spec, s.localSigning, s.allowLocalSigningMode) {
+		if s.local == nil {
+			err := fmt.Errorf("issuer %s signs certificates locally, which is not configured on the controller", iss.name.Name)
+			return nil, &statusError{reason: "Error", message: "Local signing is not configured on the controller", err: err}
+		}
+
+		p, err := provisioners.New(s.local, iss.spec.RequestType, log)
+		if err != nil {
+			return nil, &statusError{reason: "Error", message: "Failed initialize provisioner", err: err}
+		}
+
+		return p.Issue(ctx, csr, duration)
+	}
+
+	var resp *cfapi.SignResponse
+	err := s.withClient(ctx, log, obj, iss, func(c *cfapi.Client) error {
+		p, err := provisioners.New(c, iss.spec.RequestType, log)
+		if err != nil {
+			return &statusError{reason: "Error", message: "Failed initialize provisioner", err: err}
+		}
+
+		resp, err = p.Issue(ctx, csr, duration)
+		return err
+	})
+
+	return resp, err
+}
+
+// withClient calls fn with a Cloudflare API client for the first of the issuer's credentials the API
+// accepts, falling back to the next credential on authentication errors. Issuers in the Local signing
+// mode do not use the API, so an error is returned for them. Rejected credentials are
+// recorded in the issuer's status, and skipped until their Secret or file changes; events about them are
+// recorded on obj. Calls to fn go through the circuit breaker of the credential, and credentials with an
+// open breaker are skipped too. Once the breaker of the last credential is open, the issuer is marked as
+// not ready. Errors creating a client are returned as a *statusError, while errors returned by fn or the
+// breaker are returned as is.
+func (s *issuerSigner) withClient(ctx context.Context, log logr.Logger, obj client.Object, iss *issuer, fn func(c *cfapi.Client) error) error {
+	if err := localSigningModeError(iss.spec, s.localSigning, s.allowLocalSigningMode); err != nil {
+		return err
+	}
+
+	if signsLocally(iss.spec, s.localSigning, s.allowLocalSigningMode) {
+		err := fmt.Errorf("issuer %s signs certificates locally, without the Cloudflare API", iss.name.Name)
+		return &statusError{reason: "LocalSigning", message: "Issuer signs certificates with the local CA, without the Cloudflare API", err: err}
+	}
+
+	creds := IssuerCredentials(iss.spec.Auth)
+	if len(creds) == 0 {
+		err := fmt.Errorf("issuer %s does not have an authentication method configured", iss.name.Name)
+		return &statusError{reason: "MissingAuthentication", message: "No authentication methods were configured", err: err}
+	}
+
+	for i, cred := range creds {
+		last := i == len(creds)-1
+		log := log.WithValues("credential", credentialName(cred))
+
+		c, version, err := s.apiClient(ctx, iss, cred, fmt.Sprintf("%s/%d", iss.meta.UID, i))
+		if err != nil {
+			log.Error(err, "failed to create Cloudflare API client")
+			if !last {
+				continue
+			}
+
+			return err
+		}
+
+		if !last && credentialRejected(iss.status, credentialName(cred), version) {
+			log.V(4).Info("credential was rejected by the Cloudflare API, trying next credential")
+			continue
+		}
+
+		key := breakerKey(iss.spec, iss.namespace, cred)
+		err = s.breakers.Do(key, func() error { return fn(c) })
+
+		var openErr *provisioners.CircuitOpenError
+		if errors.As(err, &openErr) {
+			if !last {
+				log.Error(err, "Cloudflare API is unavailable with credential, trying next credential")
+				continue
+			}
+
+			if _, open := s.breakers.Open(key); open {
+				s.recorder.Eventf(obj, core.EventTypeWarning, apiUnavailableReason, "Cloudflare API is unavailable: %v", openErr.Err)
+				s.markUnavailable(ctx, log, iss)
+			}
+
+			return err
+		}
+
+		if cfapi.IsAuthenticationError(err) {
+			s.rejectCredential(ctx, log, iss, credentialName(cred), version, err)
+
+			if !last {
+				log.Error(err, "credential was rejected by the Cloudflare API, trying next credential")
+				s.recorder.Eventf(obj, core.EventTypeWarning, "CredentialRejected", "Credential %s was rejected by the Cloudflare API, trying next credential", credentialName(cred))
+
+				continue
+			}
+		}
+
+		return err
+	}
+
+	// Unreachable, the last credential always returns.
+	return fmt.Errorf("issuer %s has no usable credentials", iss.name.Name)
+}
+
+// signsLocally reports whether an issuer with spec signs certificates with the local CA, either
+// because localSigning is enabled on the controller, or because of its signing mode if
+// allowLocalSigningMode is enabled on the controller.
+func signsLocally(spec v1.OriginIssuerSpec, localSigning, allowLocalSigningMode bool) bool {
+	return localSigning || (allowLocalSigningMode && spec.SigningMode == v1.SigningModeLocal)
+}
+
+// localSigningModeError returns a *statusError if an issuer with spec is in the Local signing mode
+// without the controller allowing it. Such issuers neither sign locally nor with the Cloudflare API,
+// as the signing mode of an issuer alone must not swap the Origin CA for an untrusted one.
+func localSigningModeError(spec v1.OriginIssuerSpec, localSigning, allowLocalSigningMode bool) error {
+	if spec.SigningMode != v1.SigningModeLocal || signsLocally(spec, localSigning, allowLocalSigningMode) {
+		return nil
+	}
+
+	return &statusError{
+		reason:  "LocalSigningModeNotAllowed",
+		message: "Issuer is in the Local signing mode, which is only honoured when the controller is started with --allow-local-signing-mode",
+		err:     errors.New("local signing mode is not allowed by the controller"),
+	}
+}
+
+// apiClient returns a Cloudflare API client authenticating with cred, and the version of the Secret or
+// file cred was read from. Clients are cached under key, and rebuilt when the issuer or the objects it
+// references change.
+func (s *issuerSigner) apiClient(ctx context.Context, iss *issuer, cred v1.OriginIssuerCredential, key string) (*cfapi.Client, string, error) {
+	credVersion, err := credentialVersion(ctx, s.client, s.credentials, cred, iss.namespace)
+	if err != nil {
+		return nil, "", err
+	}
+
+	version := fmt.Sprintf("%d/%s", iss.meta.Generation, credVersion)
+	if iss.spec.API != nil && iss.spec.API.CABundle != nil {
+		bundle, err := caBundleMetadata(ctx, s.client, iss.spec.API.CABundle, iss.namespace)
+		if err != nil {
+			return nil, "", caBundleError(err)
+		}
+
+		version += "/" + bundle.ResourceVersion
+	}
+
+	c, ok := s.clients.get(key, version)
+	if !ok {
+		c, err = s.buildClient(ctx, iss, cred)
+		if err != nil {
+			return nil, "", err
+		}
+
+		s.clients.put(key, version, c)
+	}
+
+	return c, credVersion, nil
+}
+
+// buildClient builds a Cloudflare API client authenticating with cred, using the API settings of the
+// issuer.
+func (s *issuerSigner) buildClient(ctx context.Context, iss *issuer, cred v1.OriginIssuerCredential) (*cfapi.Client, error) {
+	value, _, err := readCredential(ctx, s.reader, s.credentials, cred, iss.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	b := s.builder.Clone()
+	if cred.ServiceKeyRef != nil || cred.ServiceKeyFile != "" {
+		b.WithServiceKey(value)
+	} else {
+		b.WithToken(value)
+	}
+
+	if err := configureAPI(ctx, s.reader, b, iss.spec.API, iss.namespace); err != nil {
+		return nil, caBundleError(err)
+	}
+
+	c, err := b.Build()
+	if err != nil {
+		return nil, &statusError{reason: "Error", message: fmt.Sprintf("Failed to create Cloudflare API client: %v", err), err: err}
+	}
+
+	return c, nil
+}
+
+// markUnavailable marks the issuer as not ready while the Cloudflare API is unavailable. The issuer
+// controllers mark it as ready again once a circuit breaker of the issuer half-opens.
+func (s *issuerSigner) markUnavailable(ctx context.Context, log logr.Logger, iss *issuer) {
+	obj, status := s.issuerObject(iss)
+	if obj == nil {
+		return
+	}
+
+	if err := s.client.Get(ctx, iss.name, obj); err != nil {
+		log.Error(err, "failed to retrieve issuer to mark the Cloudflare API as unavailable")
+		return
+	}
+
+	SetIssuerStatusCondition(status, v1.ConditionReady, v1.ConditionFalse, log, s.clock, apiUnavailableReason, apiUnavailableMessage)
+
+	if err := s.client.Status().Update(ctx, obj); err != nil {
+		log.Error(err, "failed to mark the Cloudflare API as unavailable in issuer status")
+	}
+}
+
+// issuerObject returns an empty object of the kind of the issuer, and its status.
+func (s *issuerSigner) issuerObject(iss *issuer) (client.Object, *v1.OriginIssuerStatus) {
+	switch iss.kind {
+	case "OriginIssuer":
+		oi := &v1.OriginIssuer{}
+		return oi, &oi.Status
+	case "ClusterOriginIssuer":
+		coi := &v1.ClusterOriginIssuer{}
+		return coi, &coi.Status
+	default:
+		return nil, nil
+	}
+}
+
+// rejectCredential records that the Cloudflare API rejected the named credential of the issuer, so it
+// is skipped until its Secret or file changes.
+func (s *issuerSigner) rejectCredential(ctx context.Context, log logr.Logger, iss *issuer, name, version string, err error) {
+	obj, status := s.issuerObject(iss)
+	if obj == nil {
+		return
+	}
+
+	if err := s.client.Get(ctx, iss.name, obj); err != nil {
+		log.Error(err, "failed to retrieve issuer to record rejected credential")
+		return
+	}
+
+	rejectCredential(status, s.clock, name, version, err)
+
+	if err := s.client.Status().Update(ctx, obj); err != nil {
+		log.Error(err, "failed to record rejected credential in issuer status")
+	}
+}
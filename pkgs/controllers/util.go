@@ -34,6 +34,30 @@ func IssuerStatusHasCondition(status v1.OriginIssuerStatus, c v1.OriginIssuerCon
 // condition will be updated and the LastTransitionTime set to the current
 // time.
 func SetIssuerStatusCondition(ois *v1.OriginIssuerStatus, conditionType v1.ConditionType, status v1.ConditionStatus, log logr.Logger, cl clock.Clock, reason, message string) {
+	setStatusCondition(&ois.Conditions, "OriginIssuer", conditionType, status, log, cl, reason, message)
+}
+
+// SetCertificateStatusCondition will set a condition on the given OriginCertificateStatus,
+// updating the LastTransitionTime in the same way as SetIssuerStatusCondition.
+func SetCertificateStatusCondition(ocs *v1.OriginCertificateStatus, conditionType v1.ConditionType, status v1.ConditionStatus, log logr.Logger, cl clock.Clock, reason, message string) {
+	setStatusCondition(&ocs.Conditions, "OriginCertificate", conditionType, status, log, cl, reason, message)
+}
+
+// SetInventoryStatusCondition will set a condition on the given OriginInventoryStatus,
+// updating the LastTransitionTime in the same way as SetIssuerStatusCondition.
+func SetInventoryStatusCondition(ois *v1.OriginInventoryStatus, conditionType v1.ConditionType, status v1.ConditionStatus, log logr.Logger, cl clock.Clock, reason, message string) {
+	setStatusCondition(&ois.Conditions, "OriginInventory", conditionType, status, log, cl, reason, message)
+}
+
+// statusCondition is the condition of the status of any of the resources, which
+// all share the fields of OriginIssuerCondition.
+type statusCondition interface {
+	v1.OriginIssuerCondition | v1.OriginCertificateCondition | v1.OriginInventoryCondition
+}
+
+// setStatusCondition sets a condition on the conditions of a resource of kind, as
+// described by SetIssuerStatusCondition.
+func setStatusCondition[C statusCondition](conditions *[]C, kind string, conditionType v1.ConditionType, status v1.ConditionStatus, log logr.Logger, cl clock.Clock, reason, message string) {
 	now := metav1.NewTime(cl.Now())
 	c := v1.OriginIssuerCondition{
 		Type:               conditionType,
@@ -43,7 +67,8 @@ func SetIssuerStatusCondition(ois *v1.OriginIssuerStatus, conditionType v1.Condi
 		LastTransitionTime: &now,
 	}
 
-	for i, condition := range ois.Conditions {
+	for i, existing := range *conditions {
+		condition := v1.OriginIssuerCondition(existing)
 		if condition.Type != conditionType {
 			continue
 		}
@@ -51,17 +76,17 @@ func SetIssuerStatusCondition(ois *v1.OriginIssuerStatus, conditionType v1.Condi
 		if condition.Status == status {
 			c.LastTransitionTime = condition.LastTransitionTime
 		} else {
-			log.Info("found status change for OriginIssuer; setting lastTransitionTime",
+			log.Info("found status change for "+kind+"; setting lastTransitionTime",
 				"condition", condition.Type,
 				"old_status", condition.Status,
 				"new_status", c.Status,
 			)
 		}
 
-		ois.Conditions[i] = c
+		(*conditions)[i] = C(c)
 
 		return
 	}
 
-	ois.Conditions = append(ois.Conditions, c)
+	*conditions = append(*conditions, C(c))
 }
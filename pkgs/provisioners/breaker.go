@@ -0,0 +1,241 @@
+package provisioners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"k8s.io/utils/clock"
+)
+
+// CircuitOpenError is returned instead of calling the Cloudflare API while the circuit
+// breaker of a credential is open.
+type CircuitOpenError struct {
+	// RetryAfter is how long until the breaker allows a probe of the API.
+	RetryAfter time.Duration
+
+	// Err is the failure that opened the breaker.
+	Err error
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("Cloudflare API is unavailable: %v", e.Err)
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return e.Err
+}
+
+// Breakers holds a circuit breaker for each credential and endpoint of the Cloudflare API.
+// A breaker opens after threshold consecutive calls fail because the API is unavailable,
+// rejecting calls with a *CircuitOpenError for the cooldown. Once the cooldown elapses it
+// half-opens, allowing a single call through to probe the API: the breaker closes if the
+// API responds, and opens for another cooldown otherwise.
+//
+// A nil *Breakers, or one with a threshold of zero, never opens.
+type Breakers struct {
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+type breaker struct {
+	failures int
+	lastErr  error
+
+	// lastCall is when the outcome of the last call through the breaker was recorded.
+	lastCall time.Time
+
+	// openedAt is when the breaker last opened, or zero while it is closed.
+	openedAt time.Time
+
+	// probing is set while the call probing a half-open breaker is in flight.
+	probing bool
+}
+
+// NewBreakers returns breakers opening after threshold consecutive failures, for cooldown.
+func NewBreakers(threshold int, cooldown time.Duration, cl clock.Clock) *Breakers {
+	return &Breakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     cl,
+		breakers:  make(map[string]*breaker),
+	}
+}
+
+// Do calls fn through the breaker identified by key, unless it is open. Errors returned by fn
+// are returned as is, except for the failure opening the breaker, which is returned as a
+// *CircuitOpenError. Only errors for which cfapi.IsUnavailableError reports true are failures;
+// any other outcome means the API responded, and closes the breaker.
+func (b *Breakers) Do(key string, fn func() error) error {
+	if b == nil || b.threshold <= 0 {
+		return fn()
+	}
+
+	if err := b.allow(key); err != nil {
+		return err
+	}
+
+	return b.done(key, fn())
+}
+
+// Open reports whether the breaker identified by key is open, and how long until it half-opens.
+func (b *Breakers) Open(key string) (time.Duration, bool) {
+	if b == nil || b.threshold <= 0 {
+		return 0, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.breakers[key]
+	if !ok || br.openedAt.IsZero() {
+		return 0, false
+	}
+
+	remaining := br.openedAt.Add(b.cooldown).Sub(b.clock.Now())
+	if remaining <= 0 {
+		return 0, false
+	}
+
+	return remaining, true
+}
+
+// Unavailable returns a *CircuitOpenError if the breakers identified by keys for which include
+// reports true were all opened by recent calls, so the Cloudflare API is unavailable through each
+// of them. Any of them that recently saw a call succeed makes the API available. Breakers that saw
+// no call for a cooldown are forgotten, so neither an issuer that is no longer used nor one that
+// recovered long ago hides an outage or reports one.
+func (b *Breakers) Unavailable(include func(key string) bool) error {
+	if b == nil || b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	b.prune(now)
+
+	var openErr *CircuitOpenError
+	for key, br := range b.breakers {
+		if !include(key) {
+			continue
+		}
+
+		if br.openedAt.IsZero() {
+			if br.failures == 0 {
+				return nil
+			}
+
+			continue
+		}
+
+		// A breaker whose cooldown elapsed says nothing until a probe of the API reopens or closes it.
+		remaining := br.openedAt.Add(b.cooldown).Sub(now)
+		if remaining <= 0 {
+			continue
+		}
+
+		if openErr == nil || remaining < openErr.RetryAfter {
+			openErr = &CircuitOpenError{RetryAfter: remaining, Err: br.lastErr}
+		}
+	}
+
+	if openErr == nil {
+		return nil
+	}
+
+	return openErr
+}
+
+// prune forgets the breakers that saw no call for a cooldown, once open breakers among them half-opened.
+// A forgotten breaker is closed.
+func (b *Breakers) prune(now time.Time) {
+	for key, br := range b.breakers {
+		if br.probing {
+			continue
+		}
+
+		idle := br.lastCall
+		if !br.openedAt.IsZero() {
+			idle = br.openedAt.Add(b.cooldown)
+		}
+
+		if now.Sub(idle) >= b.cooldown {
+			delete(b.breakers, key)
+		}
+	}
+}
+
+// allow returns a *CircuitOpenError if the breaker identified by key is open, or half-open with
+// a probe already in flight. Otherwise, a call may proceed, probing the API if the breaker is
+// half-open.
+func (b *Breakers) allow(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.breakers[key]
+	if !ok || br.openedAt.IsZero() {
+		return nil
+	}
+
+	if remaining := br.openedAt.Add(b.cooldown).Sub(b.clock.Now()); remaining > 0 {
+		return &CircuitOpenError{RetryAfter: remaining, Err: br.lastErr}
+	}
+
+	if br.probing {
+		return &CircuitOpenError{RetryAfter: b.cooldown, Err: br.lastErr}
+	}
+
+	br.probing = true
+
+	return nil
+}
+
+// done records the outcome of a call allowed through the breaker identified by key.
+func (b *Breakers) done(key string, err error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Forget idle breakers first, so a failure after a long pause is counted afresh.
+	now := b.clock.Now()
+	b.prune(now)
+
+	br, ok := b.breakers[key]
+	if !ok {
+		br = &breaker{}
+	}
+
+	probe := br.probing
+	br.probing = false
+
+	// A canceled call says nothing about the API, a later call probes it instead.
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	// The API responded, which closes the breaker.
+	if !cfapi.IsUnavailableError(err) {
+		b.breakers[key] = &breaker{lastCall: now}
+		return err
+	}
+
+	b.breakers[key] = br
+	br.failures++
+	br.lastErr = err
+	br.lastCall = now
+
+	if probe || br.failures >= b.threshold {
+		br.openedAt = now
+		return &CircuitOpenError{RetryAfter: b.cooldown, Err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,148 @@
+package provisioners
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"gotest.tools/v3/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestBreakers(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	breakers := NewBreakers(3, time.Minute, clock)
+
+	unavailable := &url.Error{Op: "Post", URL: "https://api.cloudflare.com", Err: errors.New("connection refused")}
+
+	calls := 0
+	fail := func() error { calls++; return unavailable }
+	succeed := func() error { calls++; return nil }
+
+	// Failures below the threshold are returned as is.
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, breakers.Do("key", fail), error(unavailable))
+	}
+
+	// A response from the API resets the count of consecutive failures.
+	rejected := &cfapi.APIError{Code: 1010, Message: "Invalid CSR"}
+	assert.Equal(t, breakers.Do("key", func() error { calls++; return rejected }), error(rejected))
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, breakers.Do("key", fail), error(unavailable))
+	}
+
+	_, open := breakers.Open("key")
+	assert.Assert(t, !open)
+
+	// The failure reaching the threshold opens the breaker.
+	var openErr *CircuitOpenError
+	assert.Assert(t, errors.As(breakers.Do("key", fail), &openErr))
+	assert.Equal(t, openErr.RetryAfter, time.Minute)
+	assert.Equal(t, openErr.Err, error(unavailable))
+
+	retryAfter, open := breakers.Open("key")
+	assert.Assert(t, open)
+	assert.Equal(t, retryAfter, time.Minute)
+
+	// Calls are rejected while the breaker is open, and other keys are unaffected.
+	calls = 0
+	clock.Step(20 * time.Second)
+	assert.Assert(t, errors.As(breakers.Do("key", succeed), &openErr))
+	assert.Equal(t, openErr.RetryAfter, 40*time.Second)
+	assert.NilError(t, breakers.Do("other", succeed))
+	assert.Equal(t, calls, 1)
+
+	// A failed probe opens the breaker for another cooldown.
+	clock.Step(40 * time.Second)
+	_, open = breakers.Open("key")
+	assert.Assert(t, !open)
+	assert.Assert(t, errors.As(breakers.Do("key", fail), &openErr))
+	assert.Equal(t, openErr.RetryAfter, time.Minute)
+	assert.Equal(t, calls, 2)
+
+	// Only a single probe is allowed through a half-open breaker.
+	clock.Step(time.Minute)
+	assert.NilError(t, breakers.Do("key", func() error {
+		calls++
+		assert.Assert(t, errors.As(breakers.Do("key", succeed), &openErr))
+		return nil
+	}))
+	assert.Equal(t, calls, 3)
+
+	// A successful probe closes the breaker.
+	assert.NilError(t, breakers.Do("key", succeed))
+	assert.Equal(t, calls, 4)
+}
+
+func TestBreakersUnavailable(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	breakers := NewBreakers(1, time.Minute, clock)
+	all := func(string) bool { return true }
+
+	unavailable := &url.Error{Op: "Post", URL: "https://api.cloudflare.com", Err: errors.New("connection refused")}
+	fail := func() error { return unavailable }
+	succeed := func() error { return nil }
+
+	// Without any call, the API is not known to be unavailable.
+	assert.NilError(t, breakers.Unavailable(all))
+
+	_ = breakers.Do("key", fail)
+	clock.Step(20 * time.Second)
+	_ = breakers.Do("other", fail)
+
+	var openErr *CircuitOpenError
+	assert.Assert(t, errors.As(breakers.Unavailable(all), &openErr))
+	assert.Equal(t, openErr.RetryAfter, 40*time.Second)
+	assert.Equal(t, openErr.Err, error(unavailable))
+
+	// Breakers that are not included are ignored.
+	assert.NilError(t, breakers.Unavailable(func(key string) bool { return key == "ignored" }))
+
+	// A recent success makes the API available.
+	_ = breakers.Do("healthy", succeed)
+	assert.NilError(t, breakers.Unavailable(all))
+
+	// A breaker whose cooldown elapsed neither reports the API as available nor unavailable.
+	clock.Step(time.Minute)
+	_ = breakers.Do("other", fail)
+	assert.Assert(t, errors.As(breakers.Unavailable(all), &openErr))
+	assert.Equal(t, openErr.RetryAfter, time.Minute)
+
+	// Once idle for a cooldown, breakers are forgotten.
+	clock.Step(2 * time.Minute)
+	assert.NilError(t, breakers.Unavailable(all))
+	_, open := breakers.Open("other")
+	assert.Assert(t, !open)
+
+	assert.NilError(t, (*Breakers)(nil).Unavailable(all))
+}
+
+func TestBreakersCanceled(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	breakers := NewBreakers(1, time.Minute, clock)
+
+	canceled := &url.Error{Op: "Post", URL: "https://api.cloudflare.com", Err: context.Canceled}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, breakers.Do("key", func() error { return canceled }), error(canceled))
+	}
+
+	_, open := breakers.Open("key")
+	assert.Assert(t, !open)
+}
+
+func TestBreakersDisabled(t *testing.T) {
+	unavailable := &url.Error{Op: "Post", URL: "https://api.cloudflare.com", Err: errors.New("connection refused")}
+
+	for _, breakers := range []*Breakers{nil, NewBreakers(0, time.Minute, clocktesting.NewFakeClock(time.Now()))} {
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, breakers.Do("key", func() error { return unavailable }), error(unavailable))
+		}
+
+		_, open := breakers.Open("key")
+		assert.Assert(t, !open)
+	}
+}
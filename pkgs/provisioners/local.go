@@ -0,0 +1,103 @@
+package provisioners
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	"k8s.io/utils/clock"
+)
+
+// CertificateAuthority provides the CA certificate and key a LocalSigner signs certificates with.
+type CertificateAuthority interface {
+	CA(ctx context.Context) (*x509.Certificate, crypto.Signer, error)
+}
+
+// LocalSigner implements Signer with a local CA instead of the Cloudflare API. Certificates are shaped
+// like those issued by the Origin CA, with the same subject, extensions and validity, so only their
+// trust root differs.
+type LocalSigner struct {
+	ca    CertificateAuthority
+	clock clock.Clock
+}
+
+// NewLocalSigner returns a signer signing certificates with the CA provided by ca.
+func NewLocalSigner(ca CertificateAuthority, cl clock.Clock) *LocalSigner {
+	return &LocalSigner{ca: ca, clock: cl}
+}
+
+// originSubject is the subject of certificates issued by the Origin CA.
+var originSubject = pkix.Name{
+	Organization:       []string{"CloudFlare, Inc."},
+	OrganizationalUnit: []string{"CloudFlare Origin CA"},
+	CommonName:         "CloudFlare Origin Certificate",
+}
+
+// Sign signs the request like the Origin CA would, rejecting the requests the Cloudflare API rejects.
+func (s *LocalSigner) Sign(ctx context.Context, req *cfapi.SignRequest) (*cfapi.SignResponse, error) {
+	switch {
+	case len(req.Hostnames) == 0:
+		return nil, errors.New("local signing: request does not contain any hostnames")
+	case !slices.Contains(allowedValidty, req.Validity):
+		return nil, fmt.Errorf("local signing: validity %d is not one of %v", req.Validity, allowedValidty)
+	case req.Type != "origin-ecc" && req.Type != "origin-rsa":
+		return nil, fmt.Errorf("local signing: unknown request type %q", req.Type)
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes([]byte(req.CSR))
+	if err != nil {
+		return nil, fmt.Errorf("local signing: failed to decode CSR: %w", err)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("local signing: invalid CSR signature: %w", err)
+	}
+
+	caCert, caKey, err := s.ca.CA(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("local signing: failed to load CA: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 159))
+	if err != nil {
+		return nil, fmt.Errorf("local signing: failed to generate serial number: %w", err)
+	}
+
+	// The Origin CA issues certificates valid from the start of the current minute.
+	notBefore := s.clock.Now().UTC().Truncate(time.Minute)
+	notAfter := notBefore.Add(time.Duration(req.Validity) * 24 * time.Hour)
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               originSubject,
+		DNSNames:              req.Hostnames,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}, caCert, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("local signing: failed to sign certificate: %w", err)
+	}
+
+	return &cfapi.SignResponse{
+		Id:          serial.String(),
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Hostnames:   req.Hostnames,
+		Expiration:  notAfter,
+		Type:        req.Type,
+		Validity:    req.Validity,
+		CSR:         req.CSR,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package provisioners
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmgen "github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/cloudflare/origin-ca-issuer/internal/cfapi"
+	v1 "github.com/cloudflare/origin-ca-issuer/pkgs/apis/v1"
+	"github.com/go-logr/logr"
+	"gotest.tools/v3/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestLocalSigner(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	now := time.Date(2024, time.March, 1, 12, 34, 56, 0, time.UTC)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "local CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(20 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	assert.NilError(t, err)
+	caCert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+
+	ca := CAFunc(func(ctx context.Context) (*x509.Certificate, crypto.Signer, error) {
+		return caCert, caKey, nil
+	})
+
+	signer := NewLocalSigner(ca, clocktesting.NewFakeClock(now))
+
+	csrPEM, _, err := cmgen.CSR(x509.ECDSA, cmgen.SetCSRDNSNames("example.com", "*.example.com"))
+	assert.NilError(t, err)
+
+	p, err := New(signer, v1.RequestTypeOriginECC, logr.Discard())
+	assert.NilError(t, err)
+
+	resp, err := p.Issue(context.Background(), csrPEM, 10*24*time.Hour)
+	assert.NilError(t, err)
+
+	cert, err := pki.DecodeX509CertificateBytes([]byte(resp.Certificate))
+	assert.NilError(t, err)
+
+	assert.Equal(t, resp.Id, cert.SerialNumber.String())
+	assert.DeepEqual(t, resp.Hostnames, []string{"example.com", "*.example.com"})
+	assert.Equal(t, resp.Type, "origin-ecc")
+	assert.Equal(t, resp.Validity, 7)
+
+	assert.DeepEqual(t, cert.DNSNames, []string{"example.com", "*.example.com"})
+	assert.Equal(t, cert.Subject.CommonName, "CloudFlare Origin Certificate")
+	assert.Equal(t, cert.NotBefore, time.Date(2024, time.March, 1, 12, 34, 0, 0, time.UTC))
+	assert.Equal(t, cert.NotAfter, time.Date(2024, time.March, 8, 12, 34, 0, 0, time.UTC))
+	assert.Equal(t, resp.Expiration, cert.NotAfter)
+	assert.Assert(t, !cert.IsCA)
+	assert.NilError(t, cert.CheckSignatureFrom(caCert))
+}
+
+func TestLocalSigner_Error(t *testing.T) {
+	ca := CAFunc(func(ctx context.Context) (*x509.Certificate, crypto.Signer, error) {
+		t.Fatal("unexpected CA load")
+		return nil, nil, nil
+	})
+
+	signer := NewLocalSigner(ca, clocktesting.NewFakeClock(time.Now()))
+
+	csrPEM, _, err := cmgen.CSR(x509.ECDSA, cmgen.SetCSRDNSNames("example.com"))
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name  string
+		req   *cfapi.SignRequest
+		error string
+	}{
+		{
+			name:  "no hostnames",
+			req:   &cfapi.SignRequest{Validity: 7, Type: "origin-ecc", CSR: string(csrPEM)},
+			error: "local signing: request does not contain any hostnames",
+		},
+		{
+			name:  "invalid validity",
+			req:   &cfapi.SignRequest{Hostnames: []string{"example.com"}, Validity: 10, Type: "origin-ecc", CSR: string(csrPEM)},
+			error: "local signing: validity 10 is not one of [7 30 90 365 730 1095 5475]",
+		},
+		{
+			name:  "unknown type",
+			req:   &cfapi.SignRequest{Hostnames: []string{"example.com"}, Validity: 7, Type: "origin-dsa", CSR: string(csrPEM)},
+			error: `local signing: unknown request type "origin-dsa"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Sign(context.Background(), tt.req)
+			assert.Error(t, err, tt.error)
+		})
+	}
+}
+
+type CAFunc func(ctx context.Context) (*x509.Certificate, crypto.Signer, error)
+
+func (f CAFunc) CA(ctx context.Context) (*x509.Certificate, crypto.Signer, error) {
+	return f(ctx)
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
+	"time"
 
 	certmanager "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -22,6 +24,11 @@ const (
 
 var allowedValidty = []int{7, 30, 90, 365, 730, 1095, 5475}
 
+// AllowedValidities returns the validities, in days, supported by the Origin CA.
+func AllowedValidities() []int {
+	return slices.Clone(allowedValidty)
+}
+
 // Provisioner allows for CertificateRequests to be signed using the stored
 // Cloudflare API client.
 type Provisioner struct {
@@ -51,18 +58,31 @@ func New(client Signer, reqType v1.RequestType, log logr.Logger) (*Provisioner,
 // normalized to the closests validity allowed by the Cloudflare API, which make be significantly different
 // than the validity provided.
 func (p *Provisioner) Sign(ctx context.Context, cr *certmanager.CertificateRequest) (certPem []byte, err error) {
-	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	var duration time.Duration
+	if cr.Spec.Duration != nil {
+		duration = cr.Spec.Duration.Duration
+	}
+
+	resp, err := p.Issue(ctx, cr.Spec.Request, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(resp.Certificate), nil
+}
+
+// Issue uses the Cloudflare API to sign a PEM-encoded certificate signing request, valid for the
+// hostnames of its DNS subject alternative names. Like Sign, the duration is normalized to the closest
+// validity allowed by the Cloudflare API, and a zero duration requests the default validity. It returns
+// the complete response of the Cloudflare API, including the ID of the issued certificate.
+func (p *Provisioner) Issue(ctx context.Context, csrPEM []byte, duration time.Duration) (*cfapi.SignResponse, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
 	hostnames := csr.DNSNames
-	var duration int
-	if cr.Spec.Duration == nil {
-		duration = DefaultDurationInternval
-	} else {
-		duration = closest(int(cr.Spec.Duration.Duration.Hours()/24), allowedValidty)
-	}
+	validity := Validity(duration)
 
 	var reqType string
 	switch p.reqType {
@@ -74,16 +94,26 @@ func (p *Provisioner) Sign(ctx context.Context, cr *certmanager.CertificateReque
 
 	resp, err := p.client.Sign(ctx, &cfapi.SignRequest{
 		Hostnames: hostnames,
-		Validity:  duration,
+		Validity:  validity,
 		Type:      reqType,
-		CSR:       string(cr.Spec.Request),
+		CSR:       string(csrPEM),
 	})
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to sign request: %w", err)
 	}
 
-	return []byte(resp.Certificate), nil
+	return resp, nil
+}
+
+// Validity returns the validity, in days, of certificates requested for duration: the validity
+// supported by the Origin CA closest to duration, or the default validity if duration is zero.
+func Validity(duration time.Duration) int {
+	if duration == 0 {
+		return DefaultDurationInternval
+	}
+
+	return closest(int(duration.Hours()/24), allowedValidty)
 }
 
 func closest(of int, valid []int) int {